@@ -6,15 +6,22 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type config struct {
-	Db     *dbConfig
-	Server *serverConfig
-	Auth   *authConfig
-	Header string
+	Db          *dbConfig
+	Server      *serverConfig
+	Auth        *authConfig
+	Reaper      *reaperConfig
+	TestCases   *testCasesConfig `mapstructure:"test-cases"`
+	Attachments *attachmentsConfig
+	Projects    *projectsConfig
+	Retention   *retentionConfig
+	Quarantine  *quarantineConfig
+	Header      string
 }
 
 type dbConfig struct {
@@ -40,6 +47,57 @@ type authConfig struct {
 	ScopeClaimName      string `mapstructure:"scope-claim-name"`
 }
 
+type reaperConfig struct {
+	Enabled           bool          `mapstructure:"enabled"`
+	Interval          time.Duration `mapstructure:"interval"`
+	InProgressTimeout time.Duration `mapstructure:"in-progress-timeout"`
+}
+
+type testCasesConfig struct {
+	Fingerprint string `mapstructure:"fingerprint"`
+}
+
+type attachmentsConfig struct {
+	MaxSize   int64     `mapstructure:"max-size"`
+	Store     string    `mapstructure:"store"`
+	LocalPath string    `mapstructure:"local-path"`
+	S3        *s3Config `mapstructure:"s3"`
+}
+
+type s3Config struct {
+	Endpoint        string `mapstructure:"endpoint"`
+	Bucket          string `mapstructure:"bucket"`
+	Region          string `mapstructure:"region"`
+	AccessKeyID     string `mapstructure:"access-key-id"`
+	SecretAccessKey string `mapstructure:"secret-access-key"`
+	UsePathStyle    bool   `mapstructure:"use-path-style"`
+}
+
+type projectsConfig struct {
+	RequireRegistration bool `mapstructure:"require-registration"`
+}
+
+type retentionConfig struct {
+	Enabled          bool          `mapstructure:"enabled"`
+	Interval         time.Duration `mapstructure:"interval"`
+	Days             int           `mapstructure:"days"`
+	RunsPerBranch    int           `mapstructure:"runs-per-branch"`
+	BatchSize        int           `mapstructure:"batch-size"`
+	TrashGracePeriod time.Duration `mapstructure:"trash-grace-period"`
+}
+
+type quarantineConfig struct {
+	DefaultDuration time.Duration         `mapstructure:"default-duration"`
+	Auto            *autoQuarantineConfig `mapstructure:"auto"`
+}
+
+type autoQuarantineConfig struct {
+	Enabled   bool          `mapstructure:"enabled"`
+	Interval  time.Duration `mapstructure:"interval"`
+	Threshold float64       `mapstructure:"threshold"`
+	Duration  time.Duration `mapstructure:"duration"`
+}
+
 var configuration *config
 
 //go:embed config.yaml
@@ -88,6 +146,64 @@ func LoadConfig() (*config, error) {
 	if os.Getenv("SCOPE_CLAIM_NAME") != "" {
 		configuration.Auth.ScopeClaimName = os.Getenv("SCOPE_CLAIM_NAME")
 	}
+	if os.Getenv("FERN_IN_PROGRESS_TIMEOUT") != "" {
+		if timeout, err := time.ParseDuration(os.Getenv("FERN_IN_PROGRESS_TIMEOUT")); err == nil {
+			configuration.Reaper.InProgressTimeout = timeout
+		}
+	}
+	if os.Getenv("FERN_TEST_CASE_FINGERPRINT") != "" {
+		configuration.TestCases.Fingerprint = os.Getenv("FERN_TEST_CASE_FINGERPRINT")
+	}
+	if os.Getenv("FERN_ATTACHMENTS_MAX_SIZE") != "" {
+		if maxSize, err := strconv.ParseInt(os.Getenv("FERN_ATTACHMENTS_MAX_SIZE"), 10, 64); err == nil {
+			configuration.Attachments.MaxSize = maxSize
+		}
+	}
+	if os.Getenv("FERN_ATTACHMENTS_STORE") != "" {
+		configuration.Attachments.Store = os.Getenv("FERN_ATTACHMENTS_STORE")
+	}
+	if os.Getenv("FERN_ATTACHMENTS_PATH") != "" {
+		configuration.Attachments.LocalPath = os.Getenv("FERN_ATTACHMENTS_PATH")
+	}
+	if os.Getenv("FERN_S3_ENDPOINT") != "" {
+		configuration.Attachments.S3.Endpoint = os.Getenv("FERN_S3_ENDPOINT")
+	}
+	if os.Getenv("FERN_S3_BUCKET") != "" {
+		configuration.Attachments.S3.Bucket = os.Getenv("FERN_S3_BUCKET")
+	}
+	if os.Getenv("FERN_S3_REGION") != "" {
+		configuration.Attachments.S3.Region = os.Getenv("FERN_S3_REGION")
+	}
+	if os.Getenv("FERN_S3_USE_PATH_STYLE") != "" {
+		configuration.Attachments.S3.UsePathStyle, _ = strconv.ParseBool(os.Getenv("FERN_S3_USE_PATH_STYLE"))
+	}
+	if os.Getenv("AWS_ACCESS_KEY_ID") != "" {
+		configuration.Attachments.S3.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+	}
+	if os.Getenv("AWS_SECRET_ACCESS_KEY") != "" {
+		configuration.Attachments.S3.SecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+	}
+	if os.Getenv("FERN_REQUIRE_REGISTERED_PROJECTS") != "" {
+		configuration.Projects.RequireRegistration, _ = strconv.ParseBool(os.Getenv("FERN_REQUIRE_REGISTERED_PROJECTS"))
+	}
+	if os.Getenv("FERN_RETENTION_DAYS") != "" {
+		if days, err := strconv.Atoi(os.Getenv("FERN_RETENTION_DAYS")); err == nil {
+			configuration.Retention.Days = days
+		}
+	}
+	if os.Getenv("FERN_RETENTION_RUNS_PER_BRANCH") != "" {
+		if runs, err := strconv.Atoi(os.Getenv("FERN_RETENTION_RUNS_PER_BRANCH")); err == nil {
+			configuration.Retention.RunsPerBranch = runs
+		}
+	}
+	if os.Getenv("FERN_AUTO_QUARANTINE_ENABLED") != "" {
+		configuration.Quarantine.Auto.Enabled, _ = strconv.ParseBool(os.Getenv("FERN_AUTO_QUARANTINE_ENABLED"))
+	}
+	if os.Getenv("FERN_AUTO_QUARANTINE_THRESHOLD") != "" {
+		if threshold, err := strconv.ParseFloat(os.Getenv("FERN_AUTO_QUARANTINE_THRESHOLD"), 64); err == nil {
+			configuration.Quarantine.Auto.Threshold = threshold
+		}
+	}
 	if os.Getenv("FERN_HEADER_NAME") != "" {
 		configuration.Header = os.Getenv("FERN_HEADER_NAME")
 	}
@@ -107,6 +223,30 @@ func GetAuth() *authConfig {
 	return configuration.Auth
 }
 
+func GetReaper() *reaperConfig {
+	return configuration.Reaper
+}
+
+func GetTestCases() *testCasesConfig {
+	return configuration.TestCases
+}
+
+func GetAttachments() *attachmentsConfig {
+	return configuration.Attachments
+}
+
+func GetProjects() *projectsConfig {
+	return configuration.Projects
+}
+
 func GetHeaderName() string {
 	return configuration.Header
 }
+
+func GetRetention() *retentionConfig {
+	return configuration.Retention
+}
+
+func GetQuarantine() *quarantineConfig {
+	return configuration.Quarantine
+}
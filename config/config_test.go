@@ -6,6 +6,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
 	"os"
+	"time"
 )
 
 // Mock the file reading function
@@ -38,6 +39,24 @@ var _ = Describe("When LoadConfig is invoked", func() {
 			Expect(appConfig.Db.MaxOpenConns).To(Equal(100))
 			Expect(appConfig.Db.MaxIdleConns).To(Equal(10))
 			Expect(appConfig.Header).To(Equal("Fern Acceptance Test Report"))
+			Expect(appConfig.Reaper.Enabled).To(BeTrue())
+			Expect(appConfig.Reaper.Interval).To(Equal(time.Minute))
+			Expect(appConfig.Reaper.InProgressTimeout).To(Equal(6 * time.Hour))
+			Expect(appConfig.TestCases.Fingerprint).To(Equal("exact"))
+			Expect(appConfig.Attachments.MaxSize).To(Equal(int64(25 << 20)))
+			Expect(appConfig.Attachments.Store).To(Equal("local"))
+			Expect(appConfig.Attachments.LocalPath).To(Equal("attachments"))
+			Expect(appConfig.Attachments.S3.Region).To(Equal("us-east-1"))
+			Expect(appConfig.Attachments.S3.UsePathStyle).To(BeFalse())
+			Expect(appConfig.Projects.RequireRegistration).To(BeFalse())
+			Expect(appConfig.Retention.Enabled).To(BeTrue())
+			Expect(appConfig.Retention.Interval).To(Equal(time.Hour))
+			Expect(appConfig.Retention.Days).To(Equal(0))
+			Expect(appConfig.Retention.BatchSize).To(Equal(500))
+			Expect(appConfig.Retention.TrashGracePeriod).To(Equal(720 * time.Hour))
+			Expect(appConfig.Quarantine.DefaultDuration).To(Equal(336 * time.Hour))
+			Expect(appConfig.Quarantine.Auto.Enabled).To(BeFalse())
+			Expect(appConfig.Quarantine.Auto.Threshold).To(Equal(0.5))
 		})
 
 		It("should get non-nil DB", func() {
@@ -69,6 +88,19 @@ var _ = Describe("When LoadConfig is invoked", func() {
 		os.Setenv("FERN_PORT", "5432")
 		os.Setenv("FERN_DATABASE", "fern")
 		os.Setenv("FERN_HEADER_NAME", "Custom Fern Report Header")
+		os.Setenv("FERN_IN_PROGRESS_TIMEOUT", "90m")
+		os.Setenv("FERN_TEST_CASE_FINGERPRINT", "normalized")
+		os.Setenv("FERN_ATTACHMENTS_STORE", "s3")
+		os.Setenv("FERN_S3_ENDPOINT", "http://localhost:9000")
+		os.Setenv("FERN_S3_BUCKET", "fern")
+		os.Setenv("FERN_S3_USE_PATH_STYLE", "true")
+		os.Setenv("AWS_ACCESS_KEY_ID", "fern")
+		os.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+		os.Setenv("FERN_REQUIRE_REGISTERED_PROJECTS", "true")
+		os.Setenv("FERN_RETENTION_DAYS", "90")
+		os.Setenv("FERN_RETENTION_RUNS_PER_BRANCH", "20")
+		os.Setenv("FERN_AUTO_QUARANTINE_ENABLED", "true")
+		os.Setenv("FERN_AUTO_QUARANTINE_THRESHOLD", "0.7")
 
 		//v := viper.New()
 		result, err := config.LoadConfig()
@@ -85,6 +117,19 @@ var _ = Describe("When LoadConfig is invoked", func() {
 		Expect(result.Auth.ScopeClaimName).To(Equal("fern_scope"))
 		Expect(result.Header).To(Equal("Custom Fern Report Header"))
 		Expect(result.Header).To(Equal("Custom Fern Report Header"))
+		Expect(result.Reaper.InProgressTimeout).To(Equal(90 * time.Minute))
+		Expect(result.TestCases.Fingerprint).To(Equal("normalized"))
+		Expect(result.Attachments.Store).To(Equal("s3"))
+		Expect(result.Attachments.S3.Endpoint).To(Equal("http://localhost:9000"))
+		Expect(result.Attachments.S3.Bucket).To(Equal("fern"))
+		Expect(result.Attachments.S3.UsePathStyle).To(BeTrue())
+		Expect(result.Attachments.S3.AccessKeyID).To(Equal("fern"))
+		Expect(result.Attachments.S3.SecretAccessKey).To(Equal("secret"))
+		Expect(result.Projects.RequireRegistration).To(BeTrue())
+		Expect(result.Retention.Days).To(Equal(90))
+		Expect(result.Retention.RunsPerBranch).To(Equal(20))
+		Expect(result.Quarantine.Auto.Enabled).To(BeTrue())
+		Expect(result.Quarantine.Auto.Threshold).To(Equal(0.7))
 	})
 
 })
@@ -12,7 +12,10 @@ import (
 	"github.com/guidewire/fern-reporter/config"
 	"github.com/guidewire/fern-reporter/pkg/api/routers"
 	"github.com/guidewire/fern-reporter/pkg/auth"
+	"github.com/guidewire/fern-reporter/pkg/blobstore"
 	"github.com/guidewire/fern-reporter/pkg/db"
+	"github.com/guidewire/fern-reporter/pkg/jobs"
+	"github.com/guidewire/fern-reporter/pkg/quarantine"
 	"html/template"
 	"log"
 
@@ -26,11 +29,17 @@ import (
 
 //go:embed pkg/views/test_runs.html
 //go:embed pkg/views/insights.html
+//go:embed pkg/views/flaky.html
+//go:embed pkg/views/quarantine.html
+//go:embed pkg/views/failures.html
+//go:embed pkg/views/compare.html
 var testRunsTemplate embed.FS
 
 func main() {
 	initConfig()
 	initDb()
+	initBlobStore()
+	initJobs()
 	initServer()
 }
 
@@ -44,6 +53,35 @@ func initDb() {
 	db.Initialize()
 }
 
+func initBlobStore() {
+	blobstore.Initialize()
+}
+
+func initJobs() {
+	reaperConfig := config.GetReaper()
+	if reaperConfig.Enabled {
+		go jobs.StartRunReaper(context.Background(), db.GetDb(), reaperConfig.Interval, reaperConfig.InProgressTimeout)
+	} else {
+		log.Println("Run reaper is disabled, in-progress test runs will not be aborted.")
+	}
+
+	retentionConfig := config.GetRetention()
+	if retentionConfig.Enabled {
+		policy := jobs.RetentionPolicy{Days: retentionConfig.Days, RunsPerBranch: retentionConfig.RunsPerBranch, TrashGracePeriod: retentionConfig.TrashGracePeriod}
+		go jobs.StartRetentionPurge(context.Background(), db.GetDb(), blobstore.GetStore(), retentionConfig.Interval, policy, retentionConfig.BatchSize)
+	} else {
+		log.Println("Retention purge is disabled, expired test runs will not be deleted.")
+	}
+
+	autoQuarantineConfig := config.GetQuarantine().Auto
+	if autoQuarantineConfig.Enabled {
+		policy := quarantine.AutoPolicy{Threshold: autoQuarantineConfig.Threshold, Duration: autoQuarantineConfig.Duration}
+		go jobs.StartAutoQuarantine(context.Background(), db.GetDb(), autoQuarantineConfig.Interval, policy)
+	} else {
+		log.Println("Auto quarantine is disabled, flaky specs will only be quarantined by hand.")
+	}
+}
+
 func initServer() {
 	serverConfig := config.GetServer()
 	gin.SetMode(gin.DebugMode)
@@ -67,9 +105,10 @@ func initServer() {
 	funcMap := template.FuncMap{
 		"CalculateDuration": utils.CalculateDuration,
 		"FormatDate":        utils.FormatDate,
+		"SpecTree":          utils.SpecTree,
 	}
 
-	templ, err := template.New("").Funcs(funcMap).ParseFS(testRunsTemplate, "pkg/views/test_runs.html", "pkg/views/insights.html")
+	templ, err := template.New("").Funcs(funcMap).ParseFS(testRunsTemplate, "pkg/views/test_runs.html", "pkg/views/insights.html", "pkg/views/flaky.html", "pkg/views/quarantine.html", "pkg/views/failures.html", "pkg/views/compare.html")
 	if err != nil {
 		log.Fatalf("error parsing templates: %v", err)
 	}
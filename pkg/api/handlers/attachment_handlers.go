@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/config"
+	"github.com/guidewire/fern-reporter/pkg/blobstore"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// multipartOverhead is allowed on top of the attachment size limit for
+	// the boundaries and headers of the multipart form.
+	multipartOverhead = 1 << 20
+	maxFileNameLength = 255
+	sniffLength       = 512
+)
+
+// WithBlobStore sets the store that keeps the contents of attachments.
+func (h *Handler) WithBlobStore(store blobstore.Store) *Handler {
+	h.blobs = store
+	return h
+}
+
+// UploadAttachment stores the multipart field "file" as an attachment of a
+// test run. The form field spec_run_id attaches it to one of the spec runs of
+// the run instead. The content type is sniffed from the content, falling back
+// to the file extension when the content does not tell.
+func (h *Handler) UploadAttachment(c *gin.Context) {
+	if h.blobs == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "attachments are not configured"})
+		return
+	}
+	var testRun models.TestRun
+	if err := h.db.Select("id", "test_project_name").Where("id = ?", c.Param("id")).First(&testRun).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "test run not found"})
+		return
+	}
+	if rejectOutOfScopeProject(c, testRun.TestProjectName) {
+		return
+	}
+
+	maxSize := config.GetAttachments().MaxSize
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+multipartOverhead)
+	header, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("attachments must not be larger than %d bytes", maxSize)})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "the attachment must be sent as the multipart field \"file\""})
+		return
+	}
+	if header.Size > maxSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("attachments must not be larger than %d bytes", maxSize)})
+		return
+	}
+	fileName := path.Base(strings.ReplaceAll(header.Filename, "\\", "/"))
+	if fileName == "." || fileName == "/" || len(fileName) > maxFileNameLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("file name must be between 1 and %d characters", maxFileNameLength)})
+		return
+	}
+
+	attachment := models.Attachment{
+		TestRunID: testRun.ID,
+		FileName:  fileName,
+		Size:      header.Size,
+	}
+	if value := c.PostForm("spec_run_id"); value != "" {
+		specRunID, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "spec_run_id must be a number"})
+			return
+		}
+		var count int64
+		h.db.Table("spec_runs").
+			Joins("INNER JOIN suite_runs ON suite_runs.id = spec_runs.suite_id").
+			Where("spec_runs.id = ? AND suite_runs.test_run_id = ?", specRunID, testRun.ID).
+			Count(&count)
+		if count == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "spec run not found in test run"})
+			return
+		}
+		attachment.SpecRunID = &specRunID
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "error reading attachment"})
+		return
+	}
+	defer file.Close()
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "error reading attachment"})
+		return
+	}
+	head = head[:n]
+	attachment.ContentType = detectContentType(head, fileName)
+
+	attachment.StorageKey, err = newStorageKey(testRun.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error storing attachment"})
+		return
+	}
+	ctx := c.Request.Context()
+	content := io.MultiReader(bytes.NewReader(head), file)
+	if err := h.blobs.Put(ctx, attachment.StorageKey, content, attachment.Size, attachment.ContentType); err != nil {
+		log.Printf("error storing attachment of test run %d: %v", testRun.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error storing attachment"})
+		return
+	}
+	if err := h.db.Create(&attachment).Error; err != nil {
+		blobstore.DeleteAll(ctx, h.blobs, []string{attachment.StorageKey})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error saving attachment"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, &attachment)
+}
+
+// GetTestRunAttachments lists the attachments of a test run, including those
+// of its spec runs.
+func (h *Handler) GetTestRunAttachments(c *gin.Context) {
+	attachments := []models.Attachment{}
+	if err := h.db.Scopes(liveAttachments).Where("attachments.test_run_id = ?", c.Param("id")).Order("attachments.id").Find(&attachments).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error loading attachments"})
+		return
+	}
+	c.JSON(http.StatusOK, attachments)
+}
+
+// DownloadAttachment sends the content of an attachment. Only images and
+// plain text are shown inline; everything else is downloaded, so that
+// uploaded HTML or scripts never run in the context of the reporter.
+func (h *Handler) DownloadAttachment(c *gin.Context) {
+	var attachment models.Attachment
+	if err := h.db.Scopes(liveAttachments).Where("attachments.id = ?", c.Param("id")).First(&attachment).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "attachment not found"})
+		return
+	}
+	if h.blobs == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "attachments are not configured"})
+		return
+	}
+	content, err := h.blobs.Get(c.Request.Context(), attachment.StorageKey)
+	if errors.Is(err, blobstore.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "attachment content not found"})
+		return
+	} else if err != nil {
+		log.Printf("error reading attachment %d: %v", attachment.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error reading attachment"})
+		return
+	}
+	defer content.Close()
+
+	disposition := "attachment"
+	if attachment.IsImage() || strings.HasPrefix(attachment.ContentType, "text/plain") {
+		disposition = "inline"
+	}
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType(disposition, map[string]string{"filename": attachment.FileName}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// DeleteAttachment removes an attachment and its content.
+func (h *Handler) DeleteAttachment(c *gin.Context) {
+	var attachment models.Attachment
+	if err := h.db.Scopes(inScopeAttachments(c)).Where("id = ?", c.Param("id")).First(&attachment).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "attachment not found"})
+		return
+	}
+	if err := h.db.Delete(&attachment).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error deleting attachment"})
+		return
+	}
+	blobstore.DeleteAll(c, h.blobs, []string{attachment.StorageKey})
+	c.JSON(http.StatusOK, &attachment)
+}
+
+// liveAttachments hides the attachments of test runs in the trash.
+func liveAttachments(db *gorm.DB) *gorm.DB {
+	return db.Joins("INNER JOIN test_runs ON test_runs.id = attachments.test_run_id").Scopes(models.LiveTestRuns)
+}
+
+// loadAttachments returns the attachments of the test runs, keyed by the ID of
+// their spec run, and those that belong to no spec run, keyed by the ID of
+// their test run.
+func (h *Handler) loadAttachments(testRuns []models.TestRun) (specAttachments, runAttachments map[uint64][]models.Attachment) {
+	specAttachments = map[uint64][]models.Attachment{}
+	runAttachments = map[uint64][]models.Attachment{}
+	var ids []uint64
+	for _, testRun := range testRuns {
+		if testRun.ID != 0 {
+			ids = append(ids, testRun.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return specAttachments, runAttachments
+	}
+
+	var attachments []models.Attachment
+	if err := h.db.Where("test_run_id IN ?", ids).Order("id").Find(&attachments).Error; err != nil {
+		log.Printf("error loading attachments: %v", err)
+		return specAttachments, runAttachments
+	}
+	for _, attachment := range attachments {
+		if attachment.SpecRunID != nil {
+			specAttachments[*attachment.SpecRunID] = append(specAttachments[*attachment.SpecRunID], attachment)
+		} else {
+			runAttachments[attachment.TestRunID] = append(runAttachments[attachment.TestRunID], attachment)
+		}
+	}
+	return specAttachments, runAttachments
+}
+
+// detectContentType sniffs the content type from the first bytes of a file.
+// When the content is not recognized, the type registered for the file
+// extension is used instead.
+func detectContentType(head []byte, fileName string) string {
+	contentType := http.DetectContentType(head)
+	if contentType == "application/octet-stream" {
+		if byExtension := mime.TypeByExtension(path.Ext(fileName)); byExtension != "" {
+			return byExtension
+		}
+	}
+	return contentType
+}
+
+func newStorageKey(testRunID uint64) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("testruns/%d/%s", testRunID, hex.EncodeToString(random)), nil
+}
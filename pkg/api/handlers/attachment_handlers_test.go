@@ -0,0 +1,227 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/config"
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/blobstore"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func multipartUpload(fileName string, content []byte, fields map[string]string) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for name, value := range fields {
+		Expect(writer.WriteField(name, value)).To(Succeed())
+	}
+	part, err := writer.CreateFormFile("file", fileName)
+	Expect(err).NotTo(HaveOccurred())
+	_, err = part.Write(content)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(writer.Close()).To(Succeed())
+
+	req, _ := http.NewRequest("POST", "/api/testrun/3/attachments", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+var _ = Describe("Attachment handlers", func() {
+	selectTestRunID := regexp.QuoteMeta(`SELECT "id","test_project_name" FROM "test_runs" WHERE id = $1 AND "test_runs"."deleted_at" IS NULL ORDER BY "test_runs"."id" LIMIT $2`)
+
+	var (
+		root  string
+		store *blobstore.LocalStore
+	)
+
+	BeforeEach(func() {
+		_, err := config.LoadConfig()
+		Expect(err).NotTo(HaveOccurred())
+
+		root = GinkgoT().TempDir()
+		store, err = blobstore.NewLocalStore(root)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	storedFiles := func() []string {
+		var files []string
+		Expect(filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err == nil && !info.IsDir() {
+				files = append(files, path)
+			}
+			return err
+		})).To(Succeed())
+		return files
+	}
+
+	Context("when UploadAttachment handler is invoked", func() {
+		It("should store the file and record it with the sniffed content type", func() {
+			mock.ExpectQuery(selectTestRunID).
+				WithArgs("3", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "spec_runs" INNER JOIN suite_runs ON suite_runs.id = spec_runs.suite_id WHERE spec_runs.id = $1 AND suite_runs.test_run_id = $2`)).
+				WithArgs(30, 3).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "attachments" ("test_run_id","spec_run_id","file_name","content_type","size","storage_key","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "id"`)).
+				WithArgs(3, 30, "screenshot.txt", "image/png", len(pngHeader), sqlmock.AnyArg(), sqlmock.AnyArg()).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11))
+			mock.ExpectCommit()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "3"}}
+			c.Request = multipartUpload("screenshot.txt", pngHeader, map[string]string{"spec_run_id": "30"})
+
+			handlers.NewHandler(gormDb).WithBlobStore(store).UploadAttachment(c)
+
+			Expect(w.Code).To(Equal(http.StatusCreated))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+			var attachment models.Attachment
+			Expect(json.Unmarshal(w.Body.Bytes(), &attachment)).To(Succeed())
+			Expect(attachment.ID).To(Equal(uint64(11)))
+			Expect(*attachment.SpecRunID).To(Equal(uint64(30)))
+			Expect(attachment.ContentType).To(Equal("image/png"))
+			Expect(w.Body.String()).NotTo(ContainSubstring("storage"))
+
+			files := storedFiles()
+			Expect(files).To(HaveLen(1))
+			Expect(filepath.ToSlash(files[0])).To(ContainSubstring("/testruns/3/"))
+			Expect(os.ReadFile(files[0])).To(Equal(pngHeader))
+		})
+
+		It("should reject files larger than the configured limit", func() {
+			config.GetAttachments().MaxSize = 4
+
+			mock.ExpectQuery(selectTestRunID).
+				WithArgs("3", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "3"}}
+			c.Request = multipartUpload("build.log", []byte("too long"), nil)
+
+			handlers.NewHandler(gormDb).WithBlobStore(store).UploadAttachment(c)
+
+			Expect(w.Code).To(Equal(http.StatusRequestEntityTooLarge))
+			Expect(w.Body.String()).To(ContainSubstring("must not be larger than 4 bytes"))
+			Expect(storedFiles()).To(BeEmpty())
+		})
+
+		It("should reject spec runs of other test runs", func() {
+			mock.ExpectQuery(selectTestRunID).
+				WithArgs("3", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "spec_runs"`)).
+				WithArgs(99, 3).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "3"}}
+			c.Request = multipartUpload("build.log", []byte("ok"), map[string]string{"spec_run_id": "99"})
+
+			handlers.NewHandler(gormDb).WithBlobStore(store).UploadAttachment(c)
+
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+			Expect(storedFiles()).To(BeEmpty())
+		})
+	})
+
+	Context("when DownloadAttachment handler is invoked", func() {
+		download := func(contentType string) *httptest.ResponseRecorder {
+			Expect(store.Put(context.Background(), "testruns/3/abc", strings.NewReader("<b>hi</b>"), 9, contentType)).To(Succeed())
+			mock.ExpectQuery(regexp.QuoteMeta(`FROM "attachments" INNER JOIN test_runs ON test_runs.id = attachments.test_run_id `+
+				`WHERE attachments.id = $1 AND test_runs.deleted_at IS NULL ORDER BY "attachments"."id" LIMIT $2`)).
+				WithArgs("11", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id", "file_name", "content_type", "size", "storage_key"}).
+					AddRow(11, 3, "page.html", contentType, 9, "testruns/3/abc"))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "11"}}
+			c.Request, _ = http.NewRequest("GET", "/api/attachments/11", nil)
+
+			handlers.NewHandler(gormDb).WithBlobStore(store).DownloadAttachment(c)
+			return w
+		}
+
+		It("should send plain text inline", func() {
+			w := download("text/plain; charset=utf-8")
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(Equal("<b>hi</b>"))
+			Expect(w.Header().Get("Content-Type")).To(Equal("text/plain; charset=utf-8"))
+			Expect(w.Header().Get("Content-Disposition")).To(Equal(`inline; filename=page.html`))
+			Expect(w.Header().Get("X-Content-Type-Options")).To(Equal("nosniff"))
+		})
+
+		It("should never show HTML inline", func() {
+			w := download("text/html; charset=utf-8")
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Header().Get("Content-Disposition")).To(Equal(`attachment; filename=page.html`))
+		})
+	})
+
+	Context("when GetTestRunAttachments handler is invoked", func() {
+		It("should only list the attachments of live test runs", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`FROM "attachments" INNER JOIN test_runs ON test_runs.id = attachments.test_run_id ` +
+				`WHERE attachments.test_run_id = $1 AND test_runs.deleted_at IS NULL ORDER BY attachments.id`)).
+				WithArgs("3").
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "3"}}
+			c.Request, _ = http.NewRequest("GET", "/api/testrun/3/attachments", nil)
+
+			handlers.NewHandler(gormDb).GetTestRunAttachments(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			Expect(w.Body.String()).To(Equal("[]"))
+		})
+	})
+
+	Context("when the owning test run is deleted", func() {
+		It("should keep the contents of its attachments while it is in the trash", func() {
+			Expect(store.Put(context.Background(), "testruns/3/abc", strings.NewReader("log"), 3, "text/plain")).To(Succeed())
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_runs" SET "deleted_at"=$1,"deleted_by"=$2 WHERE "test_runs"."deleted_at" IS NULL AND "id" = $3`)).
+				WithArgs(sqlmock.AnyArg(), "", 3).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "3"}}
+
+			handlers.NewHandler(gormDb).WithBlobStore(store).DeleteTestRun(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			_, err := store.Get(context.Background(), "testruns/3/abc")
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,338 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/quarantine"
+	"github.com/guidewire/fern-reporter/pkg/validation"
+	"gorm.io/gorm"
+)
+
+const (
+	// bulkBatchSize is the number of test runs stored per transaction
+	bulkBatchSize = 100
+	// maxBulkLineSize bounds the size of a single test run in a bulk upload
+	maxBulkLineSize = 32 << 20
+)
+
+// BulkLineResult reports the outcome of one line of a bulk upload. Exactly
+// one of ID and Error is set, or of Valid and Error in a dry run; Fields lists
+// the invalid fields of the line.
+type BulkLineResult struct {
+	Line     int               `json:"line"`
+	ID       uint64            `json:"id,omitempty"`
+	Existing bool              `json:"existing,omitempty"`
+	Valid    bool              `json:"valid,omitempty"`
+	Error    string            `json:"error,omitempty"`
+	Fields   validation.Errors `json:"fields,omitempty"`
+}
+
+// BulkReport is the response of a bulk upload.
+type BulkReport struct {
+	Created int              `json:"created"`
+	Failed  int              `json:"failed"`
+	Results []BulkLineResult `json:"results"`
+	Error   string           `json:"error,omitempty"`
+}
+
+type bulkLine struct {
+	line int
+	// data is kept to store the run again on its own when its batch fails
+	data    []byte
+	testRun *models.TestRun
+}
+
+// CreateTestRunsBulk stores newline-delimited test runs, as used for
+// backfilling historical data. The body may be gzip compressed and is read as
+// a stream; runs are inserted in batches and every line gets a result. With
+// ?dryRun=true the lines are only validated.
+func (h *Handler) CreateTestRunsBulk(c *gin.Context) {
+	dryRun := isDryRun(c)
+	body, err := bulkBodyReader(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report := BulkReport{Results: []BulkLineResult{}}
+	tags := map[string]models.Tag{}
+	unregistered := map[string]bool{}
+	var batch []bulkLine
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxBulkLineSize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		testRun, err := decodeBulkTestRun(data)
+		if err != nil {
+			result := BulkLineResult{Line: line, Error: err.Error()}
+			if errors.As(err, &result.Fields) {
+				result.Error = "invalid test run"
+			}
+			report.add(result)
+			continue
+		}
+
+		projectName := testRun.TestProjectName
+		if !projectInScope(c, projectName) {
+			report.add(BulkLineResult{Line: line, Error: outOfScopeProjectMessage})
+			continue
+		}
+		rejected, checked := unregistered[projectName]
+		if !checked {
+			if rejected, err = h.unregisteredProject(projectName); err != nil {
+				report.add(BulkLineResult{Line: line, Error: "error loading project"})
+				continue
+			}
+			unregistered[projectName] = rejected
+		}
+		if rejected {
+			report.add(BulkLineResult{Line: line, Error: unregisteredProjectMessage(projectName)})
+			continue
+		}
+		if dryRun {
+			report.add(BulkLineResult{Line: line, Valid: true})
+			continue
+		}
+
+		batch = append(batch, bulkLine{line: line, data: append([]byte(nil), data...), testRun: testRun})
+		if len(batch) == bulkBatchSize {
+			h.saveBulkBatch(batch, tags, &report)
+			batch = batch[:0]
+		}
+	}
+	h.saveBulkBatch(batch, tags, &report)
+
+	if err := scanner.Err(); err != nil {
+		report.Error = fmt.Sprintf("error reading line %d: %v", line+1, err)
+		c.JSON(http.StatusBadRequest, report)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// bulkBodyReader transparently decompresses gzip bodies, detected by their
+// magic number so clients do not need to set Content-Encoding.
+func bulkBodyReader(body io.Reader) (io.Reader, error) {
+	buffered := bufio.NewReader(body)
+	magic, err := buffered.Peek(2)
+	if err != nil || magic[0] != 0x1f || magic[1] != 0x8b {
+		return buffered, nil
+	}
+
+	gz, err := gzip.NewReader(buffered)
+	if err != nil {
+		return nil, fmt.Errorf("error reading gzip body: %w", err)
+	}
+	return gz, nil
+}
+
+func decodeBulkTestRun(data []byte) (*models.TestRun, error) {
+	var testRun models.TestRun
+	if err := json.Unmarshal(data, &testRun); err != nil {
+		return nil, fmt.Errorf("invalid test run: %v", err)
+	}
+	if testRun.ID != 0 {
+		return nil, errors.New("id must not be set for new test runs")
+	}
+	if testRun.IdempotencyKey != nil && *testRun.IdempotencyKey == "" {
+		testRun.IdempotencyKey = nil
+	}
+	if err := validation.ValidateTestRun(&testRun); err != nil {
+		return nil, err
+	}
+	// Backfilled runs predate the active quarantine rules
+	quarantine.Clear(&testRun)
+	completeTestRun(&testRun)
+	return &testRun, nil
+}
+
+// saveBulkBatch stores a batch of test runs in one transaction. Tags are
+// resolved through the cache shared by the whole upload, so each distinct tag
+// is looked up at most once.
+func (h *Handler) saveBulkBatch(batch []bulkLine, tags map[string]models.Tag, report *BulkReport) {
+	if len(batch) == 0 {
+		return
+	}
+
+	// Runs uploaded before are reported instead of being stored again
+	existing, err := h.findBulkExisting(batch)
+	if err != nil {
+		for _, item := range batch {
+			report.add(BulkLineResult{Line: item.line, Error: "error saving record"})
+		}
+		return
+	}
+
+	var pending []bulkLine
+	var testRuns []*models.TestRun
+	seen := map[string]bool{}
+	for _, item := range batch {
+		if key := item.testRun.IdempotencyKey; key != nil {
+			if id, ok := existing[*key]; ok {
+				report.add(BulkLineResult{Line: item.line, ID: id, Existing: true})
+				continue
+			}
+			if seen[*key] {
+				report.add(BulkLineResult{Line: item.line, Error: "duplicate idempotency_key in upload"})
+				continue
+			}
+			seen[*key] = true
+		}
+		pending = append(pending, item)
+		testRuns = append(testRuns, item.testRun)
+	}
+	if len(testRuns) == 0 {
+		return
+	}
+
+	if err := h.storeBulkRuns(testRuns, tags); err != nil {
+		if len(pending) == 1 {
+			report.add(BulkLineResult{Line: pending[0].line, Error: "error saving record"})
+			return
+		}
+		// One bad run rolls back the whole batch, so each run is stored again
+		// on its own to only report the bad ones
+		for _, item := range pending {
+			h.saveBulkLine(item, tags, report)
+		}
+		return
+	}
+	for _, item := range pending {
+		report.add(BulkLineResult{Line: item.line, ID: item.testRun.ID})
+	}
+}
+
+// saveBulkLine stores the run of a line of a failed batch in a transaction of
+// its own. The run is decoded again, as the failed batch left the IDs it
+// assigned behind.
+func (h *Handler) saveBulkLine(item bulkLine, tags map[string]models.Tag, report *BulkReport) {
+	testRun, err := decodeBulkTestRun(item.data)
+	if err == nil {
+		err = h.storeBulkRuns([]*models.TestRun{testRun}, tags)
+	}
+	if err != nil {
+		report.add(BulkLineResult{Line: item.line, Error: "error saving record"})
+		return
+	}
+	report.add(BulkLineResult{Line: item.line, ID: testRun.ID})
+}
+
+// storeBulkRuns stores test runs with their tags and test cases in one
+// transaction.
+func (h *Handler) storeBulkRuns(testRuns []*models.TestRun, tags map[string]models.Tag) error {
+	created := map[string]models.Tag{}
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := resolveBulkTags(tx, testRuns, tags, created); err != nil {
+			return err
+		}
+		if err := resolveTestCases(tx, testRuns...); err != nil {
+			return err
+		}
+		return tx.CreateInBatches(testRuns, bulkBatchSize).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	// Only cache tags once the transaction that created them is committed
+	for name, tag := range created {
+		tags[name] = tag
+	}
+	return nil
+}
+
+func (h *Handler) findBulkExisting(batch []bulkLine) (map[string]uint64, error) {
+	var keys []string
+	for _, item := range batch {
+		if item.testRun.IdempotencyKey != nil {
+			keys = append(keys, *item.testRun.IdempotencyKey)
+		}
+	}
+	existing := map[string]uint64{}
+	if len(keys) == 0 {
+		return existing, nil
+	}
+
+	var testRuns []models.TestRun
+	if err := h.db.Unscoped().Select("id", "idempotency_key").Where("idempotency_key IN ?", keys).Find(&testRuns).Error; err != nil {
+		return nil, err
+	}
+	for _, testRun := range testRuns {
+		existing[*testRun.IdempotencyKey] = testRun.ID
+	}
+	return existing, nil
+}
+
+// resolveBulkTags replaces the tags of every spec run with stored tags. Names
+// missing from the cache are upserted with a single statement and recorded in
+// created.
+func resolveBulkTags(tx *gorm.DB, testRuns []*models.TestRun, cache, created map[string]models.Tag) error {
+	var missing []models.Tag
+	for _, testRun := range testRuns {
+		for _, suiteRun := range testRun.SuiteRuns {
+			for _, specRun := range suiteRun.SpecRuns {
+				for _, tag := range specRun.Tags {
+					if _, ok := cache[tag.Name]; ok {
+						continue
+					}
+					if _, ok := created[tag.Name]; ok {
+						continue
+					}
+					created[tag.Name] = models.Tag{}
+					missing = append(missing, models.Tag{Name: tag.Name})
+				}
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		if err := tx.Clauses(tagUpsert).Create(&missing).Error; err != nil {
+			return err
+		}
+		for _, tag := range missing {
+			created[tag.Name] = tag
+		}
+	}
+
+	for _, testRun := range testRuns {
+		for i := range testRun.SuiteRuns {
+			for j := range testRun.SuiteRuns[i].SpecRuns {
+				specRun := &testRun.SuiteRuns[i].SpecRuns[j]
+				for k, tag := range specRun.Tags {
+					if stored, ok := cache[tag.Name]; ok {
+						specRun.Tags[k] = stored
+					} else {
+						specRun.Tags[k] = created[tag.Name]
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (r *BulkReport) add(result BulkLineResult) {
+	if result.Error != "" {
+		r.Failed++
+	} else if !result.Existing && !result.Valid {
+		r.Created++
+	}
+	r.Results = append(r.Results, result)
+}
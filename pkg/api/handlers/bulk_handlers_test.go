@@ -0,0 +1,169 @@
+package handlers_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/validation"
+)
+
+var _ = Describe("Bulk handlers", func() {
+	Context("when CreateTestRunsBulk handler is invoked", func() {
+		It("should store valid lines in one batch and report every line", func() {
+			body := strings.Join([]string{
+				`{"test_project_name":"Checkout","suite_runs":[{"suite_name":"Cart","spec_runs":[{"spec_description":"adds an item","status":"passed","tags":[{"name":"smoke"}]}]}]}`,
+				`{"test_project_name":`,
+				``,
+				`{"test_seed":1}`,
+			}, "\n")
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "tags" ("name") VALUES ($1) ON CONFLICT ("name") DO UPDATE SET "name"="excluded"."name" RETURNING "id"`)).
+				WithArgs("smoke").
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "test_cases"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "merged_into_id"}).AddRow(41, nil))
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "test_runs"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11))
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "suite_runs"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(21))
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "spec_runs"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(31))
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "tags"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "spec_run_tags"`)).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/api/testrun/bulk", strings.NewReader(body))
+
+			handler := handlers.NewHandler(gormDb)
+			handler.CreateTestRunsBulk(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+			var report handlers.BulkReport
+			Expect(json.Unmarshal(w.Body.Bytes(), &report)).To(Succeed())
+			Expect(report.Created).To(Equal(1))
+			Expect(report.Failed).To(Equal(2))
+			Expect(report.Results).To(HaveLen(3))
+			Expect(report.Results[0].Line).To(Equal(2))
+			Expect(report.Results[0].Error).To(ContainSubstring("invalid test run"))
+			Expect(report.Results[1].Error).To(Equal("invalid test run"))
+			Expect(report.Results[1].Fields).To(ConsistOf(validation.FieldError{Path: "test_project_name", Message: "is required"}))
+			Expect(report.Results[2]).To(Equal(handlers.BulkLineResult{Line: 1, ID: 11}))
+		})
+
+		It("should reject the runs of projects the token may not write to", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Set("fernProjectName", "Checkout")
+			c.Request, _ = http.NewRequest("POST", "/api/testrun/bulk", strings.NewReader(`{"test_project_name":"Search"}`))
+
+			handlers.NewHandler(gormDb).CreateTestRunsBulk(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var report handlers.BulkReport
+			Expect(json.Unmarshal(w.Body.Bytes(), &report)).To(Succeed())
+			Expect(report.Failed).To(Equal(1))
+			Expect(report.Results).To(Equal([]handlers.BulkLineResult{{Line: 1, Error: "project name does not match fern project scope claim"}}))
+		})
+
+		It("with ?dryRun=true, it should validate every line without storing any", func() {
+			body := `{"test_project_name":"Checkout"}` + "\n" + `{"test_seed":1}`
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/api/testrun/bulk?dryRun=true", strings.NewReader(body))
+
+			handlers.NewHandler(gormDb).CreateTestRunsBulk(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			var report handlers.BulkReport
+			Expect(json.Unmarshal(w.Body.Bytes(), &report)).To(Succeed())
+			Expect(report.Created).To(Equal(0))
+			Expect(report.Failed).To(Equal(1))
+			Expect(report.Results).To(HaveLen(2))
+			Expect(report.Results[0]).To(Equal(handlers.BulkLineResult{Line: 1, Valid: true}))
+			Expect(report.Results[1].Error).To(Equal("invalid test run"))
+		})
+
+		It("with a gzip body, it should report runs that were already uploaded", func() {
+			var body bytes.Buffer
+			gz := gzip.NewWriter(&body)
+			_, err := gz.Write([]byte(`{"test_project_name":"Checkout","idempotency_key":"run-1"}` + "\n"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gz.Close()).To(Succeed())
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id","idempotency_key" FROM "test_runs" WHERE idempotency_key IN ($1)`)).
+				WithArgs("run-1").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "idempotency_key"}).AddRow(5, "run-1"))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/api/testrun/bulk", &body)
+
+			handler := handlers.NewHandler(gormDb)
+			handler.CreateTestRunsBulk(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+			var report handlers.BulkReport
+			Expect(json.Unmarshal(w.Body.Bytes(), &report)).To(Succeed())
+			Expect(report.Created).To(Equal(0))
+			Expect(report.Results).To(Equal([]handlers.BulkLineResult{{Line: 1, ID: 5, Existing: true}}))
+		})
+
+		It("and the batch cannot be saved, it should only report an error for the lines that cannot be saved on their own", func() {
+			body := `{"test_project_name":"A"}` + "\n" + `{"test_project_name":"B"}`
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "test_runs"`)).
+				WillReturnError(sqlmock.ErrCancelled)
+			mock.ExpectRollback()
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "test_runs"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+			mock.ExpectCommit()
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "test_runs"`)).
+				WillReturnError(sqlmock.ErrCancelled)
+			mock.ExpectRollback()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/api/testrun/bulk", strings.NewReader(body))
+
+			handler := handlers.NewHandler(gormDb)
+			handler.CreateTestRunsBulk(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var report handlers.BulkReport
+			Expect(json.Unmarshal(w.Body.Bytes(), &report)).To(Succeed())
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			Expect(report.Created).To(Equal(1))
+			Expect(report.Failed).To(Equal(1))
+			Expect(report.Results).To(Equal([]handlers.BulkLineResult{
+				{Line: 1, ID: 7},
+				{Line: 2, Error: "error saving record"},
+			}))
+		})
+	})
+})
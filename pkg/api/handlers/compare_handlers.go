@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/compare"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+// CompareTestRuns diffs the specs of the ?head= test run against the ?base=
+// test run. Specs are matched by their test case, or else by their suite,
+// containers and description.
+func (h *Handler) CompareTestRuns(c *gin.Context) {
+	base, head, ok := h.comparedTestRuns(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, compare.Runs(base, head))
+}
+
+// CompareTestRunsHTML renders the comparison of two test runs, with the same
+// parameters as CompareTestRuns.
+func (h *Handler) CompareTestRunsHTML(c *gin.Context) {
+	base, head, ok := h.comparedTestRuns(c)
+	if !ok {
+		return
+	}
+	c.HTML(http.StatusOK, "compare.html", gin.H{
+		"reportHeader": h.reportHeader(base.TestProjectName, head.TestProjectName),
+		"comparison":   compare.Runs(base, head),
+	})
+}
+
+// comparedTestRuns loads the base and head test runs of a comparison,
+// responding with 400 Bad Request when an id is invalid and 404 Not Found
+// when a test run does not exist.
+func (h *Handler) comparedTestRuns(c *gin.Context) (base, head models.TestRun, ok bool) {
+	baseID, err := strconv.ParseUint(c.Query("base"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "base must be a test run id"})
+		return base, head, false
+	}
+	headID, err := strconv.ParseUint(c.Query("head"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "head must be a test run id"})
+		return base, head, false
+	}
+
+	if err := h.db.Preload("SuiteRuns.SpecRuns").Where("id = ?", baseID).First(&base).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "base test run not found"})
+		return base, head, false
+	}
+	if err := h.db.Preload("SuiteRuns.SpecRuns").Where("id = ?", headID).First(&head).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "head test run not found"})
+		return base, head, false
+	}
+	return base, head, true
+}
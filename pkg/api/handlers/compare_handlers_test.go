@@ -0,0 +1,126 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+)
+
+var _ = Describe("Compare handlers", func() {
+	start := time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+	expectTestRun := func(id int, branch string, specRuns *sqlmock.Rows) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1`)).
+			WithArgs(id, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name", "git_branch", "start_time"}).AddRow(id, "Checkout", branch, start))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suite_runs" WHERE "suite_runs"."test_run_id" = $1`)).
+			WithArgs(id).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id", "suite_name"}).AddRow(id*10, id, "Cart"))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_runs" WHERE "spec_runs"."suite_id" = $1`)).
+			WithArgs(id * 10).
+			WillReturnRows(specRuns)
+	}
+	specColumns := []string{"id", "suite_id", "spec_description", "status", "start_time", "end_time"}
+	expectTestRuns := func() {
+		expectTestRun(1, "main", sqlmock.NewRows(specColumns).
+			AddRow(11, 10, "adds an item", "passed", start, start.Add(time.Second)).
+			AddRow(12, 10, "empties", "failed", start, start.Add(time.Second)).
+			AddRow(13, 10, "checks out", "passed", start, start.Add(2*time.Second)))
+		expectTestRun(2, "feature", sqlmock.NewRows(specColumns).
+			AddRow(21, 20, "adds an item", "failed", start, start.Add(time.Second)).
+			AddRow(22, 20, "empties", "passed", start, start.Add(time.Second)).
+			AddRow(23, 20, "checks out", "passed", start, start.Add(2*time.Second)).
+			AddRow(24, 20, "ships", "passed", start, start.Add(10*time.Second)))
+	}
+
+	Context("when CompareTestRuns handler is invoked", func() {
+		It("should diff the specs of the head run against the base run", func() {
+			expectTestRuns()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("GET", "/api/reports/compare?base=1&head=2", nil)
+
+			handlers.NewHandler(gormDb).CompareTestRuns(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			var comparison models.RunComparison
+			Expect(json.Unmarshal(w.Body.Bytes(), &comparison)).To(Succeed())
+			Expect(comparison.Base.GitBranch).To(Equal("main"))
+			Expect(comparison.Head.GitBranch).To(Equal("feature"))
+			Expect(comparison.Counts).To(Equal(models.ComparisonCounts{Added: 1, NewlyFailing: 1, NewlyPassing: 1, Unchanged: 1}))
+			Expect(comparison.Suites).To(HaveLen(1))
+			Expect(comparison.Suites[0].Specs[0].BaseSpecRunID).To(Equal(uint64(11)))
+			Expect(comparison.Suites[0].Specs[0].HeadSpecRunID).To(Equal(uint64(21)))
+		})
+
+		It("should respond with 400 when an id is missing", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("GET", "/api/reports/compare?base=1", nil)
+
+			handlers.NewHandler(gormDb).CompareTestRuns(c)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(w.Body.String()).To(ContainSubstring("head must be a test run id"))
+		})
+
+		It("should respond with 404 when a test run does not exist", func() {
+			expectTestRun(1, "main", sqlmock.NewRows(specColumns))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("GET", "/api/reports/compare?base=1&head=3", nil)
+
+			handlers.NewHandler(gormDb).CompareTestRuns(c)
+
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+			Expect(w.Body.String()).To(ContainSubstring("head test run not found"))
+		})
+	})
+
+	Context("when CompareTestRunsHTML handler is invoked", func() {
+		It("should render the changed specs of the comparison", func() {
+			expectTestRuns()
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT "report_header" FROM "projects"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"report_header"}))
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(w)
+			router.SetFuncMap(template.FuncMap{"FormatDate": utils.FormatDate})
+			router.LoadHTMLGlob("../../views/compare.html")
+			router.GET("/reports/compare/", handlers.NewHandler(gormDb).CompareTestRunsHTML)
+			request, _ := http.NewRequest("GET", "/reports/compare/?base=1&head=2", nil)
+			router.ServeHTTP(w, request)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			doc, err := goquery.NewDocumentFromReader(w.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(doc.Find(".compared-suite").Length()).To(Equal(1))
+			changes := doc.Find(".compared-spec").Map(func(_ int, row *goquery.Selection) string {
+				return row.AttrOr("data-change", "")
+			})
+			Expect(changes).To(Equal([]string{"newly_failing", "newly_passing", "unchanged", "added"}))
+			Expect(doc.Find(".compared-spec.is-hidden").Length()).To(Equal(1))
+			Expect(strings.TrimSpace(doc.Find(".compared-runs a").First().AttrOr("href", ""))).To(Equal("/reports/testruns/1"))
+		})
+	})
+})
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/failures"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+// ReportTestRunFailureGroups groups the failed spec runs of a test run by
+// their normalized failure message, largest group first.
+func (h *Handler) ReportTestRunFailureGroups(c *gin.Context) {
+	var testRun models.TestRun
+	if err := h.db.Preload("SuiteRuns.SpecRuns").Where("id = ?", c.Param("id")).First(&testRun).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "test run not found"})
+		return
+	}
+	c.JSON(http.StatusOK, failures.Group(failures.FromTestRun(testRun)))
+}
+
+// ReportFailureGroups groups the failed spec runs of a project by their
+// normalized failure message, largest group first. ?branch= narrows the
+// failures down to one branch, ?startTime= and ?endTime= set the window (the
+// last 7 days by default) and ?limit= bounds the number of groups.
+func (h *Handler) ReportFailureGroups(c *gin.Context) {
+	query, ok := failureGroupQuery(c, c.Param("project"))
+	if !ok {
+		return
+	}
+	failureGroups, err := failures.Detect(h.db, query, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error grouping failures"})
+		return
+	}
+	c.JSON(http.StatusOK, failureGroups)
+}
+
+// ReportFailureGroupsHTML renders the failure groups of a project, with the
+// same parameters as ReportFailureGroups.
+func (h *Handler) ReportFailureGroupsHTML(c *gin.Context) {
+	projectName := c.Param("name")
+	query, ok := failureGroupQuery(c, projectName)
+	if !ok {
+		return
+	}
+	query = query.WithDefaults(time.Now())
+	failureGroups, err := failures.Detect(h.db, query, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error grouping failures"})
+		return
+	}
+
+	c.HTML(http.StatusOK, "failures.html", gin.H{
+		"reportHeader":  h.reportHeader(projectName),
+		"projectName":   projectName,
+		"branch":        query.Branch,
+		"startTime":     query.Start,
+		"endTime":       query.End,
+		"failureGroups": failureGroups,
+	})
+}
+
+// failureGroupQuery reads the failure group parameters of a request,
+// responding with 400 Bad Request when one is invalid.
+func failureGroupQuery(c *gin.Context, projectName string) (failures.Query, bool) {
+	query := failures.Query{Project: projectName, Branch: c.Query("branch")}
+
+	var err error
+	if query.Start, err = ParseTimeFromStringWithDefault(c.Query("startTime"), time.Time{}); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid startTime parameter: %v", err)})
+		return query, false
+	}
+	if query.End, err = ParseTimeFromStringWithDefault(c.Query("endTime"), time.Time{}); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid endTime parameter: %v", err)})
+		return query, false
+	}
+	if value := c.Query("limit"); value != "" {
+		if query.Limit, err = strconv.Atoi(value); err != nil || query.Limit < 1 || query.Limit > failures.MaxLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and " + strconv.Itoa(failures.MaxLimit)})
+			return query, false
+		}
+	}
+	return query, true
+}
+
+// classifyFailures classifies the spec runs of a test run against the previous
+// runs of its project branch. Failures are only logged, leaving the spec runs
+// unclassified.
+func (h *Handler) classifyFailures(testRun *models.TestRun) {
+	if err := failures.Classify(h.db, testRun); err != nil {
+		log.Printf("error classifying failures: %v", err)
+	}
+}
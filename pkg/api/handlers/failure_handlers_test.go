@@ -0,0 +1,303 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/failures"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+)
+
+var _ = Describe("Failure handlers", func() {
+	start := time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+	selectFailures := regexp.QuoteMeta(`SELECT spec_runs.test_case_id, suite_runs.suite_name, spec_runs.spec_description, spec_runs.message, ` +
+		`CASE WHEN spec_runs.message = '' THEN spec_runs.failure END AS failure, COUNT(*) AS count, ` +
+		`MIN(GREATEST(spec_runs.start_time, test_runs.start_time)) AS first_seen, MAX(GREATEST(spec_runs.start_time, test_runs.start_time)) AS last_seen, ` +
+		`(array_agg(test_runs.id ORDER BY test_runs.start_time DESC, spec_runs.id DESC))[1] AS last_test_run_id, ` +
+		`(array_agg(spec_runs.id ORDER BY test_runs.start_time DESC, spec_runs.id DESC))[1] AS last_spec_run_id, ` +
+		`string_agg(DISTINCT test_runs.id::text, ',') AS test_run_ids FROM "spec_runs" ` +
+		`INNER JOIN suite_runs ON suite_runs.id = spec_runs.suite_id INNER JOIN test_runs ON test_runs.id = suite_runs.test_run_id ` +
+		`WHERE test_runs.test_project_name = $1 AND (test_runs.start_time >= $2 AND test_runs.start_time <= $3) AND test_runs.deleted_at IS NULL ` +
+		`AND spec_runs.status IN ($4,$5)`)
+	groupFailures := regexp.QuoteMeta(` GROUP BY spec_runs.test_case_id, suite_runs.suite_name, spec_runs.spec_description, spec_runs.message, ` +
+		`CASE WHEN spec_runs.message = '' THEN spec_runs.failure END ORDER BY count DESC, last_seen DESC LIMIT `)
+	failureColumns := []string{"test_case_id", "suite_name", "spec_description", "message", "count", "first_seen", "last_seen",
+		"last_test_run_id", "last_spec_run_id", "test_run_ids", "failure"}
+	failureRows := func() *sqlmock.Rows {
+		// A spec run without a message is grouped by its structured failure
+		structured, _ := models.Failure{Message: "Expected 3 to equal 4"}.Value()
+		return sqlmock.NewRows(failureColumns).
+			AddRow(7, "Cart", "adds an item", `Get "http://10.0.0.5:8080/cart": connection refused`, 1, start, start, 1, 10, "1", nil).
+			AddRow(8, "Cart", "empties", `Get "http://10.0.0.5:8080/cart": connection refused`, 1, start, start, 1, 11, "1", nil).
+			AddRow(7, "Cart", "adds an item", `Get "http://10.0.0.6:8080/cart": connection refused`, 1, start.Add(time.Hour), start.Add(time.Hour), 2, 20, "2", nil).
+			AddRow(9, "Cart", "checks out", "", 1, start.Add(time.Hour), start.Add(time.Hour), 2, 21, "2", structured)
+	}
+
+	Context("when ReportFailureGroups handler is invoked", func() {
+		It("should group the failures of the branch by their normalized message", func() {
+			mock.ExpectQuery(selectFailures+regexp.QuoteMeta(` AND test_runs.git_branch = $6`)+groupFailures).
+				WithArgs("Checkout", sqlmock.AnyArg(), sqlmock.AnyArg(), "failed", "errored", "main", failures.MaxSpecFailures).
+				WillReturnRows(failureRows())
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "project", Value: "Checkout"}}
+			c.Request, _ = http.NewRequest("GET", "/api/reports/failures/Checkout?branch=main", nil)
+
+			handlers.NewHandler(gormDb).ReportFailureGroups(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			var failureGroups []models.FailureGroup
+			Expect(json.Unmarshal(w.Body.Bytes(), &failureGroups)).To(Succeed())
+			Expect(failureGroups).To(HaveLen(2))
+			Expect(failureGroups[0].Pattern).To(Equal(`Get "http://<addr>/cart": connection refused`))
+			Expect(failureGroups[0].Count).To(Equal(3))
+			Expect(failureGroups[0].TestRuns).To(Equal(2))
+			Expect(failureGroups[0].FirstSeen).To(BeTemporally("==", start))
+			Expect(failureGroups[0].LastSeen).To(BeTemporally("==", start.Add(time.Hour)))
+			Expect(failureGroups[0].Specs).To(HaveLen(2))
+			Expect(failureGroups[0].Specs[0].SpecDescription).To(Equal("adds an item"))
+			Expect(failureGroups[0].Specs[0].Count).To(Equal(2))
+			Expect(failureGroups[1].Pattern).To(Equal("Expected <n> to equal <n>"))
+		})
+
+		It("should reject an invalid limit", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "project", Value: "Checkout"}}
+			c.Request, _ = http.NewRequest("GET", "/api/reports/failures/Checkout?limit=0", nil)
+
+			handlers.NewHandler(gormDb).ReportFailureGroups(c)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("when ReportTestRunFailureGroups handler is invoked", func() {
+		It("should group the failures of the test run", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND "test_runs"."deleted_at" IS NULL ORDER BY "test_runs"."id" LIMIT $2`)).
+				WithArgs("2", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name", "start_time"}).AddRow(2, "Checkout", start))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suite_runs" WHERE "suite_runs"."test_run_id" = $1`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id", "suite_name"}).AddRow(4, 2, "Cart"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_runs" WHERE "spec_runs"."suite_id" = $1`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "suite_id", "spec_description", "status", "message"}).
+					AddRow(1, 4, "adds an item", "failed", "timed out after 5s").
+					AddRow(2, 4, "empties", "errored", "timed out after 10s").
+					AddRow(3, 4, "checks out", "passed", ""))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "2"}}
+			c.Request, _ = http.NewRequest("GET", "/api/reports/testruns/2/failures", nil)
+
+			handlers.NewHandler(gormDb).ReportTestRunFailureGroups(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			var failureGroups []models.FailureGroup
+			Expect(json.Unmarshal(w.Body.Bytes(), &failureGroups)).To(Succeed())
+			Expect(failureGroups).To(HaveLen(1))
+			Expect(failureGroups[0].Pattern).To(Equal("timed out after <n>s"))
+			Expect(failureGroups[0].Count).To(Equal(2))
+			Expect(failureGroups[0].FirstSeen).To(BeTemporally("==", start))
+		})
+
+		It("should respond with 404 when the test run does not exist", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "2"}}
+			c.Request, _ = http.NewRequest("GET", "/api/reports/testruns/2/failures", nil)
+
+			handlers.NewHandler(gormDb).ReportTestRunFailureGroups(c)
+
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Context("when the HTML report of a test run is rendered", func() {
+		It("should list its failure groups above the specs", func() {
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.SetFuncMap(template.FuncMap{
+				"CalculateDuration": utils.CalculateDuration,
+				"FormatDate":        utils.FormatDate,
+				"SpecTree":          utils.SpecTree,
+			})
+			router.LoadHTMLGlob("../../views/test_runs.html")
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND "test_runs"."deleted_at" IS NULL ORDER BY "test_runs"."id" LIMIT $2`)).
+				WithArgs("2", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name", "status"}).AddRow(2, "Checkout", "failed"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suite_runs" WHERE "suite_runs"."test_run_id" = $1`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id", "suite_name"}).AddRow(4, 2, "Cart"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_runs" WHERE "spec_runs"."suite_id" = $1`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "suite_id", "spec_description", "status", "message"}).
+					AddRow(1, 4, "adds an item", "failed", "dial tcp 10.0.0.5:5432: connection refused").
+					AddRow(2, 4, "empties", "failed", "dial tcp 10.0.0.5:5432: connection refused"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_run_tags"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"spec_run_id", "tag_id"}))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT ownership_rules.*`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "quarantine_rules"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "attachments"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			router.GET("/reports/testruns/:id", handlers.NewHandler(gormDb).ReportTestRunByIdHTML)
+			w := httptest.NewRecorder()
+			request, _ := http.NewRequest("GET", "/reports/testruns/2", nil)
+			router.ServeHTTP(w, request)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			doc, err := goquery.NewDocumentFromReader(w.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(doc.Find(".failure-groups tbody tr").Length()).To(Equal(1))
+			Expect(strings.TrimSpace(doc.Find(".failure-group-count").Text())).To(Equal("2"))
+			Expect(doc.Find(".failure-group-pattern").Text()).To(Equal("dial tcp <addr>: connection refused"))
+			Expect(doc.Find(".failure-group-spec").Length()).To(Equal(2))
+		})
+	})
+
+	Context("when the report of a test run classifies its failures", func() {
+		latestFirst := `OVER (PARTITION BY spec_runs.test_case_id, CASE WHEN spec_runs.test_case_id IS NULL THEN suite_runs.suite_name END, ` +
+			`CASE WHEN spec_runs.test_case_id IS NULL THEN spec_runs.spec_description END ORDER BY test_runs.start_time DESC, spec_runs.id DESC)`
+		selectHistory := regexp.QuoteMeta(`SELECT test_case_id, test_run_id, spec_run_id, suite_name, spec_description, status, start_time, git_branch, git_sha ` +
+			`FROM (SELECT spec_runs.test_case_id, test_runs.id AS test_run_id, spec_runs.id AS spec_run_id, suite_runs.suite_name, ` +
+			`spec_runs.spec_description, spec_runs.status, test_runs.start_time, test_runs.git_branch, test_runs.git_sha, ` +
+			`ROW_NUMBER() ` + latestFirst + ` AS recency, COUNT(*) FILTER (WHERE spec_runs.status IN ('passed','flaky')) ` + latestFirst + ` AS passes, ` +
+			`LEAD(spec_runs.status) ` + latestFirst + ` AS previous_status FROM "spec_runs" ` +
+			`INNER JOIN suite_runs ON suite_runs.id = spec_runs.suite_id INNER JOIN test_runs ON test_runs.id = suite_runs.test_run_id ` +
+			`WHERE (test_runs.test_project_name = $1 AND test_runs.git_branch = $2) AND (test_runs.start_time >= $3 AND test_runs.start_time < $4) ` +
+			`AND test_runs.id <> $5 AND test_runs.deleted_at IS NULL AND spec_runs.status IN ($6,$7,$8,$9) AND spec_runs.test_case_id IN ($10,$11,$12)) AS executions ` +
+			`WHERE recency = 1 OR (passes = 0 AND (previous_status IS NULL OR previous_status IN ('passed','flaky'))) ORDER BY start_time, spec_run_id`)
+		historyColumns := []string{"test_case_id", "test_run_id", "spec_run_id", "suite_name", "spec_description", "status", "start_time", "git_branch", "git_sha"}
+
+		expectTestRun := func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND "test_runs"."deleted_at" IS NULL ORDER BY "test_runs"."id" LIMIT $2`)).
+				WithArgs("3", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name", "status", "start_time", "git_branch"}).
+					AddRow(3, "Checkout", "failed", start.Add(3*time.Hour), "main"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suite_runs" WHERE "suite_runs"."test_run_id" = $1`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id", "suite_name"}).AddRow(4, 3, "Cart"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_runs" WHERE "spec_runs"."suite_id" = $1`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "suite_id", "spec_description", "status", "test_case_id"}).
+					AddRow(30, 4, "adds an item", "failed", 7).
+					AddRow(31, 4, "empties", "failed", 8).
+					AddRow(32, 4, "checks out", "passed", 9))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_run_tags"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"spec_run_id", "tag_id"}))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT ownership_rules.*`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "quarantine_rules"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			mock.ExpectQuery(selectHistory).
+				WithArgs("Checkout", "main", start.Add(3*time.Hour-30*24*time.Hour), start.Add(3*time.Hour), 3, "passed", "flaky", "failed", "errored", 7, 8, 9).
+				WillReturnRows(sqlmock.NewRows(historyColumns).
+					AddRow(7, 1, 10, "Cart", "adds an item", "passed", start.Add(time.Hour), "main", "aaa").
+					AddRow(8, 1, 11, "Cart", "empties", "failed", start.Add(time.Hour), "main", "aaa").
+					AddRow(8, 2, 21, "Cart", "empties", "failed", start.Add(2*time.Hour), "main", "bbb").
+					AddRow(9, 2, 22, "Cart", "checks out", "errored", start.Add(2*time.Hour), "main", "bbb"))
+		}
+
+		It("should classify every failure of ReportTestRunById", func() {
+			expectTestRun()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "3"}}
+			c.Request, _ = http.NewRequest("GET", "/api/reports/testruns/3/", nil)
+
+			handlers.NewHandler(gormDb).ReportTestRunById(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			var response struct {
+				TestRuns []models.TestRun `json:"testRuns"`
+			}
+			Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+			specRuns := response.TestRuns[0].SuiteRuns[0].SpecRuns
+			Expect(specRuns[0].Classification.Kind).To(Equal("new_failure"))
+			Expect(specRuns[1].Classification.Kind).To(Equal("still_failing"))
+			Expect(specRuns[1].Classification.FailingSinceTestRunID).To(Equal(uint64(1)))
+			Expect(specRuns[1].Classification.FailingSinceGitSha).To(Equal("aaa"))
+			Expect(specRuns[2].Classification.Kind).To(Equal("fixed"))
+			Expect(specRuns[2].Classification.PreviousTestRunID).To(Equal(uint64(2)))
+		})
+
+		It("should tag every failure in the HTML report", func() {
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.SetFuncMap(template.FuncMap{
+				"CalculateDuration": utils.CalculateDuration,
+				"FormatDate":        utils.FormatDate,
+				"SpecTree":          utils.SpecTree,
+			})
+			router.LoadHTMLGlob("../../views/test_runs.html")
+			expectTestRun()
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "attachments"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			router.GET("/reports/testruns/:id", handlers.NewHandler(gormDb).ReportTestRunByIdHTML)
+			w := httptest.NewRecorder()
+			request, _ := http.NewRequest("GET", "/reports/testruns/3", nil)
+			router.ServeHTTP(w, request)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			doc, err := goquery.NewDocumentFromReader(w.Body)
+			Expect(err).NotTo(HaveOccurred())
+			tags := doc.Find(".spec-classification").Map(func(_ int, tag *goquery.Selection) string {
+				return strings.TrimSpace(tag.Text())
+			})
+			Expect(tags).To(Equal([]string{"new failure", "failing since run 1 @ aaa", "fixed"}))
+			Expect(doc.Find(".spec-classification").Eq(1).AttrOr("href", "")).To(Equal("/reports/testruns/1"))
+		})
+	})
+
+	Context("when ReportFailureGroupsHTML handler is invoked", func() {
+		It("should list the failure groups of the project", func() {
+			mock.ExpectQuery(selectFailures + groupFailures).
+				WillReturnRows(failureRows())
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT "report_header" FROM "projects"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"report_header"}))
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(w)
+			router.SetFuncMap(template.FuncMap{"FormatDate": utils.FormatDate})
+			router.LoadHTMLGlob("../../views/failures.html")
+			router.GET("/insights/:name/failures", handlers.NewHandler(gormDb).ReportFailureGroupsHTML)
+			request, _ := http.NewRequest("GET", "/insights/Checkout/failures", nil)
+			router.ServeHTTP(w, request)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			doc, err := goquery.NewDocumentFromReader(w.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(doc.Find(".failure-groups tbody tr").Length()).To(Equal(2))
+			Expect(strings.TrimSpace(doc.Find(".failure-group-count").First().Text())).To(Equal("3"))
+			links := doc.Find(".failure-group-spec a").Map(func(_ int, link *goquery.Selection) string {
+				return link.AttrOr("href", "")
+			})
+			Expect(links).To(Equal([]string{"/reports/testruns/2", "/reports/testruns/1", "/reports/testruns/2"}))
+		})
+	})
+})
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/flaky"
+)
+
+// ReportFlakySpecs ranks the flaky specs of a project, most flaky first.
+// ?branch= narrows the analysis down to one branch, ?startTime= and ?endTime=
+// set the window (the last 30 days by default), ?minExecutions= skips rarely
+// run specs and ?limit= bounds the number of specs.
+func (h *Handler) ReportFlakySpecs(c *gin.Context) {
+	query, ok := flakyQuery(c, c.Param("project"))
+	if !ok {
+		return
+	}
+	flakySpecs, err := flaky.Detect(h.db, query, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error detecting flaky specs"})
+		return
+	}
+	c.JSON(http.StatusOK, flakySpecs)
+}
+
+// ReportFlakySpecsHTML renders the flaky specs of a project, with the same
+// parameters as ReportFlakySpecs.
+func (h *Handler) ReportFlakySpecsHTML(c *gin.Context) {
+	projectName := c.Param("name")
+	query, ok := flakyQuery(c, projectName)
+	if !ok {
+		return
+	}
+	query = query.WithDefaults(time.Now())
+	flakySpecs, err := flaky.Detect(h.db, query, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error detecting flaky specs"})
+		return
+	}
+
+	c.HTML(http.StatusOK, "flaky.html", gin.H{
+		"reportHeader": h.reportHeader(projectName),
+		"projectName":  projectName,
+		"branch":       query.Branch,
+		"startTime":    query.Start,
+		"endTime":      query.End,
+		"flakySpecs":   flakySpecs,
+	})
+}
+
+// flakyQuery reads the flaky spec parameters of a request, responding with
+// 400 Bad Request when one is invalid.
+func flakyQuery(c *gin.Context, projectName string) (flaky.Query, bool) {
+	query := flaky.Query{Project: projectName, Branch: c.Query("branch")}
+
+	var err error
+	if query.Start, err = ParseTimeFromStringWithDefault(c.Query("startTime"), time.Time{}); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid startTime parameter: %v", err)})
+		return query, false
+	}
+	if query.End, err = ParseTimeFromStringWithDefault(c.Query("endTime"), time.Time{}); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid endTime parameter: %v", err)})
+		return query, false
+	}
+	if value := c.Query("minExecutions"); value != "" {
+		if query.MinExecutions, err = strconv.Atoi(value); err != nil || query.MinExecutions < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "minExecutions must be a positive number"})
+			return query, false
+		}
+	}
+	if value := c.Query("limit"); value != "" {
+		if query.Limit, err = strconv.Atoi(value); err != nil || query.Limit < 1 || query.Limit > flaky.MaxLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and " + strconv.Itoa(flaky.MaxLimit)})
+			return query, false
+		}
+	}
+	return query, true
+}
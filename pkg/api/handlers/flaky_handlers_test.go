@@ -0,0 +1,108 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/flaky"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Flaky handlers", func() {
+	start := time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+	bySpec := `PARTITION BY test_runs.git_branch, spec_runs.test_case_id, CASE WHEN spec_runs.test_case_id IS NULL THEN suite_runs.suite_name END, ` +
+		`CASE WHEN spec_runs.test_case_id IS NULL THEN spec_runs.spec_description END`
+	selectExecutions := regexp.QuoteMeta(`SELECT test_case_id, test_run_id, spec_run_id, suite_name, spec_description, status, start_time, git_branch, git_sha ` +
+		`FROM (SELECT spec_runs.test_case_id, test_runs.id AS test_run_id, spec_runs.id AS spec_run_id, suite_runs.suite_name, ` +
+		`spec_runs.spec_description, spec_runs.status, test_runs.start_time, test_runs.git_branch, test_runs.git_sha, ` +
+		`ROW_NUMBER() OVER (` + bySpec + ` ORDER BY test_runs.start_time DESC, spec_runs.id DESC) AS recency, COUNT(*) OVER (` + bySpec + `) AS executions, ` +
+		`COUNT(*) FILTER (WHERE spec_runs.status IN ('failed','errored','flaky')) OVER (` + bySpec + `) AS unstable FROM "spec_runs" ` +
+		`INNER JOIN suite_runs ON suite_runs.id = spec_runs.suite_id INNER JOIN test_runs ON test_runs.id = suite_runs.test_run_id ` +
+		`WHERE test_runs.test_project_name = $1 AND (test_runs.start_time >= $2 AND test_runs.start_time <= $3) AND test_runs.deleted_at IS NULL ` +
+		`AND spec_runs.status IN ($4,$5,$6,$7)`)
+	boundExecutions := func(n int) string {
+		return regexp.QuoteMeta(fmt.Sprintf(`) AS executions WHERE recency <= $%d AND executions >= $%d AND unstable > 0 ORDER BY start_time, spec_run_id`, n, n+1))
+	}
+	executionColumns := []string{"test_case_id", "test_run_id", "spec_run_id", "suite_name", "spec_description", "status", "start_time", "git_branch", "git_sha"}
+	flakyRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows(executionColumns).
+			AddRow(7, 1, 10, "Cart", "adds an item", "passed", start, "main", "aaa").
+			AddRow(7, 2, 20, "Cart", "adds an item", "failed", start.Add(time.Hour), "main", "aaa").
+			AddRow(7, 3, 30, "Cart", "adds an item", "passed", start.Add(2*time.Hour), "main", "bbb")
+	}
+
+	Context("when ReportFlakySpecs handler is invoked", func() {
+		It("should rank the flaky specs of the branch with their evidence", func() {
+			mock.ExpectQuery(selectExecutions+regexp.QuoteMeta(` AND test_runs.git_branch = $8`)+boundExecutions(9)).
+				WithArgs("Checkout", sqlmock.AnyArg(), sqlmock.AnyArg(), "passed", "flaky", "failed", "errored", "main", flaky.MaxExecutions, flaky.DefaultMinExecutions).
+				WillReturnRows(flakyRows())
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "project", Value: "Checkout"}}
+			c.Request, _ = http.NewRequest("GET", "/api/reports/flaky/Checkout?branch=main", nil)
+
+			handlers.NewHandler(gormDb).ReportFlakySpecs(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			var flakySpecs []models.FlakySpec
+			Expect(json.Unmarshal(w.Body.Bytes(), &flakySpecs)).To(Succeed())
+			Expect(flakySpecs).To(HaveLen(1))
+			Expect(flakySpecs[0].SpecDescription).To(Equal("adds an item"))
+			Expect(flakySpecs[0].CommitFlips).To(Equal(1))
+			Expect(flakySpecs[0].Evidence).To(HaveLen(3))
+			Expect(flakySpecs[0].Evidence[1].URL).To(Equal("/reports/testruns/2"))
+		})
+
+		It("should reject an invalid limit", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "project", Value: "Checkout"}}
+			c.Request, _ = http.NewRequest("GET", "/api/reports/flaky/Checkout?limit=0", nil)
+
+			handlers.NewHandler(gormDb).ReportFlakySpecs(c)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("when ReportFlakySpecsHTML handler is invoked", func() {
+		It("should link every flaky spec to its evidence runs", func() {
+			mock.ExpectQuery(selectExecutions + boundExecutions(8)).
+				WillReturnRows(flakyRows())
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT "report_header" FROM "projects"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"report_header"}))
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(w)
+			router.LoadHTMLGlob("../../views/flaky.html")
+			router.GET("/insights/:name/flaky", handlers.NewHandler(gormDb).ReportFlakySpecsHTML)
+			request, _ := http.NewRequest("GET", "/insights/Checkout/flaky", nil)
+			router.ServeHTTP(w, request)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			doc, err := goquery.NewDocumentFromReader(w.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(doc.Find(".flaky-specs tbody tr").Length()).To(Equal(1))
+			Expect(strings.TrimSpace(doc.Find(".flaky-spec").Text())).To(Equal("adds an item"))
+			links := doc.Find(".evidence a").Map(func(_ int, link *goquery.Selection) string {
+				return link.AttrOr("href", "")
+			})
+			Expect(links).To(Equal([]string{"/reports/testruns/3", "/reports/testruns/2", "/reports/testruns/1"}))
+		})
+	})
+})
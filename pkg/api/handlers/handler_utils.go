@@ -2,19 +2,23 @@ package handlers
 
 import (
 	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
 	"time"
 )
 
 const timeQueryLayout = "2006-01-02T15:04:05"
 
+// GetLongestTestRuns lists the suite runs of a project by the duration of
+// their test run, with the pass rate of their executed spec runs.
 func GetLongestTestRuns(h *Handler, projectName string, startTimeRange time.Time, endTimeRange time.Time) []models.TestRunInsight {
 	var testRuns []models.TestRunInsight
 
-	h.db.Table("test_runs").
+	h.db.Table("test_runs").Scopes(models.LiveTestRuns).
 		Joins("INNER JOIN suite_runs ON test_runs.id = suite_runs.test_run_id").
 		Joins("INNER JOIN spec_runs ON suite_runs.id = spec_runs.suite_id").
 		Select("suite_runs.id, test_runs.test_project_name, test_runs.start_time, test_runs.end_time,"+
-			"ROUND(AVG(CASE WHEN spec_runs.status = 'passed' THEN 100.0 ELSE 0.0 END), 3) AS pass_rate, "+
+			"ROUND(COALESCE(AVG(CASE WHEN spec_runs.status IN ('passed','flaky') THEN 100.0 ELSE 0.0 END) "+
+			"FILTER (WHERE spec_runs.status NOT IN ('skipped','pending')), 0), 3) AS pass_rate, "+
 			"(test_runs.end_time - test_runs.start_time) AS duration").
 		Where("test_runs.start_time >= ?", startTimeRange).
 		Where("test_runs.start_time <= ?", endTimeRange).
@@ -28,7 +32,7 @@ func GetLongestTestRuns(h *Handler, projectName string, startTimeRange time.Time
 
 func GetAverageDuration(h *Handler, projectName string, startTimeRange time.Time, endTimeRange time.Time) float64 {
 	var averageDuration float64
-	h.db.Table("test_runs").
+	h.db.Table("test_runs").Scopes(models.LiveTestRuns).
 		Select("AVG(EXTRACT(EPOCH FROM (end_time - start_time)))").
 		Where("test_project_name = ?", projectName).
 		Where("start_time >= ?", startTimeRange).
@@ -50,20 +54,65 @@ func ParseTimeFromStringWithDefault(timeString string, defaultTime time.Time) (t
 	return parsedTime, nil
 }
 
-func GetProjectSpecStatistics(h *Handler, projectName string) []models.TestSummary {
+// containerPath joins the texts of the containers of a spec run down to the
+// depth bound to its placeholder.
+const containerPath = `COALESCE((SELECT string_agg(hierarchy.container ->> 'text', ' ' ORDER BY hierarchy.position) 
+                FROM jsonb_array_elements(spec_runs.containers) WITH ORDINALITY AS hierarchy(container, position) 
+                WHERE hierarchy.position <= ?), '')`
+
+// GetProjectSpecStatistics counts the spec runs of every suite run of a
+// project, counting flaky spec runs as passed and pending ones as skipped. A level above zero further splits the counts by the containers of
+// the specs down to that depth, reported as the joined container texts.
+func GetProjectSpecStatistics(h *Handler, projectName string, level int) []models.TestSummary {
 	var testSummaries []models.TestSummary
-	h.db.Table("test_runs").
-		Joins("INNER JOIN suite_runs ON test_runs.id = suite_runs.test_run_id").
-		Joins("INNER JOIN spec_runs ON suite_runs.id = spec_runs.suite_id").
-		Select(`suite_runs.id AS suite_run_id, 
+	columns := `suite_runs.id AS suite_run_id, 
             test_runs.test_project_name, 
             test_runs.start_time, 
-            COUNT(spec_runs.id) FILTER (WHERE spec_runs.status = 'passed') AS total_passed_spec_runs, 
-			COUNT(spec_runs.id) FILTER (WHERE spec_runs.status = 'skipped') AS total_skipped_spec_runs, 
-            COUNT(spec_runs.id) AS total_spec_runs`).
+            COUNT(spec_runs.id) FILTER (WHERE spec_runs.status IN ('passed','flaky')) AS total_passed_spec_runs, 
+			COUNT(spec_runs.id) FILTER (WHERE spec_runs.status IN ('skipped','pending')) AS total_skipped_spec_runs, 
+            COUNT(spec_runs.id) AS total_spec_runs`
+	groups := "suite_runs.id, test_runs.test_project_name, test_runs.start_time"
+	order := "test_runs.start_time"
+	var args []interface{}
+	if level > 0 {
+		columns += ", " + containerPath + " AS container_path"
+		groups += ", container_path"
+		order += ", container_path"
+		args = append(args, level)
+	}
+
+	h.db.Table("test_runs").Scopes(models.LiveTestRuns).
+		Joins("INNER JOIN suite_runs ON test_runs.id = suite_runs.test_run_id").
+		Joins("INNER JOIN spec_runs ON suite_runs.id = spec_runs.suite_id").
+		Select(columns, args...).
 		Where("test_runs.test_project_name = ?", projectName).
-		Group("suite_runs.id, test_runs.test_project_name, test_runs.start_time").
-		Order("test_runs.start_time").
+		Group(groups).
+		Order(order).
 		Scan(&testSummaries)
 	return testSummaries
 }
+
+// GetLabelPassRates returns the pass rate of the executed spec runs of a
+// project for each value of a label. A spec run's label is looked up on the
+// spec run first, then on its suite run and test run.
+func GetLabelPassRates(h *Handler, projectName string, key string, startTimeRange time.Time, endTimeRange time.Time) []models.LabelPassRate {
+	var passRates []models.LabelPassRate
+	value := "COALESCE(spec_runs.labels ->> @key, suite_runs.labels ->> @key, test_runs.labels ->> @key)"
+	h.db.Table("test_runs").Scopes(models.LiveTestRuns).
+		Joins("INNER JOIN suite_runs ON test_runs.id = suite_runs.test_run_id").
+		Joins("INNER JOIN spec_runs ON suite_runs.id = spec_runs.suite_id").
+		Select(value+" AS value, "+
+			"COUNT(spec_runs.id) FILTER (WHERE spec_runs.status IN @passed) AS passed, "+
+			"COUNT(spec_runs.id) AS executed, "+
+			"ROUND(AVG(CASE WHEN spec_runs.status IN @passed THEN 100.0 ELSE 0.0 END), 3) AS pass_rate",
+			map[string]interface{}{"key": key, "passed": []string{utils.StatusPassed, utils.StatusFlaky}}).
+		Where("test_runs.test_project_name = ?", projectName).
+		Where("test_runs.start_time >= ?", startTimeRange).
+		Where("test_runs.start_time <= ?", endTimeRange).
+		Where("spec_runs.status NOT IN ?", []string{utils.StatusSkipped, utils.StatusPending}).
+		Where(value+" IS NOT NULL", map[string]interface{}{"key": key}).
+		Group("value").
+		Order("value").
+		Scan(&passRates)
+	return passRates
+}
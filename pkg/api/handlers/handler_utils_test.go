@@ -44,6 +44,7 @@ var _ = Describe("Insights test", func() {
 		funcMap := template.FuncMap{
 			"CalculateDuration": utils.CalculateDuration,
 			"FormatDate":        utils.FormatDate,
+			"SpecTree":          utils.SpecTree,
 		}
 		router.SetFuncMap(funcMap)
 		router.LoadHTMLGlob("../../views/insights.html")
@@ -65,11 +66,11 @@ var _ = Describe("Insights test", func() {
 						AddRow(2, "TestProject", time.Date(2024, 4, 21, 12, 0, 0, 0, time.UTC),
 							time.Date(2024, 4, 21, 12, 1, 0, 0, time.UTC), 33.333, 60)
 
-					mock.ExpectQuery(regexp.QuoteMeta(`SELECT suite_runs.id, test_runs.test_project_name, test_runs.start_time, test_runs.end_time,ROUND(AVG(CASE WHEN spec_runs.status = 'passed' THEN 100.0 ELSE 0.0 END), 3) AS pass_rate, (test_runs.end_time - test_runs.start_time) AS duration FROM "test_runs" INNER JOIN suite_runs ON test_runs.id = suite_runs.test_run_id INNER JOIN spec_runs ON suite_runs.id = spec_runs.suite_id WHERE test_runs.start_time >= $1 AND test_runs.start_time <= $2 AND test_project_name = $3 GROUP BY suite_runs.id, test_runs.test_project_name, test_runs.start_time, test_runs.end_time ORDER BY duration DESC`)).
+					mock.ExpectQuery(regexp.QuoteMeta(`SELECT suite_runs.id, test_runs.test_project_name, test_runs.start_time, test_runs.end_time,ROUND(COALESCE(AVG(CASE WHEN spec_runs.status IN ('passed','flaky') THEN 100.0 ELSE 0.0 END) FILTER (WHERE spec_runs.status NOT IN ('skipped','pending')), 0), 3) AS pass_rate, (test_runs.end_time - test_runs.start_time) AS duration FROM "test_runs" INNER JOIN suite_runs ON test_runs.id = suite_runs.test_run_id INNER JOIN spec_runs ON suite_runs.id = spec_runs.suite_id WHERE test_runs.start_time >= $1 AND test_runs.start_time <= $2 AND test_project_name = $3 AND test_runs.deleted_at IS NULL GROUP BY suite_runs.id, test_runs.test_project_name, test_runs.start_time, test_runs.end_time ORDER BY duration DESC`)).
 						WithArgs(startTime, endTime, testProjectName).
 						WillReturnRows(rows)
 
-					mock.ExpectQuery(regexp.QuoteMeta(`SELECT AVG(EXTRACT(EPOCH FROM (end_time - start_time))) FROM "test_runs" WHERE test_project_name = $1 AND start_time >= $2 AND start_time <= $3`)).
+					mock.ExpectQuery(regexp.QuoteMeta(`SELECT AVG(EXTRACT(EPOCH FROM (end_time - start_time))) FROM "test_runs" WHERE test_project_name = $1 AND start_time >= $2 AND start_time <= $3 AND test_runs.deleted_at IS NULL`)).
 						WithArgs(testProjectName, startTime, endTime).
 						WillReturnRows(sqlmock.NewRows([]string{"avg"}).AddRow(60))
 
@@ -117,11 +118,11 @@ var _ = Describe("Insights test", func() {
 
 					rows := sqlmock.NewRows([]string{"id", "test_project_name", "start_time", "end_time", "pass_rate", "duration"})
 
-					mock.ExpectQuery(regexp.QuoteMeta(`SELECT suite_runs.id, test_runs.test_project_name, test_runs.start_time, test_runs.end_time,ROUND(AVG(CASE WHEN spec_runs.status = 'passed' THEN 100.0 ELSE 0.0 END), 3) AS pass_rate, (test_runs.end_time - test_runs.start_time) AS duration FROM "test_runs" INNER JOIN suite_runs ON test_runs.id = suite_runs.test_run_id INNER JOIN spec_runs ON suite_runs.id = spec_runs.suite_id WHERE test_runs.start_time >= $1 AND test_runs.start_time <= $2 AND test_project_name = $3 GROUP BY suite_runs.id, test_runs.test_project_name, test_runs.start_time, test_runs.end_time ORDER BY duration DESC`)).
+					mock.ExpectQuery(regexp.QuoteMeta(`SELECT suite_runs.id, test_runs.test_project_name, test_runs.start_time, test_runs.end_time,ROUND(COALESCE(AVG(CASE WHEN spec_runs.status IN ('passed','flaky') THEN 100.0 ELSE 0.0 END) FILTER (WHERE spec_runs.status NOT IN ('skipped','pending')), 0), 3) AS pass_rate, (test_runs.end_time - test_runs.start_time) AS duration FROM "test_runs" INNER JOIN suite_runs ON test_runs.id = suite_runs.test_run_id INNER JOIN spec_runs ON suite_runs.id = spec_runs.suite_id WHERE test_runs.start_time >= $1 AND test_runs.start_time <= $2 AND test_project_name = $3 AND test_runs.deleted_at IS NULL GROUP BY suite_runs.id, test_runs.test_project_name, test_runs.start_time, test_runs.end_time ORDER BY duration DESC`)).
 						WithArgs(startTime, endTime, testProjectName).
 						WillReturnRows(rows)
 
-					mock.ExpectQuery(regexp.QuoteMeta(`SELECT AVG(EXTRACT(EPOCH FROM (end_time - start_time))) FROM "test_runs" WHERE test_project_name = $1 AND start_time >= $2 AND start_time <= $3`)).
+					mock.ExpectQuery(regexp.QuoteMeta(`SELECT AVG(EXTRACT(EPOCH FROM (end_time - start_time))) FROM "test_runs" WHERE test_project_name = $1 AND start_time >= $2 AND start_time <= $3 AND test_runs.deleted_at IS NULL`)).
 						WithArgs(testProjectName, startTime, endTime).
 						WillReturnRows(sqlmock.NewRows([]string{"avg"}).AddRow(0))
 
@@ -152,3 +153,43 @@ var _ = Describe("Insights test", func() {
 		})
 	})
 })
+
+var _ = Describe("Label insights test", func() {
+
+	Context("When ReportLabelPassRates is invoked", func() {
+		startTime := time.Date(2024, 4, 19, 0, 0, 0, 0, time.UTC)
+		endTime := time.Date(2024, 4, 22, 0, 0, 0, 0, time.UTC)
+
+		It("should return the pass rate of each label value", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(spec_runs.labels ->> $1, suite_runs.labels ->> $2, test_runs.labels ->> $3) AS value, COUNT(spec_runs.id) FILTER (WHERE spec_runs.status IN ($4,$5)) AS passed, COUNT(spec_runs.id) AS executed, ROUND(AVG(CASE WHEN spec_runs.status IN ($6,$7) THEN 100.0 ELSE 0.0 END), 3) AS pass_rate FROM "test_runs" INNER JOIN suite_runs ON test_runs.id = suite_runs.test_run_id INNER JOIN spec_runs ON suite_runs.id = spec_runs.suite_id WHERE test_runs.test_project_name = $8 AND test_runs.start_time >= $9 AND test_runs.start_time <= $10 AND spec_runs.status NOT IN ($11,$12) AND COALESCE(spec_runs.labels ->> $13, suite_runs.labels ->> $14, test_runs.labels ->> $15) IS NOT NULL AND test_runs.deleted_at IS NULL GROUP BY "value" ORDER BY value`)).
+				WithArgs("os", "os", "os", "passed", "flaky", "passed", "flaky", "TestProject", startTime, endTime, "skipped", "pending", "os", "os", "os").
+				WillReturnRows(sqlmock.NewRows([]string{"value", "passed", "executed", "pass_rate"}).
+					AddRow("linux", 3, 4, 75.0).
+					AddRow("windows", 1, 1, 100.0))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("GET", "/api/reports/insights/TestProject/labels/os?startTime=2024-04-19T00:00:00&endTime=2024-04-22T00:00:00", nil)
+			c.Params = gin.Params{{Key: "name", Value: "TestProject"}, {Key: "key", Value: "os"}}
+
+			handler := handlers.NewHandler(gormDb)
+			handler.ReportLabelPassRates(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(MatchJSON(`[{"value":"linux","passed":3,"executed":4,"pass_rate":75},{"value":"windows","passed":1,"executed":1,"pass_rate":100}]`))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+
+		It("should reject an invalid label key", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("GET", "/api/reports/insights/TestProject/labels/os,arch", nil)
+			c.Params = gin.Params{{Key: "name", Value: "TestProject"}, {Key: "key", Value: "os,arch"}}
+
+			handler := handlers.NewHandler(gormDb)
+			handler.ReportLabelPassRates(c)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+})
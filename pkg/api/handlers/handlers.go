@@ -4,21 +4,27 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/guidewire/fern-reporter/config"
+	"github.com/guidewire/fern-reporter/pkg/blobstore"
+	"github.com/guidewire/fern-reporter/pkg/failures"
+	"github.com/guidewire/fern-reporter/pkg/labels"
 	"github.com/guidewire/fern-reporter/pkg/models"
 	"github.com/guidewire/fern-reporter/pkg/utils"
+	"github.com/guidewire/fern-reporter/pkg/validation"
 
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Handler struct {
-	db *gorm.DB
+	db    *gorm.DB
+	blobs blobstore.Store
 }
 
 func NewHandler(db *gorm.DB) *Handler {
@@ -45,20 +51,124 @@ func (h *Handler) CreateTestRun(c *gin.Context) {
 		}
 	}
 
-	// Process tags
-	err := ProcessTags(gdb, &testRun)
+	h.saveTestRun(c, &testRun)
+}
+
+// IdempotencyKeyHeader lets clients retry an upload safely: a repeated
+// request with the same key returns the run created by the first one.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// saveTestRun validates a test run, processes its tags and persists the whole
+// run tree in a single transaction. With ?dryRun=true the run is only
+// validated. It is shared by every ingestion format so they
+// are stored identically.
+func (h *Handler) saveTestRun(c *gin.Context, testRun *models.TestRun) {
+	gdb := h.db
+
+	if err := validation.ValidateTestRun(testRun); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+	if rejectOutOfScopeProject(c, testRun.TestProjectName) || h.rejectUnregisteredProject(c, testRun.TestProjectName) {
+		return
+	}
+	if isDryRun(c) {
+		c.JSON(http.StatusOK, gin.H{"valid": true, "test_run": testRun})
+		return
+	}
+
+	// A key sent as a header wins over a client supplied run UUID in the body
+	if key := strings.TrimSpace(c.GetHeader(IdempotencyKeyHeader)); key != "" {
+		testRun.IdempotencyKey = &key
+	} else if testRun.IdempotencyKey != nil && *testRun.IdempotencyKey == "" {
+		testRun.IdempotencyKey = nil
+	}
+
+	if testRun.IdempotencyKey != nil {
+		if existing, ok := h.findIdempotentTestRun(*testRun.IdempotencyKey); ok {
+			c.JSON(http.StatusOK, existing)
+			return
+		}
+	}
+
+	// Runs only move to the trash through DeleteTestRun
+	testRun.DeletedAt = gorm.DeletedAt{}
+	testRun.DeletedBy = ""
+	if testRun.Status == "" {
+		h.quarantineFailures(testRun)
+	}
+	completeTestRun(testRun)
+
+	errMessage := "error saving record"
+	err := gdb.Transaction(func(tx *gorm.DB) error {
+		// Process tags
+		if err := ProcessTags(tx, testRun); err != nil {
+			errMessage = "error processing tags"
+			return err
+		}
+		if err := resolveTestCases(tx, testRun); err != nil {
+			errMessage = "error resolving test cases"
+			return err
+		}
+
+		// Save or update the testRun record in the database
+		return tx.Save(testRun).Error
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "error processing tags"})
-		return // Stop further processing if tag processing fails
+		// A concurrent retry with the same key may have won the race
+		if testRun.IdempotencyKey != nil {
+			if existing, ok := h.findIdempotentTestRun(*testRun.IdempotencyKey); ok {
+				c.JSON(http.StatusOK, existing)
+				return
+			}
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errMessage})
+		return
 	}
 
-	// Save or update the testRun record in the database
-	if err := gdb.Save(&testRun).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "error saving record"})
-		return // Stop further processing if save fails
+	c.JSON(http.StatusCreated, testRun)
+}
+
+// validationErrorResponse reports an invalid payload, listing every offending
+// field when the validation package found them.
+func validationErrorResponse(c *gin.Context, err error) {
+	var fieldErrors validation.Errors
+	if errors.As(err, &fieldErrors) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid test run", "fields": fieldErrors})
+		return
 	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
 
-	c.JSON(http.StatusCreated, &testRun)
+func isDryRun(c *gin.Context) bool {
+	dryRun, _ := strconv.ParseBool(c.Query("dryRun"))
+	return dryRun
+}
+
+// completeTestRun fills in the lifecycle fields of a run that was posted in
+// one piece. Such runs are complete, so their outcome is known.
+func completeTestRun(testRun *models.TestRun) {
+	if testRun.Status == "" {
+		testRun.Status = utils.TestRunOutcome(*testRun)
+	}
+	if testRun.LastActivityTime.IsZero() {
+		testRun.LastActivityTime = testRun.EndTime
+	}
+}
+
+// findIdempotentTestRun returns the run stored with the idempotency key. Runs
+// in the trash still hold their key, so a retried upload returns them too.
+func (h *Handler) findIdempotentTestRun(key string) (models.TestRun, bool) {
+	var testRun models.TestRun
+	err := h.db.Unscoped().Preload("SuiteRuns.SpecRuns.Tags").Where("idempotency_key = ?", key).First(&testRun).Error
+	return testRun, err == nil
+}
+
+// tagUpsert makes tag inserts return the id of an existing tag with the same
+// name instead of failing on the unique constraint.
+var tagUpsert = clause.OnConflict{
+	Columns:   []clause.Column{{Name: "name"}},
+	DoUpdates: clause.AssignmentColumns([]string{"name"}),
 }
 
 func ProcessTags(db *gorm.DB, testRun *models.TestRun) error {
@@ -72,9 +182,11 @@ func ProcessTags(db *gorm.DB, testRun *models.TestRun) error {
 				result := db.Where("name = ?", tag.Name).First(&existingTag)
 
 				if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-					// If the tag does not exist, create a new one
+					// If the tag does not exist, create a new one. Another upload may
+					// create it concurrently, so fall back to the stored row.
 					newTag := models.Tag{Name: tag.Name}
-					if err := db.Create(&newTag).Error; err != nil {
+					err := db.Clauses(tagUpsert).Create(&newTag).Error
+					if err != nil {
 						return err // Return error if tag creation fails
 					}
 					processedTags = append(processedTags, newTag)
@@ -93,9 +205,18 @@ func ProcessTags(db *gorm.DB, testRun *models.TestRun) error {
 	return nil
 }
 
+// GetTestRunAll lists test runs, optionally filtered by the "branch" and
+// "commit" query parameters. A commit may be abbreviated.
 func (h *Handler) GetTestRunAll(c *gin.Context) {
 	var testRuns []models.TestRun
-	h.db.Find(&testRuns)
+	query := h.db
+	if branch := c.Query("branch"); branch != "" {
+		query = query.Where("git_branch = ?", branch)
+	}
+	if commit := c.Query("commit"); commit != "" {
+		query = query.Where("git_sha LIKE ?", commit+"%")
+	}
+	query.Find(&testRuns)
 	c.JSON(http.StatusOK, testRuns)
 }
 
@@ -125,6 +246,8 @@ func (h *Handler) UpdateTestRun(c *gin.Context) {
 	c.JSON(http.StatusOK, &testRun)
 }
 
+// DeleteTestRun moves a test run to the trash, recording who deleted it. The
+// retention job purges it for good once the trash grace period has passed.
 func (h *Handler) DeleteTestRun(c *gin.Context) {
 	var testRun models.TestRun
 	id := c.Param("id")
@@ -135,7 +258,12 @@ func (h *Handler) DeleteTestRun(c *gin.Context) {
 		testRun.ID = uint64(testRunID)
 	}
 
-	result := h.db.Delete(&testRun)
+	testRun.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	testRun.DeletedBy = requestUser(c)
+	result := h.db.Model(&testRun).Scopes(inScopeTestRuns(c)).Updates(map[string]interface{}{
+		"deleted_at": testRun.DeletedAt,
+		"deleted_by": testRun.DeletedBy,
+	})
 	if result.Error != nil {
 		// If there was an error during the delete operation
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "error deleting test run"})
@@ -150,56 +278,99 @@ func (h *Handler) DeleteTestRun(c *gin.Context) {
 }
 
 func (h *Handler) ReportTestRunAll(c *gin.Context) {
+	selector, ok := labelSelector(c)
+	if !ok {
+		return
+	}
 	var testRuns []models.TestRun
-	h.db.Preload("SuiteRuns.SpecRuns.Tags").Find(&testRuns)
+	h.db.Scopes(selector.RunScope()).Preload("SuiteRuns.SpecRuns.Tags").Find(&testRuns)
+	testRuns = selector.FilterTestRuns(testRuns)
+	h.applyOwners(testRuns)
+	h.applyQuarantine(testRuns)
 
 	c.JSON(http.StatusOK, gin.H{
 		"testRuns":     testRuns,
-		"reportHeader": config.GetHeaderName(),
+		"reportHeader": h.reportHeader(runProjectNames(testRuns)...),
 		"total":        len(testRuns),
 	})
 }
 
 func (h *Handler) ReportTestRunById(c *gin.Context) {
+	selector, ok := labelSelector(c)
+	if !ok {
+		return
+	}
 	var testRun models.TestRun
 	id := c.Param("id")
-	h.db.Preload("SuiteRuns.SpecRuns").Where("id = ?", id).First(&testRun)
+	h.db.Preload("SuiteRuns.SpecRuns.Tags").Where("id = ?", id).First(&testRun)
+	selector.FilterTestRun(&testRun)
+	testRuns := []models.TestRun{testRun}
+	h.applyOwners(testRuns)
+	h.applyQuarantine(testRuns)
+	h.classifyFailures(&testRuns[0])
 
 	c.JSON(http.StatusOK, gin.H{
-		"reportHeader": config.GetHeaderName(),
-		"testRuns":     []models.TestRun{testRun},
+		"reportHeader": h.reportHeader(testRun.TestProjectName),
+		"testRuns":     testRuns,
 	})
 }
 
 func (h *Handler) ReportTestRunAllHTML(c *gin.Context) {
+	selector, ok := labelSelector(c)
+	if !ok {
+		return
+	}
 	var testRuns []models.TestRun
-	h.db.Preload("SuiteRuns.SpecRuns.Tags").Find(&testRuns)
-	totalTests, executedTests, passedTests, failedTests := utils.CalculateTestMetrics(testRuns)
+	h.db.Scopes(selector.RunScope()).Preload("SuiteRuns.SpecRuns.Tags").Find(&testRuns)
+	testRuns = selector.FilterTestRuns(testRuns)
+	h.applyOwners(testRuns)
+	h.applyQuarantine(testRuns)
+	specAttachments, runAttachments := h.loadAttachments(testRuns)
+	totalTests, executedTests, passedTests, failedTests, flakyTests := utils.CalculateTestMetrics(testRuns)
 
 	c.HTML(http.StatusOK, "test_runs.html", gin.H{
-		"reportHeader":  config.GetHeaderName(),
-		"testRuns":      testRuns,
-		"totalTests":    totalTests,
-		"executedTests": executedTests,
-		"passedTests":   passedTests,
-		"failedTests":   failedTests,
+		"reportHeader":    h.reportHeader(runProjectNames(testRuns)...),
+		"testRuns":        testRuns,
+		"totalTests":      totalTests,
+		"executedTests":   executedTests,
+		"passedTests":     passedTests,
+		"failedTests":     failedTests,
+		"flakyTests":      flakyTests,
+		"specAttachments": specAttachments,
+		"runAttachments":  runAttachments,
+		"failureGroups":   failures.ForTestRuns(testRuns),
+		"labelSelector":   c.Query("labelSelector"),
 	})
 }
 
 func (h *Handler) ReportTestRunByIdHTML(c *gin.Context) {
+	selector, ok := labelSelector(c)
+	if !ok {
+		return
+	}
 	var testRun models.TestRun
 	id := c.Param("id")
-	h.db.Preload("SuiteRuns.SpecRuns").Where("id = ?", id).First(&testRun)
+	h.db.Preload("SuiteRuns.SpecRuns.Tags").Where("id = ?", id).First(&testRun)
+	selector.FilterTestRun(&testRun)
 	testRuns := []models.TestRun{testRun}
-	totalTests, executedTests, passedTests, failedTests := utils.CalculateTestMetrics(testRuns)
+	h.applyOwners(testRuns)
+	h.applyQuarantine(testRuns)
+	h.classifyFailures(&testRuns[0])
+	specAttachments, runAttachments := h.loadAttachments(testRuns)
+	totalTests, executedTests, passedTests, failedTests, flakyTests := utils.CalculateTestMetrics(testRuns)
 
 	c.HTML(http.StatusOK, "test_runs.html", gin.H{
-		"reportHeader":  config.GetHeaderName(),
-		"testRuns":      []models.TestRun{testRun},
-		"totalTests":    totalTests,
-		"executedTests": executedTests,
-		"passedTests":   passedTests,
-		"failedTests":   failedTests,
+		"reportHeader":    h.reportHeader(runProjectNames(testRuns)...),
+		"testRuns":        testRuns,
+		"totalTests":      totalTests,
+		"executedTests":   executedTests,
+		"passedTests":     passedTests,
+		"failedTests":     failedTests,
+		"flakyTests":      flakyTests,
+		"specAttachments": specAttachments,
+		"runAttachments":  runAttachments,
+		"failureGroups":   failures.ForTestRuns(testRuns),
+		"labelSelector":   c.Query("labelSelector"),
 	})
 }
 
@@ -227,20 +398,73 @@ func (h *Handler) ReportTestInsights(c *gin.Context) {
 	fmt.Printf("longestTestRuns: %v\n", longestTestRuns)
 	fmt.Printf("averageDuration: %v\n", averageDuration)
 
+	var labelPassRates []models.LabelPassRate
+	label := c.Query("label")
+	if label != "" {
+		labelPassRates = GetLabelPassRates(h, projectName, label, startTime, endTime)
+	}
+
+	ownerFailures, err := GetOwnerFailures(h, projectName, startTime, endTime)
+	if err != nil {
+		log.Printf("error loading owner failures: %v", err)
+	}
+
 	c.HTML(http.StatusOK, "insights.html", gin.H{
-		"reportHeader":    config.GetHeaderName(),
+		"reportHeader":    h.reportHeader(projectName),
 		"projectName":     projectName,
 		"startTime":       startTime,
 		"endTime":         endTime,
 		"averageDuration": averageDuration,
 		"longestTestRuns": longestTestRuns,
 		"numTests":        numTests,
+		"label":           label,
+		"labelPassRates":  labelPassRates,
+		"ownerFailures":   ownerFailures,
 	})
 }
 
+// ReportLabelPassRates returns the pass rate of a project for each value of
+// the label named by the key parameter.
+func (h *Handler) ReportLabelPassRates(c *gin.Context) {
+	projectName := c.Param("name")
+	key := c.Param("key")
+	if !labels.KeyPattern.MatchString(key) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid label key %q", key)})
+		return
+	}
+
+	startTime, err := ParseTimeFromStringWithDefault(c.Query("startTime"), time.Now().AddDate(-1, 0, 0))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid startTime parameter: %v", err)})
+		return
+	}
+	endTime, err := ParseTimeFromStringWithDefault(c.Query("endTime"), time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid endTime parameter: %v", err)})
+		return
+	}
+
+	passRates := GetLabelPassRates(h, projectName, key, startTime, endTime)
+	if passRates == nil {
+		passRates = []models.LabelPassRate{}
+	}
+	c.JSON(http.StatusOK, passRates)
+}
+
+// labelSelector parses the labelSelector query parameter, responding with
+// 400 Bad Request when it is invalid.
+func labelSelector(c *gin.Context) (labels.Selector, bool) {
+	selector, err := labels.Parse(c.Query("labelSelector"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return labels.Selector{}, false
+	}
+	return selector, true
+}
+
 func (h *Handler) GetProjectAll(c *gin.Context) {
 	var projectNames []string
-	h.db.Table("test_runs").
+	h.db.Table("test_runs").Scopes(models.LiveTestRuns).
 		Distinct("test_project_name").
 		Order("test_project_name asc").
 		Pluck("test_project_name", &projectNames)
@@ -249,9 +473,21 @@ func (h *Handler) GetProjectAll(c *gin.Context) {
 	})
 }
 
+// GetTestSummary counts the spec runs of every suite run of a project.
+// ?level= splits the counts by the container hierarchy of the specs down to
+// that depth.
 func (h *Handler) GetTestSummary(c *gin.Context) {
 	projectName := c.Param("name")
-	testSummaries := GetProjectSpecStatistics(h, projectName)
+	level := 0
+	if value := c.Query("level"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "level must be a non-negative integer"})
+			return
+		}
+		level = parsed
+	}
+	testSummaries := GetProjectSpecStatistics(h, projectName, level)
 
 	c.JSON(http.StatusOK, testSummaries)
 }
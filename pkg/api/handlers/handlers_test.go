@@ -21,6 +21,7 @@ import (
 
 	"github.com/guidewire/fern-reporter/pkg/api/handlers"
 	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/validation"
 )
 
 var (
@@ -75,13 +76,87 @@ var _ = Describe("Handlers", func() {
 		})
 	})
 
+	Context("when GetTestRunAll handler is invoked with a branch and commit", func() {
+		It("should only fetch the matching records", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE git_branch = $1 AND git_sha LIKE $2`)).
+				WithArgs("main", "0a1b2c3%").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "git_branch", "git_sha"}).AddRow(1, "main", "0a1b2c3d4e5f"))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("GET", "/api/testrun/?branch=main&commit=0a1b2c3", nil)
+			handler := handlers.NewHandler(gormDb)
+
+			handler.GetTestRunAll(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+			var testRuns []models.TestRun
+			Expect(json.Unmarshal(w.Body.Bytes(), &testRuns)).To(Succeed())
+			Expect(testRuns).To(HaveLen(1))
+			Expect(testRuns[0].GitSha).To(Equal("0a1b2c3d4e5f"))
+		})
+	})
+
+	Context("when ReportTestRunAll handler is invoked with a label selector", func() {
+		It("should only report the spec runs whose effective labels match", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE (EXISTS (SELECT 1 FROM suite_runs INNER JOIN spec_runs ON spec_runs.suite_id = suite_runs.id WHERE suite_runs.test_run_id = test_runs.id AND (test_runs.labels || suite_runs.labels || spec_runs.labels) ->> $1 = $2 AND (test_runs.labels || suite_runs.labels || spec_runs.labels) ->> $3 IS NULL)) AND "test_runs"."deleted_at" IS NULL`)).
+				WithArgs("os", "linux", "slow").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name", "labels"}).AddRow(1, "TestProject", `{"os":"linux"}`))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suite_runs" WHERE "suite_runs"."test_run_id" = $1`)).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id", "suite_name", "labels"}).AddRow(1, 1, "Cart", `{}`))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_runs" WHERE "spec_runs"."suite_id" = $1`)).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "suite_id", "spec_description", "status", "labels"}).
+					AddRow(1, 1, "adds an item", "passed", `{}`).
+					AddRow(2, 1, "checks out", "failed", `{"slow":"true"}`).
+					AddRow(3, 1, "renders on windows", "passed", `{"os":"windows"}`))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_run_tags"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"spec_run_id", "tag_id"}))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("GET", "/api/reports/testruns/?labelSelector=os%3Dlinux,!slow", nil)
+			handler := handlers.NewHandler(gormDb)
+
+			handler.ReportTestRunAll(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+			var report struct {
+				TestRuns []models.TestRun `json:"testRuns"`
+			}
+			Expect(json.Unmarshal(w.Body.Bytes(), &report)).To(Succeed())
+			Expect(report.TestRuns).To(HaveLen(1))
+			Expect(report.TestRuns[0].SuiteRuns).To(HaveLen(1))
+			specRuns := report.TestRuns[0].SuiteRuns[0].SpecRuns
+			Expect(specRuns).To(HaveLen(1))
+			Expect(specRuns[0].SpecDescription).To(Equal("adds an item"))
+		})
+
+		It("should reject an invalid selector", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("GET", "/api/reports/testruns/?labelSelector=os+version%3D14", nil)
+			handler := handlers.NewHandler(gormDb)
+
+			handler.ReportTestRunAll(c)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(w.Body.String()).To(ContainSubstring("invalid label key"))
+		})
+	})
+
 	Context("When GetTestRunByID handler is invoked", func() {
 		It("should query DB with where clause filtering by id", func() {
 
 			rows := sqlmock.NewRows([]string{"ID", "TestProjectName"}).
 				AddRow(123, "project 123")
 
-			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 ORDER BY "test_runs"."id" LIMIT $2`)).
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND "test_runs"."deleted_at" IS NULL ORDER BY "test_runs"."id" LIMIT $2`)).
 				WithArgs("123", 1).
 				WillReturnRows(rows)
 
@@ -142,8 +217,8 @@ var _ = Describe("Handlers", func() {
 			}
 
 			mock.ExpectBegin()
-			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "test_runs" ("test_project_name","test_seed","start_time","end_time") VALUES ($1,$2,$3,$4) RETURNING "id"`)).
-				WithArgs(expectedTestRun.TestProjectName, expectedTestRun.TestSeed, expectedTestRun.StartTime, expectedTestRun.EndTime).
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "test_runs" ("test_project_name","test_seed","start_time","end_time","status","last_activity_time","idempotency_key","git_sha","git_branch","git_repo_url","pull_request_number","ci_provider","build_number","build_url","triggered_by","environment","labels","deleted_at","deleted_by") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19) RETURNING "id"`)).
+				WithArgs(expectedTestRun.TestProjectName, expectedTestRun.TestSeed, expectedTestRun.StartTime, expectedTestRun.EndTime, "passed", expectedTestRun.EndTime, nil, "", "", "", 0, "", "", "", "", "", "{}", nil, "").
 				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 			mock.ExpectCommit()
 
@@ -238,7 +313,7 @@ var _ = Describe("Handlers", func() {
 			}
 
 			mock.ExpectBegin()
-			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 ORDER BY "test_runs"."id" LIMIT $2`)).
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND "test_runs"."deleted_at" IS NULL ORDER BY "test_runs"."id" LIMIT $2`)).
 				WithArgs(expectedTestRun.ID, 1).
 				WillReturnError(errors.New("Record not found DB error"))
 
@@ -298,17 +373,18 @@ var _ = Describe("Handlers", func() {
 				},
 			}
 
-			//mock.ExpectBegin()
 			testRuns := sqlmock.NewRows([]string{"id", "TestProjectName"}).
 				AddRow(1, "project 1")
 
-			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 ORDER BY "test_runs"."id" LIMIT $2`)).
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND "test_runs"."deleted_at" IS NULL ORDER BY "test_runs"."id" LIMIT $2`)).
 				WithArgs(testRun.ID, 1).
 				WillReturnRows(testRuns)
 
-			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE id = $1 ORDER BY "tags"."id" LIMIT $2`)).
-				WithArgs(1, 1).
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE name = $1 ORDER BY "tags"."id" LIMIT $2`)).
+				WithArgs("TagName", 1).
 				WillReturnError(errors.New("database error"))
+			mock.ExpectRollback()
 
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
@@ -374,23 +450,21 @@ var _ = Describe("Handlers", func() {
 			testRuns := sqlmock.NewRows([]string{"id", "TestProjectName"}).
 				AddRow(1, "project 1")
 
-			//mock.ExpectBegin()
-			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 ORDER BY "test_runs"."id" LIMIT $2`)).
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND "test_runs"."deleted_at" IS NULL ORDER BY "test_runs"."id" LIMIT $2`)).
 				WithArgs(testRun.ID, 1).
 				WillReturnRows(testRuns)
 
 			rows := sqlmock.NewRows([]string{"id"})
 
-			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE name = $1 ORDER BY "tags"."id" LIMIT $2`)).WithArgs("TagName", 1).WillReturnRows(rows)
-
+			// Tags and the run tree are stored in one transaction
 			mock.ExpectBegin()
-			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "tags" ("name") VALUES ($1) RETURNING "id"`)).
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE name = $1 ORDER BY "tags"."id" LIMIT $2`)).WithArgs("TagName", 1).WillReturnRows(rows)
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "tags" ("name") VALUES ($1) ON CONFLICT ("name") DO UPDATE SET "name"="excluded"."name" RETURNING "id"`)).
 				WithArgs("TagName").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
-			mock.ExpectCommit()
-
-			mock.ExpectBegin()
-			mock.ExpectQuery(regexp.QuoteMeta(`UPDATE "test_runs" SET "test_project_name"=$1,"test_seed"=$2,"start_time"=$3,"end_time"=$4 WHERE "id" = $5`)).
-				WithArgs(testRun.TestProjectName, testRun.TestSeed, testRun.StartTime, testRun.EndTime, testRun.ID).
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "test_cases"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "merged_into_id"}).AddRow(1, nil))
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_runs" SET "test_project_name"=$1,"test_seed"=$2,"start_time"=$3,"end_time"=$4,"status"=$5,"last_activity_time"=$6,"idempotency_key"=$7,"git_sha"=$8,"git_branch"=$9,"git_repo_url"=$10,"pull_request_number"=$11,"ci_provider"=$12,"build_number"=$13,"build_url"=$14,"triggered_by"=$15,"environment"=$16,"labels"=$17 WHERE "id" = $18`)).
+				WithArgs(testRun.TestProjectName, testRun.TestSeed, testRun.StartTime, testRun.EndTime, "passed", testRun.EndTime, nil, "", "", "", 0, "", "", "", "", "", "{}", testRun.ID).
 				WillReturnError(errors.New("unable to save record"))
 			mock.ExpectRollback()
 
@@ -422,6 +496,125 @@ var _ = Describe("Handlers", func() {
 
 	})
 
+	Context("when createTestRun handler is invoked with an idempotency key", func() {
+		selectByKey := regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE idempotency_key = $1 ORDER BY "test_runs"."id" LIMIT $2`)
+
+		It("and the key was already used, it should return the original run with 200 OK", func() {
+			mock.ExpectQuery(selectByKey).
+				WithArgs("build-42", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name", "idempotency_key"}).AddRow(7, "TestProject", "build-42"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suite_runs" WHERE "suite_runs"."test_run_id" = $1`)).
+				WithArgs(7).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id"}))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/", bytes.NewBufferString(`{"test_project_name":"TestProject"}`))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Request.Header.Set(handlers.IdempotencyKeyHeader, "build-42")
+
+			handler := handlers.NewHandler(gormDb)
+			handler.CreateTestRun(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+			var testRun models.TestRun
+			Expect(json.Unmarshal(w.Body.Bytes(), &testRun)).To(Succeed())
+			Expect(testRun.ID).To(Equal(uint64(7)))
+		})
+
+		It("and the key is new, it should store it with the run and return 201 Created", func() {
+			mock.ExpectQuery(selectByKey).
+				WithArgs("run-uuid", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "test_runs"`)).
+				WithArgs("TestProject", 0, sqlmock.AnyArg(), sqlmock.AnyArg(), "passed", sqlmock.AnyArg(), "run-uuid", "", "", "", 0, "", "", "", "", "", "{}", nil, "").
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(8))
+			mock.ExpectCommit()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/", bytes.NewBufferString(`{"test_project_name":"TestProject","idempotency_key":"run-uuid"}`))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler := handlers.NewHandler(gormDb)
+			handler.CreateTestRun(c)
+
+			Expect(w.Code).To(Equal(http.StatusCreated))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+
+		It("and a concurrent request stored the run first, it should return that run", func() {
+			mock.ExpectQuery(selectByKey).
+				WithArgs("build-42", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "test_runs"`)).
+				WillReturnError(errors.New(`duplicate key value violates unique constraint "test_runs_idempotency_key_idx"`))
+			mock.ExpectRollback()
+			mock.ExpectQuery(selectByKey).
+				WithArgs("build-42", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "idempotency_key"}).AddRow(9, "build-42"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suite_runs" WHERE "suite_runs"."test_run_id" = $1`)).
+				WithArgs(9).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id"}))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/", bytes.NewBufferString(`{"test_project_name":"TestProject"}`))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Request.Header.Set(handlers.IdempotencyKeyHeader, "build-42")
+
+			handler := handlers.NewHandler(gormDb)
+			handler.CreateTestRun(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+
+	Context("when createTestRun handler is invoked with an invalid payload", func() {
+		It("should list the offending fields and return 400 Bad Request", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/", bytes.NewBufferString(`{"suite_runs":[{"suite_name":"TestSuite","spec_runs":[{"spec_description":"TestSpec","status":"exploded"}]}]}`))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler := handlers.NewHandler(gormDb)
+			handler.CreateTestRun(c)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+			var body struct {
+				Error  string                  `json:"error"`
+				Fields []validation.FieldError `json:"fields"`
+			}
+			Expect(json.Unmarshal(w.Body.Bytes(), &body)).To(Succeed())
+			Expect(body.Error).To(Equal("invalid test run"))
+			Expect(body.Fields).To(HaveLen(2))
+			Expect(body.Fields[0].Path).To(Equal("test_project_name"))
+			Expect(body.Fields[1].Path).To(Equal("suite_runs[0].spec_runs[0].status"))
+		})
+
+		It("with dryRun, it should validate without persisting", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/?dryRun=true", bytes.NewBufferString(`{"test_project_name":"TestProject","suite_runs":[{"suite_name":"TestSuite","spec_runs":[{"spec_description":"TestSpec","status":"PASS"}]}]}`))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler := handlers.NewHandler(gormDb)
+			handler.CreateTestRun(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			Expect(w.Body.String()).To(ContainSubstring(`"valid":true`))
+			Expect(w.Body.String()).To(ContainSubstring(`"status":"passed"`))
+		})
+	})
+
 	Context("When ProcessTags is invoked", func() {
 		var testRun = models.TestRun{
 			ID:              0,
@@ -595,7 +788,7 @@ var _ = Describe("Handlers", func() {
 			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE name = $1 ORDER BY "tags"."id" LIMIT $2`)).WithArgs(tag.Name, 1).WillReturnRows(rows)
 			mock.ExpectBegin()
 
-			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "tags" ("name") VALUES ($1) RETURNING "id"`)).
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "tags" ("name") VALUES ($1) ON CONFLICT ("name") DO UPDATE SET "name"="excluded"."name" RETURNING "id"`)).
 				WithArgs(tag.Name).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 			mock.ExpectCommit()
 
@@ -616,7 +809,7 @@ var _ = Describe("Handlers", func() {
 			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE name = $1 ORDER BY "tags"."id" LIMIT $2`)).WithArgs(tag.Name, 1).WillReturnRows(rows)
 			mock.ExpectBegin()
 
-			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "tags" ("name") VALUES ($1) RETURNING "id"`)).
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "tags" ("name") VALUES ($1) ON CONFLICT ("name") DO UPDATE SET "name"="excluded"."name" RETURNING "id"`)).
 				WithArgs(tag.Name).WillReturnError(errors.New("database error"))
 			mock.ExpectCommit()
 
@@ -680,7 +873,7 @@ var _ = Describe("Handlers", func() {
 	Context("when UpdateTestRun handler is invoked", func() {
 		It("and test run does not exist, it should return 404", func() {
 			rows := sqlmock.NewRows([]string{"ID", "TestProjectName"})
-			mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM \"test_runs\" WHERE id = $1 ORDER BY \"test_runs\".\"id\" LIMIT $2")).
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM \"test_runs\" WHERE id = $1 AND \"test_runs\".\"deleted_at\" IS NULL ORDER BY \"test_runs\".\"id\" LIMIT $2")).
 				WithArgs("123", 1).
 				WillReturnRows(rows)
 
@@ -723,7 +916,7 @@ var _ = Describe("Handlers", func() {
 				},
 			}
 
-			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 ORDER BY "test_runs"."id" LIMIT $2`)).
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND "test_runs"."deleted_at" IS NULL ORDER BY "test_runs"."id" LIMIT $2`)).
 				WithArgs("1", 1).
 				WillReturnRows(mock.NewRows([]string{"id", "test_project_name", "test_seed"}).
 					AddRow(expectedTestRun.ID, expectedTestRun.TestProjectName, expectedTestRun.TestSeed))
@@ -759,7 +952,7 @@ var _ = Describe("Handlers", func() {
 				EndTime:         time.Now(),
 			}
 
-			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 ORDER BY "test_runs"."id" LIMIT $2`)).
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND "test_runs"."deleted_at" IS NULL ORDER BY "test_runs"."id" LIMIT $2`)).
 				WithArgs("1", 1).
 				WillReturnRows(mock.NewRows([]string{"id", "test_project_name", "test_seed", "start_time", "end_time"}).
 					AddRow(expectedTestRun.ID, expectedTestRun.TestProjectName, expectedTestRun.TestSeed, expectedTestRun.StartTime, expectedTestRun.EndTime))
@@ -826,7 +1019,7 @@ var _ = Describe("Handlers", func() {
 			}
 
 			//mock.ExpectBegin()
-			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 ORDER BY "test_runs"."id" LIMIT $2`)).
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND "test_runs"."deleted_at" IS NULL ORDER BY "test_runs"."id" LIMIT $2`)).
 				WithArgs("1", 1).
 				WillReturnRows(mock.NewRows([]string{"id", "test_project_name", "test_seed"}).
 					AddRow(expectedTestRun.ID, expectedTestRun.TestProjectName, expectedTestRun.TestSeed))
@@ -865,8 +1058,8 @@ var _ = Describe("Handlers", func() {
 			testRunRow := sqlmock.NewResult(1, 1)
 
 			mock.ExpectBegin()
-			mock.ExpectExec("DELETE FROM \"test_runs\" WHERE \"test_runs\".\"id\" = \\$1").
-				WithArgs(123).
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_runs" SET "deleted_at"=$1,"deleted_by"=$2 WHERE "test_runs"."deleted_at" IS NULL AND "id" = $3`)).
+				WithArgs(sqlmock.AnyArg(), "", 123).
 				WillReturnResult(testRunRow)
 			mock.ExpectCommit()
 			mock.ExpectClose()
@@ -891,8 +1084,8 @@ var _ = Describe("Handlers", func() {
 		It("should handle error", func() {
 
 			mock.ExpectBegin()
-			mock.ExpectExec("DELETE FROM \"test_runs\" WHERE \"test_runs\".\"id\" = \\$1").
-				WithArgs(123).
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_runs" SET "deleted_at"=$1,"deleted_by"=$2 WHERE "test_runs"."deleted_at" IS NULL AND "id" = $3`)).
+				WithArgs(sqlmock.AnyArg(), "", 123).
 				WillReturnError(sql.ErrConnDone)
 			mock.ExpectRollback()
 			mock.ExpectClose()
@@ -917,8 +1110,8 @@ var _ = Describe("Handlers", func() {
 		It("should handle scenario of no rows affected", func() {
 
 			mock.ExpectBegin()
-			mock.ExpectExec("DELETE FROM \"test_runs\" WHERE \"test_runs\".\"id\" = \\$1").
-				WithArgs(123).
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_runs" SET "deleted_at"=$1,"deleted_by"=$2 WHERE "test_runs"."deleted_at" IS NULL AND "id" = $3`)).
+				WithArgs(sqlmock.AnyArg(), "", 123).
 				WillReturnResult(sqlmock.NewResult(0, 0))
 			mock.ExpectCommit()
 			mock.ExpectClose()
@@ -958,13 +1151,6 @@ var _ = Describe("Handlers", func() {
 		})
 
 		It("should handle invalid id format", func() {
-			mock.ExpectBegin()
-			mock.ExpectExec("DELETE FROM \"test_runs\" WHERE \"test_runs\".\"id\" = \\$1").
-				WithArgs(123).
-				WillReturnResult(sqlmock.NewResult(0, 0))
-			mock.ExpectCommit()
-			mock.ExpectClose()
-
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
 
@@ -1002,7 +1188,7 @@ var _ = Describe("Handlers", func() {
 				AddRow("ProjectF").
 				AddRow("ProjectZ")
 
-			mock.ExpectQuery(regexp.QuoteMeta(`SELECT DISTINCT test_project_name FROM "test_runs" ORDER BY test_project_name asc`)).
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT DISTINCT test_project_name FROM "test_runs" WHERE test_runs.deleted_at IS NULL ORDER BY test_project_name asc`)).
 				WillReturnRows(projectRows)
 
 			gin.SetMode(gin.TestMode)
@@ -1028,11 +1214,11 @@ var _ = Describe("Handlers", func() {
 				AddRow(2, "TestProject", time.Date(2024, 4, 21, 12, 0, 0, 0, time.UTC), 7, 2, 12)
 
 			mock.ExpectQuery(regexp.QuoteMeta(`SELECT suite_runs.id AS suite_run_id, test_runs.test_project_name, 
-			   test_runs.start_time, COUNT(spec_runs.id) FILTER (WHERE spec_runs.status = 'passed') AS total_passed_spec_runs, 
-			   COUNT(spec_runs.id) FILTER (WHERE spec_runs.status = 'skipped') AS total_skipped_spec_runs, COUNT(spec_runs.id) 
+			   test_runs.start_time, COUNT(spec_runs.id) FILTER (WHERE spec_runs.status IN ('passed','flaky')) AS total_passed_spec_runs, 
+			   COUNT(spec_runs.id) FILTER (WHERE spec_runs.status IN ('skipped','pending')) AS total_skipped_spec_runs, COUNT(spec_runs.id) 
            		AS total_spec_runs FROM "test_runs" INNER JOIN suite_runs ON test_runs.id = suite_runs.test_run_id 
 				  INNER JOIN spec_runs ON suite_runs.id = spec_runs.suite_id 
-				  WHERE test_runs.test_project_name = $1 
+				  WHERE test_runs.test_project_name = $1 AND test_runs.deleted_at IS NULL
 				  GROUP BY suite_runs.id, test_runs.test_project_name, test_runs.start_time 
 				  ORDER BY test_runs.start_time`)).WithArgs(projectName).WillReturnRows(rows)
 
@@ -1063,6 +1249,46 @@ var _ = Describe("Handlers", func() {
 			}]`
 			Expect(w.Body.String()).To(MatchJSON(expectedJSON))
 		})
+
+		It("should split the summary by the containers down to the requested level", func() {
+			rows := sqlmock.NewRows([]string{"suite_run_id", "test_project_name", "start_time", "total_passed_spec_runs", "total_skipped_spec_runs", "total_spec_runs", "container_path"}).
+				AddRow(1, "TestProject", time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC), 2, 0, 3, "Cart when empty").
+				AddRow(1, "TestProject", time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC), 4, 1, 5, "Cart when full")
+
+			mock.ExpectQuery(regexp.QuoteMeta(`AS total_spec_runs, COALESCE((SELECT string_agg(hierarchy.container ->> 'text', ' ' ORDER BY hierarchy.position) FROM jsonb_array_elements(spec_runs.containers) WITH ORDINALITY AS hierarchy(container, position) WHERE hierarchy.position <= $1), '') AS container_path FROM "test_runs"`)+`.*`+
+				regexp.QuoteMeta(`WHERE test_runs.test_project_name = $2 AND test_runs.deleted_at IS NULL GROUP BY suite_runs.id, test_runs.test_project_name, test_runs.start_time, container_path ORDER BY test_runs.start_time, container_path`)).
+				WithArgs(2, "TestProject").
+				WillReturnRows(rows)
+
+			w := httptest.NewRecorder()
+			c, router := gin.CreateTestContext(w)
+			handler := handlers.NewHandler(gormDb)
+
+			c.Request, _ = http.NewRequest("GET", "/summary/TestProject/?level=2", nil)
+			router.GET("/summary/:name/", handler.GetTestSummary)
+			router.ServeHTTP(w, c.Request)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+			var summaries []models.TestSummary
+			Expect(json.Unmarshal(w.Body.Bytes(), &summaries)).To(Succeed())
+			Expect(summaries).To(HaveLen(2))
+			Expect(summaries[0].ContainerPath).To(Equal("Cart when empty"))
+			Expect(summaries[1].TotalSpecRuns).To(Equal(int64(5)))
+		})
+
+		It("should reject a negative level", func() {
+			w := httptest.NewRecorder()
+			c, router := gin.CreateTestContext(w)
+			handler := handlers.NewHandler(gormDb)
+
+			c.Request, _ = http.NewRequest("GET", "/summary/TestProject/?level=-1", nil)
+			router.GET("/summary/:name/", handler.GetTestSummary)
+			router.ServeHTTP(w, c.Request)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
 	})
 
 })
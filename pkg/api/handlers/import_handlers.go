@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/importers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+// CreateTestRunFromJUnit accepts a JUnit XML report as the request body and
+// stores it as a test run. The project name is taken from the "project" query
+// parameter, falling back to the name of the <testsuites> element.
+func (h *Handler) CreateTestRunFromJUnit(c *gin.Context) {
+	opts := importers.JUnitOptions{
+		ProjectName:   c.Query("project"),
+		TagProperties: splitQueryList(c.Query("tagProperties")),
+	}
+
+	testRun, err := importers.ParseJUnit(c.Request.Body, opts)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	applyRunSourceQuery(c, testRun)
+	h.saveTestRun(c, testRun)
+}
+
+// CreateTestRunFromGoTest accepts the newline-delimited event stream produced
+// by `go test -json` and stores it as a test run for the "project" query
+// parameter.
+func (h *Handler) CreateTestRunFromGoTest(c *gin.Context) {
+	testRun, err := importers.ParseGoTestJSON(c.Request.Body, c.Query("project"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	applyRunSourceQuery(c, testRun)
+	h.saveTestRun(c, testRun)
+}
+
+// CreateTestRunFromGinkgo accepts a report written by `ginkgo --json-report`
+// and stores it as a test run. The project name is taken from the "project"
+// query parameter, falling back to the description of the first suite.
+func (h *Handler) CreateTestRunFromGinkgo(c *gin.Context) {
+	testRun, err := importers.ParseGinkgoReport(c.Request.Body, c.Query("project"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	applyRunSourceQuery(c, testRun)
+	h.saveTestRun(c, testRun)
+}
+
+// CreateTestRunFromCucumber accepts a cucumber JSON report and stores it as a
+// test run for the "project" query parameter.
+func (h *Handler) CreateTestRunFromCucumber(c *gin.Context) {
+	testRun, err := importers.ParseCucumberJSON(c.Request.Body, c.Query("project"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	applyRunSourceQuery(c, testRun)
+	h.saveTestRun(c, testRun)
+}
+
+// CreateTestRunFromCTRF accepts a Common Test Report Format (CTRF) document
+// and stores it as a test run.
+func (h *Handler) CreateTestRunFromCTRF(c *gin.Context) {
+	testRun, err := importers.ParseCTRF(c.Request.Body, c.Query("project"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	applyRunSourceQuery(c, testRun)
+	h.saveTestRun(c, testRun)
+}
+
+// GetTestRunCTRF exports a stored test run as a CTRF document.
+func (h *Handler) GetTestRunCTRF(c *gin.Context) {
+	var testRun models.TestRun
+	id := c.Param("id")
+	if err := h.db.Preload("SuiteRuns.SpecRuns.Tags").Where("id = ?", id).First(&testRun).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "test run not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, importers.ToCTRF(testRun))
+}
+
+// applyRunSourceQuery sets the CI/VCS metadata of an imported run from query
+// parameters, as most report formats have no place for it. Parameters that are
+// present override values found in the report.
+func applyRunSourceQuery(c *gin.Context, testRun *models.TestRun) {
+	for param, field := range map[string]*string{
+		"commit":      &testRun.GitSha,
+		"branch":      &testRun.GitBranch,
+		"repoUrl":     &testRun.GitRepoURL,
+		"ciProvider":  &testRun.CIProvider,
+		"buildNumber": &testRun.BuildNumber,
+		"buildUrl":    &testRun.BuildURL,
+		"actor":       &testRun.TriggeredBy,
+		"environment": &testRun.Environment,
+	} {
+		if value := strings.TrimSpace(c.Query(param)); value != "" {
+			*field = value
+		}
+	}
+	if pr, err := strconv.Atoi(c.Query("pr")); err == nil {
+		testRun.PullRequestNumber = pr
+	}
+}
+
+func splitQueryList(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
@@ -0,0 +1,161 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/importers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/testcases"
+)
+
+var _ = Describe("Import handlers", func() {
+	Context("when CreateTestRunFromJUnit handler is invoked", func() {
+		It("with a valid report, it should store the run and return 201 Created", func() {
+			report := `<testsuites name="Checkout">
+  <testsuite name="CartTest" timestamp="2024-04-20T12:00:00Z">
+    <testcase name="adds an item" time="1"/>
+  </testsuite>
+</testsuites>`
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "test_cases" ("test_project_name","suite_name","spec_description","fingerprint","merged_into_id","created_at") VALUES ($1,$2,$3,$4,$5,$6) ON CONFLICT ("test_project_name","fingerprint") DO UPDATE SET "suite_name"="excluded"."suite_name","spec_description"="excluded"."spec_description" RETURNING "id","merged_into_id"`)).
+				WithArgs("Checkout", "CartTest", "adds an item", testcases.Fingerprint(testcases.FingerprintExact, "CartTest", "adds an item"), nil, sqlmock.AnyArg()).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "merged_into_id"}).AddRow(7, nil))
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "test_runs"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "suite_runs"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "spec_runs"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			mock.ExpectCommit()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/api/testrun/junit", strings.NewReader(report))
+
+			handler := handlers.NewHandler(gormDb)
+			handler.CreateTestRunFromJUnit(c)
+
+			Expect(w.Code).To(Equal(http.StatusCreated))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+			var testRun models.TestRun
+			Expect(json.NewDecoder(w.Body).Decode(&testRun)).To(Succeed())
+			Expect(testRun.TestProjectName).To(Equal("Checkout"))
+			Expect(testRun.SuiteRuns).To(HaveLen(1))
+			Expect(testRun.SuiteRuns[0].SpecRuns[0].Status).To(Equal("passed"))
+		})
+
+		It("with an invalid report, it should return 400 Bad Request", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/api/testrun/junit?project=Checkout", strings.NewReader("not xml"))
+
+			handler := handlers.NewHandler(gormDb)
+			handler.CreateTestRunFromJUnit(c)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("when CreateTestRunFromGoTest handler is invoked", func() {
+		It("without a project name, it should return 400 Bad Request", func() {
+			stream := `{"Action":"pass","Package":"p","Test":"TestA"}`
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/api/testrun/gotest", strings.NewReader(stream))
+
+			handler := handlers.NewHandler(gormDb)
+			handler.CreateTestRunFromGoTest(c)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(w.Body.String()).To(ContainSubstring("project name is required"))
+		})
+	})
+
+	Context("when CreateTestRunFromGinkgo handler is invoked", func() {
+		It("with an empty report, it should return 400 Bad Request", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/api/testrun/ginkgo", strings.NewReader("[]"))
+
+			handler := handlers.NewHandler(gormDb)
+			handler.CreateTestRunFromGinkgo(c)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("when CreateTestRunFromCucumber handler is invoked", func() {
+		It("with an invalid report, it should return 400 Bad Request", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/api/testrun/cucumber?project=shop", strings.NewReader("{}"))
+
+			handler := handlers.NewHandler(gormDb)
+			handler.CreateTestRunFromCucumber(c)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("when GetTestRunCTRF handler is invoked", func() {
+		It("and the test run exists, it should return it as a CTRF report", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND "test_runs"."deleted_at" IS NULL ORDER BY "test_runs"."id" LIMIT $2`)).
+				WithArgs("1", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name", "test_seed"}).AddRow(1, "checkout", 42))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suite_runs" WHERE "suite_runs"."test_run_id" = $1`)).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id", "suite_name"}).AddRow(1, 1, "cart"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_runs" WHERE "spec_runs"."suite_id" = $1`)).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "suite_id", "spec_description", "status"}).AddRow(1, 1, "adds", "passed"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_run_tags" WHERE "spec_run_tags"."spec_run_id" = $1`)).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"spec_run_id", "tag_id"}))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+
+			handler := handlers.NewHandler(gormDb)
+			handler.GetTestRunCTRF(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var report importers.CTRFReport
+			Expect(json.NewDecoder(w.Body).Decode(&report)).To(Succeed())
+			Expect(report.ReportFormat).To(Equal("CTRF"))
+			Expect(report.Results.Extra.TestProjectName).To(Equal("checkout"))
+			Expect(report.Results.Tests).To(HaveLen(1))
+			Expect(report.Results.Tests[0].Suite).To(Equal("cart"))
+			Expect(report.Results.Summary.Passed).To(Equal(1))
+		})
+
+		It("and the test run does not exist, it should return 404 Not Found", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND "test_runs"."deleted_at" IS NULL ORDER BY "test_runs"."id" LIMIT $2`)).
+				WithArgs("1", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+
+			handler := handlers.NewHandler(gormDb)
+			handler.GetTestRunCTRF(c)
+
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
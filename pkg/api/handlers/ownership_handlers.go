@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/ownership"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"github.com/guidewire/fern-reporter/pkg/validation"
+	"gorm.io/gorm"
+)
+
+// GetOwnershipRules lists the ownership rules of a project in evaluation
+// order.
+func (h *Handler) GetOwnershipRules(c *gin.Context) {
+	project, ok := h.findProject(c)
+	if !ok {
+		return
+	}
+	rules := []models.OwnershipRule{}
+	if err := h.db.Where("project_id = ?", project.ID).Order("position").Find(&rules).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error loading ownership rules"})
+		return
+	}
+	c.JSON(http.StatusOK, rules)
+}
+
+// UpdateOwnershipRules replaces the ownership rules of a project with the
+// ordered list in the body. The last matching rule decides the owner of a
+// spec.
+func (h *Handler) UpdateOwnershipRules(c *gin.Context) {
+	project, ok := h.findProject(c)
+	if !ok {
+		return
+	}
+	var rules []models.OwnershipRule
+	if err := c.ShouldBindJSON(&rules); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validation.ValidateOwnershipRules(rules); err != nil {
+		var fieldErrors validation.Errors
+		if errors.As(err, &fieldErrors) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ownership rules", "fields": fieldErrors})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	for i := range rules {
+		rules[i].ID = 0
+		rules[i].ProjectID = project.ID
+		rules[i].Position = i
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("project_id = ?", project.ID).Delete(&models.OwnershipRule{}).Error; err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		return tx.Create(&rules).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error saving ownership rules"})
+		return
+	}
+	if rules == nil {
+		rules = []models.OwnershipRule{}
+	}
+	c.JSON(http.StatusOK, rules)
+}
+
+// ReportOwnerFailures returns the failed and executed spec runs of a project
+// for each owner.
+func (h *Handler) ReportOwnerFailures(c *gin.Context) {
+	startTime, err := ParseTimeFromStringWithDefault(c.Query("startTime"), time.Now().AddDate(-1, 0, 0))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid startTime parameter: %v", err)})
+		return
+	}
+	endTime, err := ParseTimeFromStringWithDefault(c.Query("endTime"), time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid endTime parameter: %v", err)})
+		return
+	}
+
+	ownerFailures, err := GetOwnerFailures(h, c.Param("name"), startTime, endTime)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error loading owner failures"})
+		return
+	}
+	c.JSON(http.StatusOK, ownerFailures)
+}
+
+// ownerSpec is a distinct spec of a project with the values ownership rules
+// match on, and the number of its executed and failed spec runs.
+type ownerSpec struct {
+	SuiteName       string
+	SpecDescription string
+	Containers      models.Containers
+	Tags            []byte
+	Failure         *models.Failure
+	Failed          int
+	Executed        int
+}
+
+// GetOwnerFailures counts the failed and executed spec runs of a project for
+// each owner, most failures first. Spec runs matching no rule are counted
+// under the empty owner. The spec runs are counted per distinct spec in SQL,
+// reading tags and failures only when the rules match on them, and the rules
+// are then matched once per spec.
+func GetOwnerFailures(h *Handler, projectName string, startTimeRange time.Time, endTimeRange time.Time) ([]models.OwnerFailures, error) {
+	matchers, err := ownership.Load(h.db, []string{projectName})
+	if err != nil {
+		return nil, err
+	}
+	matcher := matchers[projectName]
+
+	failed := []string{utils.StatusFailed, utils.StatusErrored}
+	tags := "NULL::jsonb"
+	if matcher.MatchesTags() {
+		tags = `(SELECT jsonb_agg(tags.name) FROM spec_run_tags 
+			INNER JOIN tags ON tags.id = spec_run_tags.tag_id 
+			WHERE spec_run_tags.spec_run_id = spec_runs.id)`
+	}
+	failure := "NULL::bytea"
+	var failureArgs []interface{}
+	if matcher.MatchesFiles() {
+		// Only failed spec runs report a file
+		failure = "CASE WHEN spec_runs.status IN ? THEN spec_runs.failure END"
+		failureArgs = append(failureArgs, failed)
+	}
+
+	specRuns := h.db.Table("test_runs").Scopes(models.LiveTestRuns).
+		Joins("INNER JOIN suite_runs ON test_runs.id = suite_runs.test_run_id").
+		Joins("INNER JOIN spec_runs ON suite_runs.id = spec_runs.suite_id").
+		Select("suite_runs.suite_name, spec_runs.spec_description, spec_runs.containers, spec_runs.status, "+
+			tags+" AS tags, "+failure+" AS failure", failureArgs...).
+		Where("test_runs.test_project_name = ?", projectName).
+		Where("test_runs.start_time >= ?", startTimeRange).
+		Where("test_runs.start_time <= ?", endTimeRange).
+		Where("spec_runs.status NOT IN ?", []string{utils.StatusSkipped, utils.StatusPending})
+
+	var specs []ownerSpec
+	err = h.db.Table("(?) AS specs", specRuns).
+		Select("suite_name, spec_description, containers, tags, failure, "+
+			"COUNT(*) FILTER (WHERE status IN ?) AS failed, COUNT(*) AS executed", failed).
+		Group("suite_name, spec_description, containers, tags, failure").
+		Scan(&specs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byOwner := map[string]*models.OwnerFailures{}
+	for _, spec := range specs {
+		specRun := models.SpecRun{SpecDescription: spec.SpecDescription, Containers: spec.Containers, Failure: spec.Failure}
+		if len(spec.Tags) > 0 {
+			var names []string
+			if err := json.Unmarshal(spec.Tags, &names); err != nil {
+				return nil, err
+			}
+			for _, name := range names {
+				specRun.Tags = append(specRun.Tags, models.Tag{Name: name})
+			}
+		}
+		owner := matcher.Owner(ownership.SpecOf(spec.SuiteName, specRun))
+
+		counts, ok := byOwner[owner]
+		if !ok {
+			counts = &models.OwnerFailures{Owner: owner}
+			byOwner[owner] = counts
+		}
+		counts.Executed += spec.Executed
+		counts.Failed += spec.Failed
+	}
+
+	ownerFailures := make([]models.OwnerFailures, 0, len(byOwner))
+	for _, counts := range byOwner {
+		ownerFailures = append(ownerFailures, *counts)
+	}
+	sort.Slice(ownerFailures, func(i, j int) bool {
+		if ownerFailures[i].Failed != ownerFailures[j].Failed {
+			return ownerFailures[i].Failed > ownerFailures[j].Failed
+		}
+		return ownerFailures[i].Owner < ownerFailures[j].Owner
+	})
+	return ownerFailures, nil
+}
+
+// applyOwners sets the owners of the spec runs of the test runs. Failures are
+// only logged, leaving the spec runs without owners.
+func (h *Handler) applyOwners(testRuns []models.TestRun) {
+	if err := ownership.ApplyAll(h.db, testRuns); err != nil {
+		log.Printf("error loading ownership rules: %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Ownership handlers", func() {
+	selectProject := regexp.QuoteMeta(`SELECT * FROM "projects" WHERE slug = $1 ORDER BY "projects"."id" LIMIT $2`)
+	selectRules := regexp.QuoteMeta(`SELECT ownership_rules.*, projects.name AS project_name FROM "ownership_rules" INNER JOIN projects ON projects.id = ownership_rules.project_id WHERE projects.name IN ($1) ORDER BY ownership_rules.project_id, ownership_rules.position`)
+	ruleColumns := []string{"id", "project_id", "position", "suite", "spec", "file", "tag", "owner", "project_name"}
+
+	Context("when UpdateOwnershipRules handler is invoked", func() {
+		It("should replace the rules of the project in order", func() {
+			mock.ExpectQuery(selectProject).
+				WithArgs("checkout", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "slug", "name"}).AddRow(4, "checkout", "Checkout"))
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "ownership_rules" WHERE project_id = $1`)).
+				WithArgs(4).
+				WillReturnResult(sqlmock.NewResult(0, 3))
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "ownership_rules" ("project_id","position","suite","spec","file","tag","owner") VALUES ($1,$2,$3,$4,$5,$6,$7),($8,$9,$10,$11,$12,$13,$14) RETURNING "id"`)).
+				WithArgs(4, 0, "Checkout*", "", "", "", "payments", 4, 1, "", "", "", "slow", "performance").
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7).AddRow(8))
+			mock.ExpectCommit()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "name", Value: "checkout"}}
+			c.Request, _ = http.NewRequest("PUT", "/api/projects/checkout/ownership", strings.NewReader(
+				`[{"suite": "Checkout*", "owner": "payments"}, {"tag": "slow", "owner": "performance"}]`))
+
+			handlers.NewHandler(gormDb).UpdateOwnershipRules(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			Expect(w.Body.String()).To(Equal(`[{"suite":"Checkout*","owner":"payments"},{"tag":"slow","owner":"performance"}]`))
+		})
+
+		It("should reject rules without patterns", func() {
+			mock.ExpectQuery(selectProject).
+				WithArgs("checkout", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "slug", "name"}).AddRow(4, "checkout", "Checkout"))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "name", Value: "checkout"}}
+			c.Request, _ = http.NewRequest("PUT", "/api/projects/checkout/ownership", strings.NewReader(`[{"owner": "payments"}]`))
+
+			handlers.NewHandler(gormDb).UpdateOwnershipRules(c)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(w.Body.String()).To(ContainSubstring(`"path":"[0]"`))
+		})
+	})
+
+	Context("when a report shows spec runs", func() {
+		It("should include the owner of every spec run", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND "test_runs"."deleted_at" IS NULL ORDER BY "test_runs"."id" LIMIT $2`)).
+				WithArgs("2", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name"}).AddRow(2, "Checkout"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suite_runs" WHERE "suite_runs"."test_run_id" = $1`)).
+				WithArgs(2).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id", "suite_name"}).AddRow(3, 2, "Checkout API"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_runs" WHERE "spec_runs"."suite_id" = $1`)).
+				WithArgs(3).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "suite_id", "spec_description", "status"}).
+					AddRow(5, 3, "pays", "passed").
+					AddRow(6, 3, "loads", "failed"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_run_tags" WHERE "spec_run_tags"."spec_run_id" IN ($1,$2)`)).
+				WithArgs(5, 6).
+				WillReturnRows(sqlmock.NewRows([]string{"spec_run_id", "tag_id"}).AddRow(6, 1))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE "tags"."id" = $1`)).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "slow"))
+			mock.ExpectQuery(selectRules).
+				WithArgs("Checkout").
+				WillReturnRows(sqlmock.NewRows(ruleColumns).
+					AddRow(7, 4, 0, "Checkout*", "", "", "", "payments", "Checkout").
+					AddRow(8, 4, 1, "", "", "", "slow", "performance", "Checkout"))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "2"}}
+			c.Request, _ = http.NewRequest("GET", "/api/reports/testruns/2/", nil)
+
+			handlers.NewHandler(gormDb).ReportTestRunById(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var report struct {
+				TestRuns []models.TestRun `json:"testRuns"`
+			}
+			Expect(json.Unmarshal(w.Body.Bytes(), &report)).To(Succeed())
+			specRuns := report.TestRuns[0].SuiteRuns[0].SpecRuns
+			Expect(specRuns[0].Owner).To(Equal("payments"))
+			Expect(specRuns[1].Owner).To(Equal("performance"))
+		})
+	})
+
+	Context("when ReportOwnerFailures handler is invoked", func() {
+		It("should count the failures of each owner", func() {
+			mock.ExpectQuery(selectRules).
+				WithArgs("Checkout").
+				WillReturnRows(sqlmock.NewRows(ruleColumns).AddRow(7, 4, 0, "Checkout*", "", "", "", "payments", "Checkout"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT suite_name, spec_description, containers, tags, failure, COUNT(*) FILTER (WHERE status IN ($1,$2)) AS failed, COUNT(*) AS executed FROM (SELECT suite_runs.suite_name, spec_runs.spec_description, spec_runs.containers, spec_runs.status, NULL::jsonb AS tags, NULL::bytea AS failure FROM "test_runs" INNER JOIN suite_runs ON test_runs.id = suite_runs.test_run_id INNER JOIN spec_runs ON suite_runs.id = spec_runs.suite_id WHERE test_runs.test_project_name = $3 AND test_runs.start_time >= $4 AND test_runs.start_time <= $5 AND spec_runs.status NOT IN ($6,$7) AND test_runs.deleted_at IS NULL) AS specs GROUP BY suite_name, spec_description, containers, tags, failure`)).
+				WithArgs("failed", "errored", "Checkout", sqlmock.AnyArg(), sqlmock.AnyArg(), "skipped", "pending").
+				WillReturnRows(sqlmock.NewRows([]string{"suite_name", "spec_description", "containers", "tags", "failure", "failed", "executed"}).
+					AddRow("Checkout API", "pays", nil, nil, nil, 1, 1).
+					AddRow("Checkout API", "refunds", nil, nil, nil, 0, 1).
+					AddRow("Search", "finds", nil, nil, nil, 1, 1))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "name", Value: "Checkout"}}
+			c.Request, _ = http.NewRequest("GET", "/api/reports/insights/Checkout/owners", nil)
+
+			handlers.NewHandler(gormDb).ReportOwnerFailures(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			Expect(w.Body.String()).To(Equal(`[{"owner":"","failed":1,"executed":1},{"owner":"payments","failed":1,"executed":2}]`))
+		})
+
+		It("should only read the tags and failures of spec runs when rules match on them", func() {
+			failure, err := models.Failure{Message: "timed out", File: "search/slow_test.go"}.Value()
+			Expect(err).NotTo(HaveOccurred())
+
+			mock.ExpectQuery(selectRules).
+				WithArgs("Checkout").
+				WillReturnRows(sqlmock.NewRows(ruleColumns).
+					AddRow(7, 4, 0, "", "", "search/*", "", "search", "Checkout").
+					AddRow(8, 4, 1, "", "", "", "perf", "performance", "Checkout"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT suite_name, spec_description, containers, tags, failure, COUNT(*) FILTER (WHERE status IN ($1,$2)) AS failed, COUNT(*) AS executed FROM (SELECT suite_runs.suite_name, spec_runs.spec_description, spec_runs.containers, spec_runs.status, (SELECT jsonb_agg(tags.name) FROM spec_run_tags`)).
+				WithArgs("failed", "errored", "failed", "errored", "Checkout", sqlmock.AnyArg(), sqlmock.AnyArg(), "skipped", "pending").
+				WillReturnRows(sqlmock.NewRows([]string{"suite_name", "spec_description", "containers", "tags", "failure", "failed", "executed"}).
+					AddRow("Search", "finds", nil, nil, failure, 2, 2).
+					AddRow("Search", "finds", nil, nil, nil, 0, 3).
+					AddRow("Search", "sorts", nil, `["perf"]`, nil, 0, 1))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "name", Value: "Checkout"}}
+			c.Request, _ = http.NewRequest("GET", "/api/reports/insights/Checkout/owners", nil)
+
+			handlers.NewHandler(gormDb).ReportOwnerFailures(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			Expect(w.Body.String()).To(Equal(`[{"owner":"search","failed":2,"executed":2},{"owner":"","failed":0,"executed":3},{"owner":"performance","failed":0,"executed":1}]`))
+		})
+	})
+})
@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/config"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/validation"
+	"gorm.io/gorm"
+)
+
+// GetProjects lists the registered projects by name.
+func (h *Handler) GetProjects(c *gin.Context) {
+	projects := []models.Project{}
+	if err := h.db.Order("name").Find(&projects).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error loading projects"})
+		return
+	}
+	c.JSON(http.StatusOK, projects)
+}
+
+// GetProject returns the project with the slug in the path.
+func (h *Handler) GetProject(c *gin.Context) {
+	project, ok := h.findProject(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, project)
+}
+
+// CreateProject registers a project. Its name is the test_project_name its
+// runs report.
+func (h *Handler) CreateProject(c *gin.Context) {
+	var project models.Project
+	if err := c.ShouldBindJSON(&project); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	project.ID = 0
+	h.saveProject(c, &project, http.StatusCreated)
+}
+
+// UpdateProject changes the settings of a project. Fields missing from the
+// body keep their values.
+func (h *Handler) UpdateProject(c *gin.Context) {
+	project, ok := h.findProject(c)
+	if !ok || rejectOutOfScopeProject(c, project.Name) {
+		return
+	}
+	id := project.ID
+	if err := c.ShouldBindJSON(&project); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	project.ID = id
+	h.saveProject(c, &project, http.StatusOK)
+}
+
+// DeleteProject unregisters a project. Its test runs are kept.
+func (h *Handler) DeleteProject(c *gin.Context) {
+	project, ok := h.findProject(c)
+	if !ok || rejectOutOfScopeProject(c, project.Name) {
+		return
+	}
+	if err := h.db.Delete(&project).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error deleting project"})
+		return
+	}
+	c.JSON(http.StatusOK, project)
+}
+
+func (h *Handler) findProject(c *gin.Context) (models.Project, bool) {
+	var project models.Project
+	err := h.db.Where("slug = ?", c.Param("name")).First(&project).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "project not found"})
+		return project, false
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error loading project"})
+		return project, false
+	}
+	return project, true
+}
+
+func (h *Handler) saveProject(c *gin.Context, project *models.Project, status int) {
+	if rejectOutOfScopeProject(c, project.Name) {
+		return
+	}
+	if err := validation.ValidateProject(project); err != nil {
+		var fieldErrors validation.Errors
+		if errors.As(err, &fieldErrors) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid project", "fields": fieldErrors})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var conflicts int64
+	if err := h.db.Model(&models.Project{}).
+		Where("(name = ? OR slug = ?) AND id <> ?", project.Name, project.Slug, project.ID).
+		Count(&conflicts).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error saving project"})
+		return
+	}
+	if conflicts > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "a project with this name or slug already exists"})
+		return
+	}
+
+	if err := h.db.Save(project).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error saving project"})
+		return
+	}
+	c.JSON(status, project)
+}
+
+// unregisteredProject reports whether runs of the project must be rejected
+// because projects must be registered before they report runs.
+func (h *Handler) unregisteredProject(projectName string) (bool, error) {
+	if !config.GetProjects().RequireRegistration {
+		return false, nil
+	}
+	var count int64
+	err := h.db.Model(&models.Project{}).Where("name = ?", projectName).Count(&count).Error
+	return count == 0, err
+}
+
+// rejectUnregisteredProject answers the request when the test run belongs to a
+// project that must be registered first.
+func (h *Handler) rejectUnregisteredProject(c *gin.Context, projectName string) bool {
+	unregistered, err := h.unregisteredProject(projectName)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error loading project"})
+		return true
+	}
+	if unregistered {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": unregisteredProjectMessage(projectName)})
+		return true
+	}
+	return false
+}
+
+func unregisteredProjectMessage(projectName string) string {
+	return fmt.Sprintf("project %q is not registered", projectName)
+}
+
+// projectInScope tells whether the token of the request may write to the
+// project. Requests are not limited to a project when auth is disabled.
+func projectInScope(c *gin.Context, projectName string) bool {
+	scopeProjectName := c.GetString("fernProjectName")
+	return scopeProjectName == "" || scopeProjectName == projectName
+}
+
+// rejectOutOfScopeProject answers the request when its token may not write
+// to the project of the test run it acts on.
+func rejectOutOfScopeProject(c *gin.Context, projectName string) bool {
+	if projectInScope(c, projectName) {
+		return false
+	}
+	c.JSON(http.StatusForbidden, gin.H{"error": outOfScopeProjectMessage})
+	return true
+}
+
+const outOfScopeProjectMessage = "project name does not match fern project scope claim"
+
+// inScopeTestRuns limits a query of test runs to the project the token of the
+// request may write to, so that the runs of other projects are not found.
+func inScopeTestRuns(c *gin.Context) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		projectName := c.GetString("fernProjectName")
+		if projectName == "" {
+			return db
+		}
+		return db.Where("test_project_name = ?", projectName)
+	}
+}
+
+// inScopeAttachments limits a query of attachments to those of the test runs
+// of the project the token of the request may write to.
+func inScopeAttachments(c *gin.Context) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		projectName := c.GetString("fernProjectName")
+		if projectName == "" {
+			return db
+		}
+		testRuns := db.Session(&gorm.Session{NewDB: true}).Unscoped().Model(&models.TestRun{}).
+			Select("id").Where("test_project_name = ?", projectName)
+		return db.Where("test_run_id IN (?)", testRuns)
+	}
+}
+
+// reportHeader returns the header of reports showing runs of the projects: the
+// report header of the project when they all belong to the same one, and the
+// configured header otherwise.
+func (h *Handler) reportHeader(projectNames ...string) string {
+	if len(projectNames) == 0 || projectNames[0] == "" {
+		return config.GetHeaderName()
+	}
+	for _, name := range projectNames[1:] {
+		if name != projectNames[0] {
+			return config.GetHeaderName()
+		}
+	}
+
+	var headers []string
+	h.db.Model(&models.Project{}).Where("name = ? AND report_header <> ''", projectNames[0]).Pluck("report_header", &headers)
+	if len(headers) == 0 {
+		return config.GetHeaderName()
+	}
+	return headers[0]
+}
+
+// runProjectNames returns the project names of the test runs.
+func runProjectNames(testRuns []models.TestRun) []string {
+	names := make([]string, len(testRuns))
+	for i, testRun := range testRuns {
+		names[i] = testRun.TestProjectName
+	}
+	return names
+}
@@ -0,0 +1,199 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/config"
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Project handlers", func() {
+	countConflicts := regexp.QuoteMeta(`SELECT count(*) FROM "projects" WHERE (name = $1 OR slug = $2) AND id <> $3`)
+	selectProject := regexp.QuoteMeta(`SELECT * FROM "projects" WHERE slug = $1 ORDER BY "projects"."id" LIMIT $2`)
+
+	BeforeEach(func() {
+		_, err := config.LoadConfig()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("when CreateProject handler is invoked", func() {
+		It("should register the project with a slug derived from its name", func() {
+			mock.ExpectQuery(countConflicts).
+				WithArgs("Checkout Service", "checkout-service", 0).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "projects" ("slug","name","display_name","description","team","default_branch","retention_days","retention_runs_per_branch","report_header","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11) RETURNING "id"`)).
+				WithArgs("checkout-service", "Checkout Service", "Checkout Service", "", "payments", "main", 90, 0, "Checkout Acceptance Tests", sqlmock.AnyArg(), sqlmock.AnyArg()).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+			mock.ExpectCommit()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/api/projects/", strings.NewReader(`{"name": "Checkout Service", "team": "payments", "default_branch": "main", "retention_days": 90, "report_header": "Checkout Acceptance Tests"}`))
+
+			handlers.NewHandler(gormDb).CreateProject(c)
+
+			Expect(w.Code).To(Equal(http.StatusCreated))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			var project models.Project
+			Expect(json.Unmarshal(w.Body.Bytes(), &project)).To(Succeed())
+			Expect(project.ID).To(Equal(uint64(4)))
+			Expect(project.Slug).To(Equal("checkout-service"))
+		})
+
+		It("should refuse a name or slug that is taken", func() {
+			mock.ExpectQuery(countConflicts).
+				WithArgs("Checkout", "checkout", 0).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/api/projects/", strings.NewReader(`{"name": "Checkout"}`))
+
+			handlers.NewHandler(gormDb).CreateProject(c)
+
+			Expect(w.Code).To(Equal(http.StatusConflict))
+		})
+
+		It("should list invalid fields", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/api/projects/", strings.NewReader(`{"name": "Checkout", "slug": "Check Out"}`))
+
+			handlers.NewHandler(gormDb).CreateProject(c)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(w.Body.String()).To(ContainSubstring(`"path":"slug"`))
+		})
+
+		It("should refuse projects the token may not write to", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/api/projects/", strings.NewReader(`{"name": "Checkout", "retention_days": 1}`))
+			c.Set("fernProjectName", "Search")
+
+			handlers.NewHandler(gormDb).CreateProject(c)
+
+			Expect(w.Code).To(Equal(http.StatusForbidden))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+
+	Context("when UpdateProject handler is invoked", func() {
+		It("should only change the fields in the body", func() {
+			mock.ExpectQuery(selectProject).
+				WithArgs("checkout", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "slug", "name", "display_name", "team"}).
+					AddRow(4, "checkout", "Checkout", "Checkout", "payments"))
+			mock.ExpectQuery(countConflicts).
+				WithArgs("Checkout", "checkout", 4).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "projects" SET "slug"=$1,"name"=$2,"display_name"=$3,"description"=$4,"team"=$5,"default_branch"=$6,"retention_days"=$7,"retention_runs_per_branch"=$8,"report_header"=$9,"created_at"=$10,"updated_at"=$11 WHERE "id" = $12`)).
+				WithArgs("checkout", "Checkout", "Checkout", "", "payments", "", 0, 0, "Checkout Report", sqlmock.AnyArg(), sqlmock.AnyArg(), 4).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "name", Value: "checkout"}}
+			c.Request, _ = http.NewRequest("PUT", "/api/projects/checkout", strings.NewReader(`{"id": 9, "report_header": "Checkout Report"}`))
+
+			handlers.NewHandler(gormDb).UpdateProject(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+
+		It("should report unknown projects", func() {
+			mock.ExpectQuery(selectProject).
+				WithArgs("unknown", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "name", Value: "unknown"}}
+			c.Request, _ = http.NewRequest("PUT", "/api/projects/unknown", strings.NewReader(`{}`))
+
+			handlers.NewHandler(gormDb).UpdateProject(c)
+
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Context("when projects must be registered", func() {
+		BeforeEach(func() {
+			config.GetProjects().RequireRegistration = true
+		})
+
+		It("should reject runs of unregistered projects", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "projects" WHERE name = $1`)).
+				WithArgs("Unknown").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/api/testrun/", strings.NewReader(`{"test_project_name": "Unknown", "suite_runs": []}`))
+
+			handlers.NewHandler(gormDb).CreateTestRun(c)
+
+			Expect(w.Code).To(Equal(http.StatusUnprocessableEntity))
+			Expect(w.Body.String()).To(ContainSubstring(`project \"Unknown\" is not registered`))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+
+		It("should reject the lines of unregistered projects in bulk uploads", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "projects" WHERE name = $1`)).
+				WithArgs("Unknown").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/api/testrun/bulk", strings.NewReader(
+				`{"test_project_name": "Unknown"}`+"\n"+`{"test_project_name": "Unknown"}`+"\n"))
+
+			handlers.NewHandler(gormDb).CreateTestRunsBulk(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var report handlers.BulkReport
+			Expect(json.Unmarshal(w.Body.Bytes(), &report)).To(Succeed())
+			Expect(report.Failed).To(Equal(2))
+			Expect(report.Results[1].Error).To(Equal(`project "Unknown" is not registered`))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+
+	Context("when a report shows the runs of a single project", func() {
+		It("should use the report header of the project", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND "test_runs"."deleted_at" IS NULL ORDER BY "test_runs"."id" LIMIT $2`)).
+				WithArgs("2", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name"}).AddRow(2, "Checkout"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suite_runs" WHERE "suite_runs"."test_run_id" = $1`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id"}))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT "report_header" FROM "projects" WHERE name = $1 AND report_header <> ''`)).
+				WithArgs("Checkout").
+				WillReturnRows(sqlmock.NewRows([]string{"report_header"}).AddRow("Checkout Acceptance Tests"))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "2"}}
+			c.Request, _ = http.NewRequest("GET", "/api/reports/testruns/2/", nil)
+
+			handlers.NewHandler(gormDb).ReportTestRunById(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			Expect(w.Body.String()).To(ContainSubstring(`"reportHeader":"Checkout Acceptance Tests"`))
+		})
+	})
+})
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/config"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/quarantine"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"github.com/guidewire/fern-reporter/pkg/validation"
+)
+
+type createQuarantineRuleRequest struct {
+	TestCaseID      *uint64    `json:"test_case_id"`
+	SuiteName       string     `json:"suite_name"`
+	SpecDescription string     `json:"spec_description"`
+	Reason          string     `json:"reason"`
+	ExpiresAt       *time.Time `json:"expires_at"`
+}
+
+type updateQuarantineRuleRequest struct {
+	Reason    *string    `json:"reason"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// GetQuarantineRules lists the active quarantine rules of a project, oldest
+// first, so that clients can skip the quarantined specs. ?all=true includes
+// the rules that expired or were lifted.
+func (h *Handler) GetQuarantineRules(c *gin.Context) {
+	projectName := c.Param("name")
+	var rules []models.QuarantineRule
+	var err error
+	if all, _ := strconv.ParseBool(c.Query("all")); all {
+		rules = []models.QuarantineRule{}
+		err = h.db.Where("test_project_name = ?", projectName).Order("id").Find(&rules).Error
+	} else {
+		rules, err = quarantine.Active(h.db, []string{projectName}, time.Now())
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error loading quarantine rules"})
+		return
+	}
+	c.JSON(http.StatusOK, rules)
+}
+
+// CreateQuarantineRule quarantines a spec of a project, given by its test
+// case or by its suite name and spec description. The rule expires after the
+// configured default duration unless expires_at is set.
+func (h *Handler) CreateQuarantineRule(c *gin.Context) {
+	if rejectOutOfScopeProject(c, c.Param("name")) {
+		return
+	}
+	var request createQuarantineRuleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	now := time.Now()
+	rule := models.QuarantineRule{
+		TestProjectName: c.Param("name"),
+		TestCaseID:      request.TestCaseID,
+		SuiteName:       request.SuiteName,
+		SpecDescription: request.SpecDescription,
+		Reason:          request.Reason,
+		Source:          quarantine.SourceManual,
+		CreatedBy:       requestUser(c),
+		CreatedAt:       now,
+		ExpiresAt:       now.Add(config.GetQuarantine().DefaultDuration),
+	}
+	if request.ExpiresAt != nil {
+		rule.ExpiresAt = *request.ExpiresAt
+	}
+	if rule.TestCaseID != nil {
+		var testCase models.TestCase
+		if err := h.db.Where("id = ? AND test_project_name = ?", *rule.TestCaseID, rule.TestProjectName).First(&testCase).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "test case not found"})
+			return
+		}
+		rule.SuiteName = testCase.SuiteName
+		rule.SpecDescription = testCase.SpecDescription
+	}
+	if !validQuarantineRule(c, &rule, now) {
+		return
+	}
+
+	if err := h.db.Create(&rule).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error saving quarantine rule"})
+		return
+	}
+	c.JSON(http.StatusCreated, rule)
+}
+
+// UpdateQuarantineRule changes the reason or the expiry of a quarantine rule,
+// for example to extend it while a fix is under way.
+func (h *Handler) UpdateQuarantineRule(c *gin.Context) {
+	if rejectOutOfScopeProject(c, c.Param("name")) {
+		return
+	}
+	var request updateQuarantineRuleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var rule models.QuarantineRule
+	if err := h.db.Where("id = ? AND test_project_name = ?", c.Param("id"), c.Param("name")).First(&rule).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "quarantine rule not found"})
+		return
+	}
+	if request.Reason != nil {
+		rule.Reason = *request.Reason
+	}
+	if request.ExpiresAt != nil {
+		rule.ExpiresAt = *request.ExpiresAt
+	}
+	if !validQuarantineRule(c, &rule, time.Now()) {
+		return
+	}
+
+	err := h.db.Model(&rule).Updates(map[string]interface{}{
+		"reason":     rule.Reason,
+		"expires_at": rule.ExpiresAt,
+	}).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error saving quarantine rule"})
+		return
+	}
+	c.JSON(http.StatusOK, rule)
+}
+
+// LiftQuarantineRule ends an active quarantine rule now. The rule is kept, so
+// the spec is not quarantined automatically again for the flakiness it was
+// quarantined for.
+func (h *Handler) LiftQuarantineRule(c *gin.Context) {
+	if rejectOutOfScopeProject(c, c.Param("name")) {
+		return
+	}
+	now := time.Now()
+	result := h.db.Model(&models.QuarantineRule{}).
+		Where("id = ? AND test_project_name = ? AND expires_at > ?", c.Param("id"), c.Param("name"), now).
+		Update("expires_at", now)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error lifting quarantine rule"})
+		return
+	} else if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "active quarantine rule not found"})
+		return
+	}
+
+	var rule models.QuarantineRule
+	if err := h.db.Where("id = ?", c.Param("id")).First(&rule).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error loading quarantine rule"})
+		return
+	}
+	c.JSON(http.StatusOK, rule)
+}
+
+// ReportQuarantineHTML renders the quarantine dashboard of a project: its
+// quarantined specs and how they fared since they were quarantined.
+func (h *Handler) ReportQuarantineHTML(c *gin.Context) {
+	projectName := c.Param("name")
+	quarantinedSpecs, err := quarantine.Report(h.db, projectName, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error loading quarantined specs"})
+		return
+	}
+
+	c.HTML(http.StatusOK, "quarantine.html", gin.H{
+		"reportHeader":     h.reportHeader(projectName),
+		"projectName":      projectName,
+		"quarantinedSpecs": quarantinedSpecs,
+	})
+}
+
+// validQuarantineRule validates a rule, responding with 400 Bad Request when
+// it is invalid.
+func validQuarantineRule(c *gin.Context, rule *models.QuarantineRule, now time.Time) bool {
+	err := validation.ValidateQuarantineRule(rule, now)
+	if err == nil {
+		return true
+	}
+	var fieldErrors validation.Errors
+	if errors.As(err, &fieldErrors) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid quarantine rule", "fields": fieldErrors})
+		return false
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	return false
+}
+
+// applyQuarantine marks the quarantined spec runs of the test runs. Failures
+// are only logged, leaving the spec runs unmarked.
+func (h *Handler) applyQuarantine(testRuns []models.TestRun) {
+	if err := quarantine.ApplyAll(h.db, testRuns, time.Now()); err != nil {
+		log.Printf("error loading quarantine rules: %v", err)
+	}
+}
+
+// quarantineFailures marks the quarantined spec runs of a test run whose
+// outcome is being derived, so that they do not fail it. Marks sent by the
+// client are cleared, and rules are only loaded when a spec run failed.
+func (h *Handler) quarantineFailures(testRun *models.TestRun) {
+	quarantine.Clear(testRun)
+	if utils.TestRunOutcome(*testRun) != utils.StatusFailed {
+		return
+	}
+	matchers, err := quarantine.Load(h.db, []string{testRun.TestProjectName}, time.Now())
+	if err != nil {
+		log.Printf("error loading quarantine rules: %v", err)
+		return
+	}
+	matchers[testRun.TestProjectName].Apply(testRun)
+}
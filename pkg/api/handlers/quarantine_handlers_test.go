@@ -0,0 +1,292 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/config"
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+)
+
+var _ = Describe("Quarantine handlers", func() {
+	selectActiveRules := regexp.QuoteMeta(`SELECT * FROM "quarantine_rules" WHERE test_project_name IN ($1) AND expires_at > $2 ORDER BY id`)
+	ruleColumns := []string{"id", "test_project_name", "test_case_id", "suite_name", "spec_description", "reason", "source", "created_by", "created_at", "expires_at"}
+	createdAt := time.Now().Add(-48 * time.Hour)
+	expiresAt := time.Now().Add(24 * time.Hour)
+
+	BeforeEach(func() {
+		_, err := config.LoadConfig()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("when GetQuarantineRules handler is invoked", func() {
+		It("should list the active rules of the project", func() {
+			mock.ExpectQuery(selectActiveRules).
+				WithArgs("Checkout", sqlmock.AnyArg()).
+				WillReturnRows(sqlmock.NewRows(ruleColumns).
+					AddRow(3, "Checkout", 7, "Cart", "adds an item", "JIRA-123", "manual", "jane", createdAt, expiresAt))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "name", Value: "Checkout"}}
+			c.Request, _ = http.NewRequest("GET", "/api/projects/Checkout/quarantine", nil)
+
+			handlers.NewHandler(gormDb).GetQuarantineRules(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			var rules []models.QuarantineRule
+			Expect(json.Unmarshal(w.Body.Bytes(), &rules)).To(Succeed())
+			Expect(rules).To(HaveLen(1))
+			Expect(rules[0].SpecDescription).To(Equal("adds an item"))
+			Expect(*rules[0].TestCaseID).To(Equal(uint64(7)))
+		})
+	})
+
+	Context("when CreateQuarantineRule handler is invoked", func() {
+		It("should quarantine the spec of a test case for the default duration", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_cases" WHERE id = $1 AND test_project_name = $2 ORDER BY "test_cases"."id" LIMIT $3`)).
+				WithArgs(7, "Checkout", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name", "suite_name", "spec_description"}).
+					AddRow(7, "Checkout", "Cart", "adds an item"))
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "quarantine_rules" ("test_project_name","test_case_id","suite_name","spec_description","reason","source","score","created_by","created_at","expires_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING "id"`)).
+				WithArgs("Checkout", 7, "Cart", "adds an item", "JIRA-123", "manual", 0.0, "jane", sqlmock.AnyArg(), sqlmock.AnyArg()).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+			mock.ExpectCommit()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "name", Value: "Checkout"}}
+			c.Request, _ = http.NewRequest("POST", "/api/projects/Checkout/quarantine", strings.NewReader(`{"test_case_id": 7, "reason": "JIRA-123"}`))
+			c.Request.Header.Set("X-Fern-User", "jane")
+
+			handlers.NewHandler(gormDb).CreateQuarantineRule(c)
+
+			Expect(w.Code).To(Equal(http.StatusCreated))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			var rule models.QuarantineRule
+			Expect(json.Unmarshal(w.Body.Bytes(), &rule)).To(Succeed())
+			Expect(rule.ID).To(Equal(uint64(3)))
+			Expect(rule.Source).To(Equal("manual"))
+			Expect(rule.ExpiresAt.Sub(rule.CreatedAt)).To(Equal(config.GetQuarantine().DefaultDuration))
+		})
+
+		It("should reject a rule without a reason", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "name", Value: "Checkout"}}
+			c.Request, _ = http.NewRequest("POST", "/api/projects/Checkout/quarantine", strings.NewReader(
+				`{"suite_name": "Cart", "spec_description": "adds an item"}`))
+
+			handlers.NewHandler(gormDb).CreateQuarantineRule(c)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(w.Body.String()).To(ContainSubstring(`"path":"reason"`))
+		})
+
+		It("should reject the rules of projects the token may not write to", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "name", Value: "Checkout"}}
+			c.Request, _ = http.NewRequest("POST", "/api/projects/Checkout/quarantine", strings.NewReader(
+				`{"suite_name": "Cart", "spec_description": "adds an item", "reason": "JIRA-123"}`))
+			c.Set("fernProjectName", "Search")
+
+			handlers.NewHandler(gormDb).CreateQuarantineRule(c)
+
+			Expect(w.Code).To(Equal(http.StatusForbidden))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+
+	Context("when UpdateQuarantineRule handler is invoked", func() {
+		It("should reject the rules of projects the token may not write to", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "name", Value: "Checkout"}, {Key: "id", Value: "3"}}
+			c.Request, _ = http.NewRequest("PUT", "/api/projects/Checkout/quarantine/3", strings.NewReader(`{"reason": "JIRA-124"}`))
+			c.Set("fernProjectName", "Search")
+
+			handlers.NewHandler(gormDb).UpdateQuarantineRule(c)
+
+			Expect(w.Code).To(Equal(http.StatusForbidden))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+
+	Context("when LiftQuarantineRule handler is invoked", func() {
+		It("should end an active rule now", func() {
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "quarantine_rules" SET "expires_at"=$1 WHERE id = $2 AND test_project_name = $3 AND expires_at > $4`)).
+				WithArgs(sqlmock.AnyArg(), "3", "Checkout", sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "quarantine_rules" WHERE id = $1 ORDER BY "quarantine_rules"."id" LIMIT $2`)).
+				WithArgs("3", 1).
+				WillReturnRows(sqlmock.NewRows(ruleColumns).
+					AddRow(3, "Checkout", nil, "Cart", "adds an item", "JIRA-123", "manual", "jane", createdAt, time.Now()))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "name", Value: "Checkout"}, {Key: "id", Value: "3"}}
+			c.Request, _ = http.NewRequest("DELETE", "/api/projects/Checkout/quarantine/3", nil)
+
+			handlers.NewHandler(gormDb).LiftQuarantineRule(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+
+		It("should return 404 when the rule is not active", func() {
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "quarantine_rules" SET "expires_at"=$1`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectCommit()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "name", Value: "Checkout"}, {Key: "id", Value: "3"}}
+			c.Request, _ = http.NewRequest("DELETE", "/api/projects/Checkout/quarantine/3", nil)
+
+			handlers.NewHandler(gormDb).LiftQuarantineRule(c)
+
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Context("when a quarantined spec fails", func() {
+		It("should mark it and leave it out of the failures of the report", func() {
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.SetFuncMap(template.FuncMap{
+				"CalculateDuration": utils.CalculateDuration,
+				"FormatDate":        utils.FormatDate,
+				"SpecTree":          utils.SpecTree,
+			})
+			router.LoadHTMLGlob("../../views/test_runs.html")
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND "test_runs"."deleted_at" IS NULL ORDER BY "test_runs"."id" LIMIT $2`)).
+				WithArgs("2", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name", "status"}).AddRow(2, "Checkout", "passed"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suite_runs" WHERE "suite_runs"."test_run_id" = $1`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id", "suite_name"}).AddRow(4, 2, "Cart"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_runs" WHERE "spec_runs"."suite_id" = $1`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "suite_id", "spec_description", "status", "test_case_id"}).
+					AddRow(1, 4, "adds an item", "failed", 7).
+					AddRow(2, 4, "empties", "passed", 8))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_run_tags"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"spec_run_id", "tag_id"}))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT ownership_rules.*`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			mock.ExpectQuery(selectActiveRules).
+				WithArgs("Checkout", sqlmock.AnyArg()).
+				WillReturnRows(sqlmock.NewRows(ruleColumns).
+					AddRow(3, "Checkout", 7, "Cart", "adds one item", "JIRA-123", "manual", "jane", createdAt, expiresAt))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "attachments"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			router.GET("/reports/testruns/:id", handlers.NewHandler(gormDb).ReportTestRunByIdHTML)
+			w := httptest.NewRecorder()
+			request, _ := http.NewRequest("GET", "/reports/testruns/2", nil)
+			router.ServeHTTP(w, request)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			doc, err := goquery.NewDocumentFromReader(w.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(doc.Find(".spec-quarantined").Length()).To(Equal(1))
+			Expect(doc.Find(".test-status").First().Text()).To(ContainSubstring("failed"))
+			Expect(doc.Text()).To(ContainSubstring("Failed/ Passed: 0/ 1"))
+		})
+
+		It("should not fail the run it is finalized with", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND "test_runs"."deleted_at" IS NULL ORDER BY "test_runs"."id" LIMIT $2`)).
+				WithArgs("1", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name", "status"}).AddRow(1, "Checkout", "in_progress"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name", "status"}).AddRow(1, "Checkout", "in_progress"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suite_runs" WHERE "suite_runs"."test_run_id" = $1`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id", "suite_name"}).AddRow(4, 1, "Cart"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_runs" WHERE "spec_runs"."suite_id" = $1`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "suite_id", "spec_description", "status"}).
+					AddRow(1, 4, "adds an item", "failed").
+					AddRow(2, 4, "empties", "passed"))
+			mock.ExpectQuery(selectActiveRules).
+				WithArgs("Checkout", sqlmock.AnyArg()).
+				WillReturnRows(sqlmock.NewRows(ruleColumns).
+					AddRow(3, "Checkout", nil, "Cart", "adds an item", "JIRA-123", "manual", "jane", createdAt, expiresAt))
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_runs" SET "end_time"=$1,"last_activity_time"=$2,"status"=$3`)).
+				WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "passed", 1).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "1"}}
+			c.Request, _ = http.NewRequest("POST", "/api/testrun/1/finalize", nil)
+
+			handlers.NewHandler(gormDb).FinalizeTestRun(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			Expect(w.Body.String()).To(ContainSubstring(`"status":"passed"`))
+		})
+	})
+
+	Context("when ReportQuarantineHTML handler is invoked", func() {
+		It("should show how the quarantined specs fared since they were quarantined", func() {
+			mock.ExpectQuery(selectActiveRules).
+				WithArgs("Checkout", sqlmock.AnyArg()).
+				WillReturnRows(sqlmock.NewRows(ruleColumns).
+					AddRow(3, "Checkout", 7, "Cart", "adds an item", "JIRA-123", "manual", "jane", createdAt, expiresAt))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT quarantine_rules.id AS rule_id, COUNT(*) AS executions, `+
+				`COUNT(*) FILTER (WHERE spec_runs.status IN ('failed','errored')) AS failures, `+
+				`(array_agg(spec_runs.status ORDER BY test_runs.start_time DESC, spec_runs.id DESC))[1] AS last_status, `+
+				`(array_agg(test_runs.id ORDER BY test_runs.start_time DESC, spec_runs.id DESC))[1] AS last_test_run_id FROM "quarantine_rules" `+
+				`INNER JOIN test_runs ON test_runs.test_project_name = quarantine_rules.test_project_name AND test_runs.start_time >= quarantine_rules.created_at `+
+				`INNER JOIN suite_runs ON suite_runs.test_run_id = test_runs.id `+
+				`INNER JOIN spec_runs ON spec_runs.suite_id = suite_runs.id AND ((quarantine_rules.test_case_id IS NOT NULL AND spec_runs.test_case_id = quarantine_rules.test_case_id) OR `+
+				`(suite_runs.suite_name = quarantine_rules.suite_name AND spec_runs.spec_description = quarantine_rules.spec_description)) `+
+				`WHERE quarantine_rules.id IN ($1) AND test_runs.start_time <= $2 AND test_runs.deleted_at IS NULL AND spec_runs.status IN ($3,$4,$5,$6) `+
+				`GROUP BY "quarantine_rules"."id"`)).
+				WithArgs(3, sqlmock.AnyArg(), "passed", "flaky", "failed", "errored").
+				WillReturnRows(sqlmock.NewRows([]string{"rule_id", "executions", "failures", "last_status", "last_test_run_id"}).
+					AddRow(3, 2, 1, "passed", 11))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT "report_header" FROM "projects"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"report_header"}))
+
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.SetFuncMap(template.FuncMap{"FormatDate": utils.FormatDate})
+			router.LoadHTMLGlob("../../views/quarantine.html")
+			router.GET("/insights/:name/quarantine", handlers.NewHandler(gormDb).ReportQuarantineHTML)
+			w := httptest.NewRecorder()
+			request, _ := http.NewRequest("GET", "/insights/Checkout/quarantine", nil)
+			router.ServeHTTP(w, request)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			doc, err := goquery.NewDocumentFromReader(w.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(doc.Find(".quarantined-specs tbody tr").Length()).To(Equal(1))
+			Expect(strings.TrimSpace(doc.Find(".quarantined-spec").Text())).To(Equal("adds an item"))
+			Expect(strings.TrimSpace(doc.Find(".quarantine-failures").Text())).To(Equal("1"))
+			Expect(doc.Find(".last-status a").AttrOr("href", "")).To(Equal("/reports/testruns/11"))
+		})
+	})
+})
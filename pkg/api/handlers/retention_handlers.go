@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/config"
+	"github.com/guidewire/fern-reporter/pkg/jobs"
+)
+
+// GetRetentionDryRun reports the test runs the retention purge would delete
+// now, for each project, without deleting anything.
+func (h *Handler) GetRetentionDryRun(c *gin.Context) {
+	retention := config.GetRetention()
+	policy := jobs.RetentionPolicy{Days: retention.Days, RunsPerBranch: retention.RunsPerBranch, TrashGracePeriod: retention.TrashGracePeriod}
+	summaries, err := jobs.SummarizeExpiredTestRuns(h.db, policy, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error loading expired test runs"})
+		return
+	}
+
+	var total int64
+	for _, summary := range summaries {
+		total += summary.Runs
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"enabled":         retention.Enabled,
+		"days":            policy.Days,
+		"runs_per_branch": policy.RunsPerBranch,
+		"projects":        summaries,
+		"total":           total,
+	})
+}
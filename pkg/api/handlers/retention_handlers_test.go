@@ -0,0 +1,46 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/config"
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+)
+
+var _ = Describe("Retention handlers", func() {
+	BeforeEach(func() {
+		_, err := config.LoadConfig()
+		Expect(err).NotTo(HaveOccurred())
+		config.GetRetention().Days = 90
+	})
+
+	It("should report the runs that would be purged without deleting them", func() {
+		oldest := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+		newest := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT ranked.test_project_name, COUNT(*) AS runs`)).
+			WithArgs(90, 0, "in_progress", sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"test_project_name", "runs", "oldest_start_time", "newest_start_time"}).
+				AddRow("Checkout", 12, oldest, newest).
+				AddRow("Search", 3, oldest, newest))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("GET", "/api/admin/retention/dry-run", nil)
+
+		handlers.NewHandler(gormDb).GetRetentionDryRun(c)
+
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+		Expect(w.Body.String()).To(ContainSubstring(`"days":90`))
+		Expect(w.Body.String()).To(ContainSubstring(`{"test_project_name":"Checkout","runs":12,"oldest_start_time":"2024-01-02T00:00:00Z","newest_start_time":"2024-03-04T00:00:00Z"}`))
+		Expect(w.Body.String()).To(ContainSubstring(`"total":15`))
+	})
+})
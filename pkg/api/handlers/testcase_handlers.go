@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/config"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/testcases"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultTestCaseHistory = 100
+	maxTestCaseHistory     = 1000
+)
+
+type mergeTestCasesRequest struct {
+	Project   string   `json:"project" binding:"required"`
+	TargetID  uint64   `json:"target_id" binding:"required"`
+	SourceIDs []uint64 `json:"source_ids" binding:"required"`
+}
+
+// resolveTestCases links the spec runs of the test runs to their test cases
+// using the configured fingerprint.
+func resolveTestCases(tx *gorm.DB, testRuns ...*models.TestRun) error {
+	return testcases.Resolve(tx, config.GetTestCases().Fingerprint, testRuns...)
+}
+
+// GetTestCases lists the test cases of a project that were not merged into
+// another one. ?q= narrows them down by suite name or spec description.
+func (h *Handler) GetTestCases(c *gin.Context) {
+	query := h.db.Where("test_project_name = ? AND merged_into_id IS NULL", c.Param("name"))
+	if q := c.Query("q"); q != "" {
+		query = query.Where("suite_name ILIKE ? OR spec_description ILIKE ?", "%"+q+"%", "%"+q+"%")
+	}
+
+	testCases := []models.TestCase{}
+	if err := query.Order("suite_name, spec_description").Find(&testCases).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error loading test cases"})
+		return
+	}
+	c.JSON(http.StatusOK, testCases)
+}
+
+// GetTestCaseHistory returns every past execution of a test case, newest
+// first. A test case that was merged reports the history of the test case it
+// was merged into. ?limit= bounds the number of executions.
+func (h *Handler) GetTestCaseHistory(c *gin.Context) {
+	limit := defaultTestCaseHistory
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxTestCaseHistory {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and " + strconv.Itoa(maxTestCaseHistory)})
+			return
+		}
+		limit = parsed
+	}
+
+	var testCase models.TestCase
+	if err := h.db.Where("id = ? AND test_project_name = ?", c.Param("id"), c.Param("name")).First(&testCase).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "test case not found"})
+		return
+	}
+	if testCase.MergedIntoID != nil {
+		var target models.TestCase
+		if err := h.db.Where("id = ?", *testCase.MergedIntoID).First(&target).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "test case not found"})
+			return
+		}
+		testCase = target
+	}
+
+	history := []models.TestCaseRun{}
+	err := h.db.Table("spec_runs").
+		Joins("INNER JOIN suite_runs ON suite_runs.id = spec_runs.suite_id").
+		Joins("INNER JOIN test_runs ON test_runs.id = suite_runs.test_run_id").
+		Scopes(models.LiveTestRuns).
+		Select("test_runs.id AS test_run_id, spec_runs.id AS spec_run_id, suite_runs.suite_name, spec_runs.spec_description, "+
+			"spec_runs.status, spec_runs.message, spec_runs.start_time, spec_runs.end_time, "+
+			"EXTRACT(EPOCH FROM (spec_runs.end_time - spec_runs.start_time)) AS duration, "+
+			"test_runs.git_branch, test_runs.git_sha").
+		Where("spec_runs.test_case_id = ?", testCase.ID).
+		Order("spec_runs.start_time DESC").
+		Limit(limit).
+		Scan(&history).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error loading test case history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"test_case": testCase,
+		"history":   history,
+	})
+}
+
+// MergeTestCases folds test cases into another one of the same project, for
+// example after a spec was renamed. The body names the project, the target_id
+// and the source_ids to merge.
+func (h *Handler) MergeTestCases(c *gin.Context) {
+	var request mergeTestCasesRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	moved, err := testcases.Merge(h.db, request.Project, request.TargetID, request.SourceIDs)
+	switch {
+	case errors.Is(err, testcases.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, testcases.ErrInvalidMerge):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error merging test cases"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"target_id":       request.TargetID,
+		"merged_ids":      request.SourceIDs,
+		"moved_spec_runs": moved,
+	})
+}
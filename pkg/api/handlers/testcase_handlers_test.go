@@ -0,0 +1,190 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Test case handlers", func() {
+	selectTestCase := regexp.QuoteMeta(`SELECT * FROM "test_cases" WHERE id = $1 AND test_project_name = $2 ORDER BY "test_cases"."id" LIMIT $3`)
+
+	Context("when GetTestCaseHistory handler is invoked", func() {
+		It("should return every execution of the test case, newest first", func() {
+			start := time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+
+			mock.ExpectQuery(selectTestCase).
+				WithArgs("7", "Checkout", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name", "suite_name", "spec_description"}).
+					AddRow(7, "Checkout", "Cart", "adds an item"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT test_runs.id AS test_run_id, spec_runs.id AS spec_run_id, suite_runs.suite_name, spec_runs.spec_description, spec_runs.status, spec_runs.message, spec_runs.start_time, spec_runs.end_time, EXTRACT(EPOCH FROM (spec_runs.end_time - spec_runs.start_time)) AS duration, test_runs.git_branch, test_runs.git_sha FROM "spec_runs" INNER JOIN suite_runs ON suite_runs.id = spec_runs.suite_id INNER JOIN test_runs ON test_runs.id = suite_runs.test_run_id WHERE spec_runs.test_case_id = $1 AND test_runs.deleted_at IS NULL ORDER BY spec_runs.start_time DESC LIMIT $2`)).
+				WithArgs(7, 2).
+				WillReturnRows(sqlmock.NewRows([]string{"test_run_id", "spec_run_id", "suite_name", "spec_description", "status", "message", "start_time", "end_time", "duration", "git_branch", "git_sha"}).
+					AddRow(2, 20, "Cart", "adds an item", "failed", "expected 1 item", start.Add(time.Hour), start.Add(time.Hour+2*time.Second), 2.0, "main", "0a1b2c3").
+					AddRow(1, 10, "Cart", "adds item", "passed", "", start, start.Add(time.Second), 1.0, "main", "ffeedd0"))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "name", Value: "Checkout"}, {Key: "id", Value: "7"}}
+			c.Request, _ = http.NewRequest("GET", "/api/projects/Checkout/testcases/7/history?limit=2", nil)
+
+			handler := handlers.NewHandler(gormDb)
+			handler.GetTestCaseHistory(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+			var response struct {
+				TestCase models.TestCase      `json:"test_case"`
+				History  []models.TestCaseRun `json:"history"`
+			}
+			Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.TestCase.ID).To(Equal(uint64(7)))
+			Expect(response.History).To(HaveLen(2))
+			Expect(response.History[0].Status).To(Equal("failed"))
+			Expect(response.History[0].Message).To(Equal("expected 1 item"))
+			Expect(response.History[0].Duration).To(Equal(2.0))
+			Expect(response.History[1].SpecDescription).To(Equal("adds item"))
+		})
+
+		It("should report the history of the test case a merged test case was merged into", func() {
+			mock.ExpectQuery(selectTestCase).
+				WithArgs("3", "Checkout", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name", "merged_into_id"}).AddRow(3, "Checkout", 7))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_cases" WHERE id = $1 ORDER BY "test_cases"."id" LIMIT $2`)).
+				WithArgs(7, 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name"}).AddRow(7, "Checkout"))
+			mock.ExpectQuery(regexp.QuoteMeta(`FROM "spec_runs"`)).
+				WithArgs(7, 100).
+				WillReturnRows(sqlmock.NewRows([]string{"test_run_id"}))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "name", Value: "Checkout"}, {Key: "id", Value: "3"}}
+			c.Request, _ = http.NewRequest("GET", "/api/projects/Checkout/testcases/3/history", nil)
+
+			handler := handlers.NewHandler(gormDb)
+			handler.GetTestCaseHistory(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			Expect(w.Body.String()).To(ContainSubstring(`"history":[]`))
+		})
+
+		It("and the test case belongs to another project, it should return 404 Not Found", func() {
+			mock.ExpectQuery(selectTestCase).
+				WithArgs("7", "Search", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "name", Value: "Search"}, {Key: "id", Value: "7"}}
+			c.Request, _ = http.NewRequest("GET", "/api/projects/Search/testcases/7/history", nil)
+
+			handler := handlers.NewHandler(gormDb)
+			handler.GetTestCaseHistory(c)
+
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Context("when GetTestCases handler is invoked", func() {
+		It("should list the test cases of the project matching the query", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_cases" WHERE (test_project_name = $1 AND merged_into_id IS NULL) AND (suite_name ILIKE $2 OR spec_description ILIKE $3) ORDER BY suite_name, spec_description`)).
+				WithArgs("Checkout", "%item%", "%item%").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "suite_name", "spec_description"}).AddRow(7, "Cart", "adds an item"))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "name", Value: "Checkout"}}
+			c.Request, _ = http.NewRequest("GET", "/api/projects/Checkout/testcases?q=item", nil)
+
+			handler := handlers.NewHandler(gormDb)
+			handler.GetTestCases(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			Expect(w.Body.String()).To(ContainSubstring(`"spec_description":"adds an item"`))
+		})
+	})
+
+	Context("when MergeTestCases handler is invoked", func() {
+		It("should move the history and the quarantine rules of the sources to the target", func() {
+			mock.ExpectBegin()
+			mock.ExpectQuery(selectTestCase).
+				WithArgs(7, "Checkout", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name"}).AddRow(7, "Checkout"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "test_cases" WHERE id IN ($1) AND test_project_name = $2`)).
+				WithArgs(3, "Checkout").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "spec_runs" SET "test_case_id"=$1 WHERE test_case_id IN ($2)`)).
+				WithArgs(7, 3).
+				WillReturnResult(sqlmock.NewResult(0, 12))
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "quarantine_rules" SET "test_case_id"=$1 WHERE test_case_id IN ($2)`)).
+				WithArgs(7, 3).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_cases" SET "merged_into_id"=$1 WHERE id IN ($2) OR merged_into_id IN ($3)`)).
+				WithArgs(7, 3, 3).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/api/admin/testcases/merge", strings.NewReader(`{"project":"Checkout","target_id":7,"source_ids":[3]}`))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler := handlers.NewHandler(gormDb)
+			handler.MergeTestCases(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			Expect(w.Body.String()).To(MatchJSON(`{"target_id":7,"merged_ids":[3],"moved_spec_runs":12}`))
+		})
+
+		It("and a source is the target, it should return 400 Bad Request", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/api/admin/testcases/merge", strings.NewReader(`{"project":"Checkout","target_id":7,"source_ids":[7]}`))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler := handlers.NewHandler(gormDb)
+			handler.MergeTestCases(c)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(w.Body.String()).To(ContainSubstring("cannot be merged into itself"))
+		})
+
+		It("and a source belongs to another project, it should return 404 Not Found", func() {
+			mock.ExpectBegin()
+			mock.ExpectQuery(selectTestCase).
+				WithArgs(7, "Checkout", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name"}).AddRow(7, "Checkout"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "test_cases"`)).
+				WithArgs(3, 4, "Checkout").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			mock.ExpectRollback()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/api/admin/testcases/merge", strings.NewReader(`{"project":"Checkout","target_id":7,"source_ids":[3,4]}`))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler := handlers.NewHandler(gormDb)
+			handler.MergeTestCases(c)
+
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+})
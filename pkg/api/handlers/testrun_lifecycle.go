@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"github.com/guidewire/fern-reporter/pkg/validation"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type finalizeTestRunRequest struct {
+	EndTime time.Time `json:"end_time"`
+	Status  string    `json:"status"`
+}
+
+// OpenTestRun creates a test run in the in_progress state. Suite runs and spec
+// runs can then be appended as they finish, and the run is closed with
+// FinalizeTestRun.
+func (h *Handler) OpenTestRun(c *gin.Context) {
+	var testRun models.TestRun
+	if err := c.ShouldBindJSON(&testRun); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	now := time.Now()
+	testRun.ID = 0
+	testRun.Status = utils.RunStatusInProgress
+	testRun.LastActivityTime = now
+	if testRun.StartTime.IsZero() {
+		testRun.StartTime = now
+	}
+
+	h.saveTestRun(c, &testRun)
+}
+
+// AppendSuiteRun adds a suite run, including any spec runs it already holds,
+// to an in-progress test run.
+func (h *Handler) AppendSuiteRun(c *gin.Context) {
+	testRun, ok := h.findInProgressTestRun(c)
+	if !ok {
+		return
+	}
+
+	var suiteRun models.SuiteRun
+	if err := c.ShouldBindJSON(&suiteRun); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	suiteRun.ID = 0
+	suiteRun.TestRunID = testRun.ID
+	if err := validation.ValidateSuiteRun(&suiteRun); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+	if isDryRun(c) {
+		c.JSON(http.StatusOK, gin.H{"valid": true, "suite_run": suiteRun})
+		return
+	}
+
+	tagged := models.TestRun{TestProjectName: testRun.TestProjectName, SuiteRuns: []models.SuiteRun{suiteRun}}
+	if status, message := h.saveTagged(&tagged, func(tx *gorm.DB) error {
+		suiteRun = tagged.SuiteRuns[0]
+		return tx.Create(&suiteRun).Error
+	}); status != 0 {
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+	h.touchTestRun(testRun.ID)
+
+	c.JSON(http.StatusCreated, &suiteRun)
+}
+
+// AppendSpecRuns adds one or more spec runs to a suite run of an in-progress
+// test run. The body is either a single spec run or an array of them.
+func (h *Handler) AppendSpecRuns(c *gin.Context) {
+	testRun, ok := h.findInProgressTestRun(c)
+	if !ok {
+		return
+	}
+
+	var suiteRun models.SuiteRun
+	if err := h.db.Where("id = ? AND test_run_id = ?", c.Param("suiteId"), testRun.ID).First(&suiteRun).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "suite run not found"})
+		return
+	}
+
+	specRuns, err := bindSpecRuns(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(specRuns) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no spec runs provided"})
+		return
+	}
+
+	for i := range specRuns {
+		specRuns[i].ID = 0
+		specRuns[i].SuiteID = suiteRun.ID
+	}
+	if err := validation.ValidateSpecRuns(specRuns); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+	if isDryRun(c) {
+		c.JSON(http.StatusOK, gin.H{"valid": true, "spec_runs": specRuns})
+		return
+	}
+
+	tagged := models.TestRun{
+		TestProjectName: testRun.TestProjectName,
+		SuiteRuns:       []models.SuiteRun{{SuiteName: suiteRun.SuiteName, SpecRuns: specRuns}},
+	}
+	if status, message := h.saveTagged(&tagged, func(tx *gorm.DB) error {
+		specRuns = tagged.SuiteRuns[0].SpecRuns
+		return tx.Create(&specRuns).Error
+	}); status != 0 {
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	// Keep the suite window covering everything reported so far
+	endTime := suiteRun.EndTime
+	for _, specRun := range specRuns {
+		if specRun.EndTime.After(endTime) {
+			endTime = specRun.EndTime
+		}
+	}
+	if endTime.After(suiteRun.EndTime) {
+		h.db.Model(&models.SuiteRun{}).Where("id = ?", suiteRun.ID).Update("end_time", endTime)
+	}
+	h.touchTestRun(testRun.ID)
+
+	c.JSON(http.StatusCreated, specRuns)
+}
+
+// FinalizeTestRun closes an in-progress test run. The outcome may be given
+// explicitly ("passed", "failed" or "aborted"); otherwise it is derived from
+// the stored spec runs, ignoring the quarantined ones. The end time defaults
+// to now.
+func (h *Handler) FinalizeTestRun(c *gin.Context) {
+	testRun, ok := h.findInProgressTestRun(c)
+	if !ok {
+		return
+	}
+
+	var request finalizeTestRunRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	switch request.Status {
+	case utils.StatusPassed, utils.StatusFailed, utils.RunStatusAborted:
+	case "":
+		if err := h.db.Preload("SuiteRuns.SpecRuns").First(&testRun, testRun.ID).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "error loading test run"})
+			return
+		}
+		h.quarantineFailures(&testRun)
+		request.Status = utils.TestRunOutcome(testRun)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be one of passed, failed or aborted"})
+		return
+	}
+
+	now := time.Now()
+	if request.EndTime.IsZero() {
+		request.EndTime = now
+	}
+
+	// The spec runs loaded to derive the outcome are not saved again
+	err := h.db.Model(&testRun).Omit(clause.Associations).Updates(map[string]interface{}{
+		"status":             request.Status,
+		"end_time":           request.EndTime,
+		"last_activity_time": now,
+	}).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error saving record"})
+		return
+	}
+	testRun.Status = request.Status
+	testRun.EndTime = request.EndTime
+	testRun.LastActivityTime = now
+
+	c.JSON(http.StatusOK, &testRun)
+}
+
+// findInProgressTestRun loads the test run named by the "id" path parameter
+// and writes an error response if it does not exist, belongs to a project the
+// token may not write to or is already finished.
+func (h *Handler) findInProgressTestRun(c *gin.Context) (models.TestRun, bool) {
+	var testRun models.TestRun
+	if err := h.db.Scopes(inScopeTestRuns(c)).Where("id = ?", c.Param("id")).First(&testRun).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "test run not found"})
+		return testRun, false
+	}
+	if testRun.Status != utils.RunStatusInProgress {
+		c.JSON(http.StatusConflict, gin.H{"error": "test run is not in progress"})
+		return testRun, false
+	}
+	return testRun, true
+}
+
+// saveTagged processes the tags and test cases of a partial run tree and
+// stores it with save in the same transaction. On failure it returns the HTTP
+// status and message to report.
+func (h *Handler) saveTagged(tagged *models.TestRun, save func(tx *gorm.DB) error) (int, string) {
+	status, message := 0, ""
+	_ = h.db.Transaction(func(tx *gorm.DB) error {
+		if err := ProcessTags(tx, tagged); err != nil {
+			status, message = http.StatusInternalServerError, "error processing tags"
+			return err
+		}
+		if err := resolveTestCases(tx, tagged); err != nil {
+			status, message = http.StatusInternalServerError, "error resolving test cases"
+			return err
+		}
+		if err := save(tx); err != nil {
+			status, message = http.StatusInternalServerError, "error saving record"
+			return err
+		}
+		return nil
+	})
+	return status, message
+}
+
+// touchTestRun records activity on an in-progress run so the reaper does not
+// abort it.
+func (h *Handler) touchTestRun(id uint64) {
+	h.db.Model(&models.TestRun{}).Where("id = ?", id).Update("last_activity_time", time.Now())
+}
+
+func bindSpecRuns(c *gin.Context) ([]models.SpecRun, error) {
+	var specRuns []models.SpecRun
+	if err := c.ShouldBindBodyWith(&specRuns, binding.JSON); err == nil {
+		return specRuns, nil
+	}
+
+	var specRun models.SpecRun
+	if err := c.ShouldBindBodyWith(&specRun, binding.JSON); err != nil {
+		return nil, err
+	}
+	return []models.SpecRun{specRun}, nil
+}
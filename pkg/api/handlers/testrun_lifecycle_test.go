@@ -0,0 +1,432 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/config"
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+)
+
+var _ = Describe("Test run lifecycle handlers", func() {
+	selectTestRun := regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND "test_runs"."deleted_at" IS NULL ORDER BY "test_runs"."id" LIMIT $2`)
+
+	Context("when OpenTestRun handler is invoked", func() {
+		It("should create an in-progress test run and return 201 Created", func() {
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "test_runs" ("test_project_name","test_seed","start_time","end_time","status","last_activity_time","idempotency_key","git_sha","git_branch","git_repo_url","pull_request_number","ci_provider","build_number","build_url","triggered_by","environment","labels","deleted_at","deleted_by") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19) RETURNING "id"`)).
+				WithArgs("TestProject", 0, sqlmock.AnyArg(), sqlmock.AnyArg(), "in_progress", sqlmock.AnyArg(), nil, "", "", "", 0, "", "", "", "", "", "{}", nil, "").
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			mock.ExpectCommit()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/api/testrun/open", strings.NewReader(`{"test_project_name":"TestProject"}`))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler := handlers.NewHandler(gormDb)
+			handler.OpenTestRun(c)
+
+			Expect(w.Code).To(Equal(http.StatusCreated))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+			var testRun models.TestRun
+			Expect(json.NewDecoder(w.Body).Decode(&testRun)).To(Succeed())
+			Expect(testRun.ID).To(Equal(uint64(1)))
+			Expect(testRun.Status).To(Equal("in_progress"))
+			Expect(testRun.StartTime).NotTo(BeZero())
+		})
+	})
+
+	Context("when AppendSuiteRun handler is invoked", func() {
+		It("and the test run is in progress, it should store the suite run", func() {
+			mock.ExpectQuery(selectTestRun).
+				WithArgs("1", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(1, "in_progress"))
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "suite_runs" ("test_run_id","suite_name","start_time","end_time","labels") VALUES ($1,$2,$3,$4,$5) RETURNING "id"`)).
+				WithArgs(1, "TestSuite", sqlmock.AnyArg(), sqlmock.AnyArg(), "{}").
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+			mock.ExpectCommit()
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_runs" SET "last_activity_time"=$1 WHERE id = $2`)).
+				WithArgs(sqlmock.AnyArg(), 1).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+			c.Request, _ = http.NewRequest("POST", "/api/testrun/1/suiterun", strings.NewReader(`{"suite_name":"TestSuite"}`))
+
+			handler := handlers.NewHandler(gormDb)
+			handler.AppendSuiteRun(c)
+
+			Expect(w.Code).To(Equal(http.StatusCreated))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+			var suiteRun models.SuiteRun
+			Expect(json.NewDecoder(w.Body).Decode(&suiteRun)).To(Succeed())
+			Expect(suiteRun.ID).To(Equal(uint64(5)))
+			Expect(suiteRun.TestRunID).To(Equal(uint64(1)))
+		})
+
+		It("and the test run is already finished, it should return 409 Conflict", func() {
+			mock.ExpectQuery(selectTestRun).
+				WithArgs("1", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(1, "passed"))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+			c.Request, _ = http.NewRequest("POST", "/api/testrun/1/suiterun", strings.NewReader(`{"suite_name":"TestSuite"}`))
+
+			handler := handlers.NewHandler(gormDb)
+			handler.AppendSuiteRun(c)
+
+			Expect(w.Code).To(Equal(http.StatusConflict))
+		})
+
+		It("and the test run does not exist, it should return 404 Not Found", func() {
+			mock.ExpectQuery(selectTestRun).
+				WithArgs("1", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+			c.Request, _ = http.NewRequest("POST", "/api/testrun/1/suiterun", strings.NewReader(`{}`))
+
+			handler := handlers.NewHandler(gormDb)
+			handler.AppendSuiteRun(c)
+
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Context("when AppendSpecRuns handler is invoked", func() {
+		It("should store the spec runs and extend the suite run", func() {
+			specEnd := time.Date(2024, 4, 20, 12, 0, 5, 0, time.UTC)
+
+			mock.ExpectQuery(selectTestRun).
+				WithArgs("1", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(1, "in_progress"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suite_runs" WHERE id = $1 AND test_run_id = $2 ORDER BY "suite_runs"."id" LIMIT $3`)).
+				WithArgs("5", 1, 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id"}).AddRow(5, 1))
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "test_cases"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "merged_into_id"}).AddRow(3, nil).AddRow(4, 2))
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "spec_runs"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10).AddRow(11))
+			mock.ExpectCommit()
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "suite_runs" SET "end_time"=$1 WHERE id = $2`)).
+				WithArgs(specEnd, 5).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_runs" SET "last_activity_time"=$1 WHERE id = $2`)).
+				WithArgs(sqlmock.AnyArg(), 1).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"}, gin.Param{Key: "suiteId", Value: "5"})
+			c.Request, _ = http.NewRequest("POST", "/api/testrun/1/suiterun/5/specrun", strings.NewReader(`[
+				{"spec_description":"first","status":"passed","end_time":"2024-04-20T12:00:01Z"},
+				{"spec_description":"second","status":"failed","end_time":"2024-04-20T12:00:05Z"}
+			]`))
+
+			handler := handlers.NewHandler(gormDb)
+			handler.AppendSpecRuns(c)
+
+			Expect(w.Code).To(Equal(http.StatusCreated))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+			var specRuns []models.SpecRun
+			Expect(json.NewDecoder(w.Body).Decode(&specRuns)).To(Succeed())
+			Expect(specRuns).To(HaveLen(2))
+			Expect(specRuns[1].SuiteID).To(Equal(uint64(5)))
+			// The test case of the second spec was merged into test case 2
+			Expect(*specRuns[0].TestCaseID).To(Equal(uint64(3)))
+			Expect(*specRuns[1].TestCaseID).To(Equal(uint64(2)))
+		})
+
+		It("and the suite run belongs to another test run, it should return 404 Not Found", func() {
+			mock.ExpectQuery(selectTestRun).
+				WithArgs("1", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(1, "in_progress"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suite_runs"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"}, gin.Param{Key: "suiteId", Value: "9"})
+			c.Request, _ = http.NewRequest("POST", "/api/testrun/1/suiterun/9/specrun", strings.NewReader(`{}`))
+
+			handler := handlers.NewHandler(gormDb)
+			handler.AppendSpecRuns(c)
+
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Context("when FinalizeTestRun handler is invoked", func() {
+		It("with an explicit outcome, it should close the run", func() {
+			endTime := time.Date(2024, 4, 20, 13, 0, 0, 0, time.UTC)
+
+			mock.ExpectQuery(selectTestRun).
+				WithArgs("1", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(1, "in_progress"))
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_runs" SET "end_time"=$1,"last_activity_time"=$2,"status"=$3 WHERE "test_runs"."deleted_at" IS NULL AND "id" = $4`)).
+				WithArgs(endTime, sqlmock.AnyArg(), "failed", 1).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+			c.Request, _ = http.NewRequest("POST", "/api/testrun/1/finalize", strings.NewReader(`{"status":"failed","end_time":"2024-04-20T13:00:00Z"}`))
+
+			handler := handlers.NewHandler(gormDb)
+			handler.FinalizeTestRun(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+			var testRun models.TestRun
+			Expect(json.NewDecoder(w.Body).Decode(&testRun)).To(Succeed())
+			Expect(testRun.Status).To(Equal("failed"))
+			Expect(testRun.EndTime).To(BeTemporally("==", endTime))
+		})
+
+		It("with an unknown outcome, it should return 400 Bad Request", func() {
+			mock.ExpectQuery(selectTestRun).
+				WithArgs("1", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(1, "in_progress"))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+			c.Request, _ = http.NewRequest("POST", "/api/testrun/1/finalize", strings.NewReader(`{"status":"done"}`))
+
+			handler := handlers.NewHandler(gormDb)
+			handler.FinalizeTestRun(c)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("and the test run belongs to another project than the token's, it should return 404 Not Found", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND test_project_name = $2 AND "test_runs"."deleted_at" IS NULL`)).
+				WithArgs("1", "Search", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+			c.Request, _ = http.NewRequest("POST", "/api/testrun/1/finalize", strings.NewReader(``))
+			c.Set("fernProjectName", "Search")
+
+			handler := handlers.NewHandler(gormDb)
+			handler.FinalizeTestRun(c)
+
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+
+	Context("when runs are rendered in the HTML report", func() {
+		It("should show in-progress runs distinctly and link finished runs to their source", func() {
+			_, err := config.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.SetFuncMap(template.FuncMap{
+				"CalculateDuration": utils.CalculateDuration,
+				"FormatDate":        utils.FormatDate,
+				"SpecTree":          utils.SpecTree,
+			})
+			router.LoadHTMLGlob("../../views/test_runs.html")
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name", "status", "git_branch", "git_sha", "build_url", "build_number"}).
+					AddRow(1, "Running", "in_progress", "", "", "", "").
+					AddRow(2, "Done", "passed", "main", "0a1b2c3d4e5f", "https://ci.example.com/builds/17", "17"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suite_runs" WHERE "suite_runs"."test_run_id" IN ($1,$2)`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id"}).AddRow(1, 2))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_runs" WHERE "spec_runs"."suite_id" = $1`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "suite_id", "status"}).AddRow(1, 1, "passed"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_run_tags"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"spec_run_id", "tag_id"}))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "attachments" WHERE test_run_id IN ($1,$2) ORDER BY id`)).
+				WithArgs(1, 2).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id", "spec_run_id", "file_name", "content_type", "size"}).
+					AddRow(5, 2, nil, "build.log", "text/plain; charset=utf-8", 2048))
+
+			handler := handlers.NewHandler(gormDb)
+			router.GET("/reports/testruns/", handler.ReportTestRunAllHTML)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/reports/testruns/", nil)
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			doc, err := goquery.NewDocumentFromReader(w.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(doc.Find("tr.run-placeholder").Length()).To(Equal(1))
+			Expect(doc.Find("tr.run-placeholder").Text()).To(ContainSubstring("Running"))
+			Expect(doc.Find("tr.test-row").Length()).To(Equal(1))
+			Expect(doc.Find("tr.test-row.run-in-progress").Length()).To(Equal(0))
+
+			source := doc.Find("tr.test-row td.test-run-source")
+			Expect(source.Find(".run-branch").Text()).To(Equal("main"))
+			Expect(source.Find(".run-commit").Text()).To(Equal("0a1b2c3"))
+			Expect(source.Find("a").AttrOr("href", "")).To(Equal("https://ci.example.com/builds/17"))
+
+			runAttachments := doc.Find("tr.run-attachments")
+			Expect(runAttachments.Length()).To(Equal(1))
+			Expect(runAttachments.Find("a.attachment-link").Text()).To(Equal("build.log"))
+			Expect(runAttachments.Find("a.attachment-link").AttrOr("href", "")).To(Equal("/api/attachments/5"))
+		})
+
+		It("should colour errored specs like failed ones", func() {
+			_, err := config.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.SetFuncMap(template.FuncMap{
+				"CalculateDuration": utils.CalculateDuration,
+				"FormatDate":        utils.FormatDate,
+				"SpecTree":          utils.SpecTree,
+			})
+			router.LoadHTMLGlob("../../views/test_runs.html")
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name", "status"}).AddRow(1, "Checkout", "failed"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suite_runs" WHERE "suite_runs"."test_run_id" = $1`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id"}).AddRow(1, 1))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_runs" WHERE "spec_runs"."suite_id" = $1`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "suite_id", "spec_description", "status"}).
+					AddRow(1, 1, "fails", "failed").
+					AddRow(2, 1, "errors", "errored").
+					AddRow(3, 1, "skips", "skipped"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_run_tags"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"spec_run_id", "tag_id"}))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "attachments" WHERE test_run_id IN ($1) ORDER BY id`)).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id", "spec_run_id", "file_name", "content_type", "size"}))
+
+			handler := handlers.NewHandler(gormDb)
+			router.GET("/reports/testruns/", handler.ReportTestRunAllHTML)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/reports/testruns/", nil)
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			doc, err := goquery.NewDocumentFromReader(w.Body)
+			Expect(err).NotTo(HaveOccurred())
+			rows := doc.Find("tr.test-row")
+			Expect(rows.Length()).To(Equal(3))
+			Expect(rows.Eq(0).AttrOr("style", "")).To(ContainSubstring("background-color: red"))
+			Expect(rows.Eq(1).AttrOr("data-status", "")).To(Equal("errored"))
+			Expect(rows.Eq(1).AttrOr("style", "")).To(ContainSubstring("background-color: red"))
+			Expect(rows.Eq(2).AttrOr("style", "")).To(ContainSubstring("background-color: yellow"))
+		})
+
+		It("should show the specs of each suite as a tree of their containers, attempts and failures", func() {
+			failure, err := models.Failure{Message: "expected an error", Kind: "assertion", File: "cart_test.go", Line: 42, StackTrace: "cart.TestQuantity()"}.Value()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = config.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.SetFuncMap(template.FuncMap{
+				"CalculateDuration": utils.CalculateDuration,
+				"FormatDate":        utils.FormatDate,
+				"SpecTree":          utils.SpecTree,
+			})
+			router.LoadHTMLGlob("../../views/test_runs.html")
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name", "status"}).AddRow(2, "Checkout", "failed"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suite_runs" WHERE "suite_runs"."test_run_id" = $1`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id", "suite_name"}).AddRow(4, 2, "Cart Suite"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_runs" WHERE "spec_runs"."suite_id" = $1`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "suite_id", "spec_description", "status", "containers", "failure", "attempts"}).
+					AddRow(1, 4, "has no items", "flaky", `[{"text":"Cart","labels":["cart"]},{"text":"when empty"}]`, nil,
+						`[{"status":"failed","message":"cart not loaded"},{"status":"passed"}]`).
+					AddRow(2, 4, "rejects a negative quantity", "failed", `[{"text":"Cart","labels":["cart"]}]`, failure, `[]`))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_run_tags"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"spec_run_id", "tag_id"}))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "attachments" WHERE test_run_id IN ($1) ORDER BY id`)).
+				WithArgs(2).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id", "spec_run_id", "file_name", "content_type", "size"}).
+					AddRow(7, 2, 2, "cart.png", "image/png", 4096))
+
+			handler := handlers.NewHandler(gormDb)
+			router.GET("/reports/testruns/", handler.ReportTestRunAllHTML)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/reports/testruns/", nil)
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			doc, err := goquery.NewDocumentFromReader(w.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(doc.Find("tr.suite-row").AttrOr("data-tree-path", "")).To(Equal("s4"))
+
+			containers := doc.Find("tr.container-row")
+			Expect(containers.Length()).To(Equal(2))
+			Expect(containers.Eq(0).AttrOr("data-tree-path", "")).To(Equal("s4.0"))
+			Expect(containers.Eq(0).Text()).To(ContainSubstring("1 failed"))
+			Expect(containers.Eq(1).AttrOr("data-tree-path", "")).To(Equal("s4.0.0"))
+
+			specs := doc.Find("tr.test-row")
+			Expect(specs.Eq(0).AttrOr("data-tree-path", "")).To(Equal("s4.0.0.0"))
+			Expect(specs.Eq(0).Find("td.test-name").Text()).To(Equal("has no items"))
+			Expect(specs.Eq(1).AttrOr("data-tree-path", "")).To(Equal("s4.0.1"))
+
+			panel := doc.Find("tr.details .failure-panel")
+			Expect(panel.Length()).To(Equal(1))
+			Expect(panel.Find(".failure-kind").Text()).To(Equal("assertion"))
+			Expect(panel.Find(".failure-location").Text()).To(Equal("cart_test.go:42"))
+			Expect(panel.Find(".failure-stack-trace").Text()).To(Equal("cart.TestQuantity()"))
+			Expect(panel.Find(".failure-stdout").Length()).To(Equal(0))
+
+			attempts := doc.Find("tr.details .spec-attempt")
+			Expect(attempts.Length()).To(Equal(2))
+			Expect(attempts.Eq(0).Text()).To(ContainSubstring("cart not loaded"))
+			Expect(doc.Find(".status-flaky").Text()).To(Equal("Flaky: 1"))
+
+			thumbnails := doc.Find("tr.details .attachment-thumbnail")
+			Expect(thumbnails.Length()).To(Equal(1))
+			Expect(thumbnails.AttrOr("src", "")).To(Equal("/api/attachments/7"))
+			Expect(thumbnails.AttrOr("alt", "")).To(Equal("cart.png"))
+			Expect(doc.Find("tr.details").Eq(0).Find(".attachments").Length()).To(Equal(0))
+		})
+	})
+})
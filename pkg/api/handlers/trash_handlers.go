@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+// userHeader names who deletes a test run or quarantines a spec when auth is
+// disabled and requests carry no token subject.
+const userHeader = "X-Fern-User"
+
+// GetTrashedTestRuns lists the deleted test runs of the token's project that
+// can still be restored, most recently deleted first.
+func (h *Handler) GetTrashedTestRuns(c *gin.Context) {
+	testRuns := []models.TestRun{}
+	if err := h.db.Unscoped().Scopes(inScopeTestRuns(c)).Where("deleted_at IS NOT NULL").Order("deleted_at DESC").Find(&testRuns).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error loading deleted test runs"})
+		return
+	}
+	c.JSON(http.StatusOK, testRuns)
+}
+
+// RestoreTestRun takes a deleted test run out of the trash.
+func (h *Handler) RestoreTestRun(c *gin.Context) {
+	id := c.Param("id")
+	result := h.db.Unscoped().Model(&models.TestRun{}).Scopes(inScopeTestRuns(c)).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]interface{}{"deleted_at": nil, "deleted_by": ""})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error restoring test run"})
+		return
+	} else if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "deleted test run not found"})
+		return
+	}
+
+	var testRun models.TestRun
+	if err := h.db.Scopes(inScopeTestRuns(c)).Where("id = ?", id).First(&testRun).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error loading test run"})
+		return
+	}
+	c.JSON(http.StatusOK, &testRun)
+}
+
+// requestUser identifies who performs a request: the subject of the token, or
+// the X-Fern-User header when auth is disabled.
+func requestUser(c *gin.Context) string {
+	if subject := c.GetString("subject"); subject != "" {
+		return subject
+	}
+	if c.Request != nil {
+		return c.GetHeader(userHeader)
+	}
+	return ""
+}
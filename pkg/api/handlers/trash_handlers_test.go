@@ -0,0 +1,161 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/pkg/api/handlers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Trash handlers", func() {
+	Context("when DeleteTestRun handler is invoked", func() {
+		It("should move the run to the trash and record who deleted it", func() {
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_runs" SET "deleted_at"=$1,"deleted_by"=$2 WHERE "test_runs"."deleted_at" IS NULL AND "id" = $3`)).
+				WithArgs(sqlmock.AnyArg(), "jane", 4).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "4"}}
+			c.Request, _ = http.NewRequest("DELETE", "/api/testrun/4", nil)
+			c.Request.Header.Set("X-Fern-User", "jane")
+
+			handlers.NewHandler(gormDb).DeleteTestRun(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			var testRun models.TestRun
+			Expect(json.Unmarshal(w.Body.Bytes(), &testRun)).To(Succeed())
+			Expect(testRun.DeletedAt.Valid).To(BeTrue())
+			Expect(testRun.DeletedBy).To(Equal("jane"))
+		})
+
+		It("should prefer the subject of the token", func() {
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_runs" SET "deleted_at"=$1,"deleted_by"=$2`)).
+				WithArgs(sqlmock.AnyArg(), "ci-bot", 4).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "4"}}
+			c.Request, _ = http.NewRequest("DELETE", "/api/testrun/4", nil)
+			c.Request.Header.Set("X-Fern-User", "jane")
+			c.Set("subject", "ci-bot")
+
+			handlers.NewHandler(gormDb).DeleteTestRun(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+		It("should not find the runs of projects the token may not write to", func() {
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_runs" SET "deleted_at"=$1,"deleted_by"=$2 WHERE test_project_name = $3 AND "test_runs"."deleted_at" IS NULL AND "id" = $4`)).
+				WithArgs(sqlmock.AnyArg(), "ci-bot", "Search", 4).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectCommit()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "4"}}
+			c.Request, _ = http.NewRequest("DELETE", "/api/testrun/4", nil)
+			c.Set("subject", "ci-bot")
+			c.Set("fernProjectName", "Search")
+
+			handlers.NewHandler(gormDb).DeleteTestRun(c)
+
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+
+	Context("when GetTrashedTestRuns handler is invoked", func() {
+		It("should list the deleted runs, most recently deleted first", func() {
+			deletedAt := time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name", "deleted_at", "deleted_by"}).
+					AddRow(4, "Checkout", deletedAt, "jane"))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("GET", "/api/testrun/trash", nil)
+
+			handlers.NewHandler(gormDb).GetTrashedTestRuns(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			var testRuns []models.TestRun
+			Expect(json.Unmarshal(w.Body.Bytes(), &testRuns)).To(Succeed())
+			Expect(testRuns).To(HaveLen(1))
+			Expect(testRuns[0].DeletedAt.Time).To(Equal(deletedAt))
+			Expect(testRuns[0].DeletedBy).To(Equal("jane"))
+		})
+
+		It("should only list the deleted runs of the token's project", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE deleted_at IS NOT NULL AND test_project_name = $1 ORDER BY deleted_at DESC`)).
+				WithArgs("Checkout").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name"}))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("GET", "/api/testrun/trash", nil)
+			c.Set("fernProjectName", "Checkout")
+
+			handlers.NewHandler(gormDb).GetTrashedTestRuns(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+
+	Context("when RestoreTestRun handler is invoked", func() {
+		It("should take the run out of the trash", func() {
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_runs" SET "deleted_at"=$1,"deleted_by"=$2 WHERE id = $3 AND deleted_at IS NOT NULL`)).
+				WithArgs(nil, "", "4").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND "test_runs"."deleted_at" IS NULL ORDER BY "test_runs"."id" LIMIT $2`)).
+				WithArgs("4", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name"}).AddRow(4, "Checkout"))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "4"}}
+			c.Request, _ = http.NewRequest("POST", "/api/testrun/4/restore", nil)
+
+			handlers.NewHandler(gormDb).RestoreTestRun(c)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+
+		It("should return 404 when the run is not in the trash", func() {
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_runs" SET "deleted_at"=$1,"deleted_by"=$2 WHERE id = $3 AND deleted_at IS NOT NULL`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectCommit()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "4"}}
+			c.Request, _ = http.NewRequest("POST", "/api/testrun/4/restore", nil)
+
+			handlers.NewHandler(gormDb).RestoreTestRun(c)
+
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"github.com/guidewire/fern-reporter/config"
 	"github.com/guidewire/fern-reporter/pkg/api/handlers"
 	"github.com/guidewire/fern-reporter/pkg/auth"
+	"github.com/guidewire/fern-reporter/pkg/blobstore"
 	"github.com/guidewire/fern-reporter/pkg/db"
 
 	"github.com/gin-gonic/gin"
@@ -14,8 +15,12 @@ var (
 )
 
 func RegisterRouters(router *gin.Engine) {
-	handler := handlers.NewHandler(db.GetDb())
+	RegisterHandlerRoutes(router, handlers.NewHandler(db.GetDb()).WithBlobStore(blobstore.GetStore()))
+}
 
+// RegisterHandlerRoutes registers the routes served by the handler, behind
+// the scope middleware when auth is enabled.
+func RegisterHandlerRoutes(router *gin.Engine, handler *handlers.Handler) {
 	authEnabled := config.GetAuth().Enabled
 
 	var api *gin.RouterGroup
@@ -31,14 +36,58 @@ func RegisterRouters(router *gin.Engine) {
 		testRun.GET("/", handler.GetTestRunAll)
 		testRun.GET("/:id", handler.GetTestRunByID)
 		testRun.POST("/", handler.CreateTestRun)
+		testRun.POST("/junit", handler.CreateTestRunFromJUnit)
+		testRun.POST("/gotest", handler.CreateTestRunFromGoTest)
+		testRun.POST("/ginkgo", handler.CreateTestRunFromGinkgo)
+		testRun.POST("/cucumber", handler.CreateTestRunFromCucumber)
+		testRun.POST("/ctrf", handler.CreateTestRunFromCTRF)
+		testRun.POST("/bulk", handler.CreateTestRunsBulk)
+		testRun.GET("/:id/ctrf", handler.GetTestRunCTRF)
+		testRun.POST("/open", handler.OpenTestRun)
+		testRun.POST("/:id/suiterun", handler.AppendSuiteRun)
+		testRun.POST("/:id/suiterun/:suiteId/specrun", handler.AppendSpecRuns)
+		testRun.POST("/:id/finalize", handler.FinalizeTestRun)
 		testRun.PUT("/:id", handler.UpdateTestRun)
 		testRun.DELETE("/:id", handler.DeleteTestRun)
+		testRun.GET("/trash", handler.GetTrashedTestRuns)
+		testRun.POST("/:id/restore", handler.RestoreTestRun)
+		testRun.POST("/:id/attachments", handler.UploadAttachment)
+		testRun.GET("/:id/attachments", handler.GetTestRunAttachments)
+
+		attachments := api.Group("/attachments")
+		attachments.GET("/:id", handler.DownloadAttachment)
+		attachments.DELETE("/:id", handler.DeleteAttachment)
+
+		projects := api.Group("/projects")
+		projects.GET("/", handler.GetProjects)
+		projects.POST("/", handler.CreateProject)
+		projects.GET("/:name", handler.GetProject)
+		projects.PUT("/:name", handler.UpdateProject)
+		projects.DELETE("/:name", handler.DeleteProject)
+		projects.GET("/:name/ownership", handler.GetOwnershipRules)
+		projects.PUT("/:name/ownership", handler.UpdateOwnershipRules)
+		projects.GET("/:name/testcases", handler.GetTestCases)
+		projects.GET("/:name/testcases/:id/history", handler.GetTestCaseHistory)
+		projects.GET("/:name/quarantine", handler.GetQuarantineRules)
+		projects.POST("/:name/quarantine", handler.CreateQuarantineRule)
+		projects.PUT("/:name/quarantine/:id", handler.UpdateQuarantineRule)
+		projects.DELETE("/:name/quarantine/:id", handler.LiftQuarantineRule)
+
+		admin := api.Group("/admin")
+		admin.POST("/testcases/merge", handler.MergeTestCases)
+		admin.GET("/retention/dry-run", handler.GetRetentionDryRun)
 
 		testReport := api.Group("/reports")
 		testReport.GET("/projects/", handler.GetProjectAll)
 		testReport.GET("/summary/:name/", handler.GetTestSummary)
 		testReport.GET("/testruns/", handler.ReportTestRunAll)
 		testReport.GET("/testruns/:id/", handler.ReportTestRunById)
+		testReport.GET("/testruns/:id/failures", handler.ReportTestRunFailureGroups)
+		testReport.GET("/insights/:name/labels/:key", handler.ReportLabelPassRates)
+		testReport.GET("/insights/:name/owners", handler.ReportOwnerFailures)
+		testReport.GET("/flaky/:project", handler.ReportFlakySpecs)
+		testReport.GET("/failures/:project", handler.ReportFailureGroups)
+		testReport.GET("/compare", handler.CompareTestRuns)
 	}
 
 	var reports *gin.RouterGroup
@@ -54,6 +103,18 @@ func RegisterRouters(router *gin.Engine) {
 		reports.GET("/:id", handler.ReportTestRunByIdHTML)
 	}
 
+	var compare *gin.RouterGroup
+	if authEnabled {
+		compare = router.Group("/reports/compare", auth.ScopeMiddleware())
+	} else {
+		compare = router.Group("/reports/compare")
+	}
+
+	compare.Use()
+	{
+		compare.GET("/", handler.CompareTestRunsHTML)
+	}
+
 	var ping *gin.RouterGroup
 	if authEnabled {
 		ping = router.Group("/ping", auth.ScopeMiddleware())
@@ -68,5 +129,8 @@ func RegisterRouters(router *gin.Engine) {
 	insights := router.Group("/insights")
 	{
 		insights.GET("/:name", handler.ReportTestInsights)
+		insights.GET("/:name/flaky", handler.ReportFlakySpecsHTML)
+		insights.GET("/:name/quarantine", handler.ReportQuarantineHTML)
+		insights.GET("/:name/failures", handler.ReportFailureGroupsHTML)
 	}
 }
@@ -12,8 +12,11 @@ import (
 	. "github.com/onsi/gomega"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"reflect"
+	"regexp"
 	"runtime"
 )
 
@@ -22,11 +25,12 @@ var _ = Describe("RegisterRouters", func() {
 		router *gin.Engine
 		gormDb *gorm.DB
 		db     *sql.DB
+		mock   sqlmock.Sqlmock
 	)
 
 	BeforeEach(func() {
 		router = gin.Default()
-		db, _, _ = sqlmock.New()
+		db, mock, _ = sqlmock.New()
 
 		dialector := postgres.New(postgres.Config{
 			DSN:                  "sqlmock_db_0",
@@ -53,8 +57,40 @@ var _ = Describe("RegisterRouters", func() {
 			ExpectRoute(router, "GET", "/api/testrun/", handler.GetTestRunAll)
 			ExpectRoute(router, "GET", "/api/testrun/:id", handler.GetTestRunByID)
 			ExpectRoute(router, "POST", "/api/testrun/", handler.CreateTestRun)
+			ExpectRoute(router, "POST", "/api/testrun/junit", handler.CreateTestRunFromJUnit)
+			ExpectRoute(router, "POST", "/api/testrun/gotest", handler.CreateTestRunFromGoTest)
+			ExpectRoute(router, "POST", "/api/testrun/ginkgo", handler.CreateTestRunFromGinkgo)
+			ExpectRoute(router, "POST", "/api/testrun/cucumber", handler.CreateTestRunFromCucumber)
+			ExpectRoute(router, "POST", "/api/testrun/ctrf", handler.CreateTestRunFromCTRF)
+			ExpectRoute(router, "POST", "/api/testrun/bulk", handler.CreateTestRunsBulk)
+			ExpectRoute(router, "GET", "/api/testrun/:id/ctrf", handler.GetTestRunCTRF)
+			ExpectRoute(router, "POST", "/api/testrun/open", handler.OpenTestRun)
+			ExpectRoute(router, "POST", "/api/testrun/:id/suiterun", handler.AppendSuiteRun)
+			ExpectRoute(router, "POST", "/api/testrun/:id/suiterun/:suiteId/specrun", handler.AppendSpecRuns)
+			ExpectRoute(router, "POST", "/api/testrun/:id/finalize", handler.FinalizeTestRun)
 			ExpectRoute(router, "PUT", "/api/testrun/:id", handler.UpdateTestRun)
 			ExpectRoute(router, "DELETE", "/api/testrun/:id", handler.DeleteTestRun)
+			ExpectRoute(router, "GET", "/api/testrun/trash", handler.GetTrashedTestRuns)
+			ExpectRoute(router, "POST", "/api/testrun/:id/restore", handler.RestoreTestRun)
+			ExpectRoute(router, "POST", "/api/testrun/:id/attachments", handler.UploadAttachment)
+			ExpectRoute(router, "GET", "/api/testrun/:id/attachments", handler.GetTestRunAttachments)
+			ExpectRoute(router, "GET", "/api/attachments/:id", handler.DownloadAttachment)
+			ExpectRoute(router, "DELETE", "/api/attachments/:id", handler.DeleteAttachment)
+			ExpectRoute(router, "GET", "/api/projects/", handler.GetProjects)
+			ExpectRoute(router, "POST", "/api/projects/", handler.CreateProject)
+			ExpectRoute(router, "GET", "/api/projects/:name", handler.GetProject)
+			ExpectRoute(router, "PUT", "/api/projects/:name", handler.UpdateProject)
+			ExpectRoute(router, "DELETE", "/api/projects/:name", handler.DeleteProject)
+			ExpectRoute(router, "GET", "/api/projects/:name/ownership", handler.GetOwnershipRules)
+			ExpectRoute(router, "PUT", "/api/projects/:name/ownership", handler.UpdateOwnershipRules)
+			ExpectRoute(router, "GET", "/api/projects/:name/testcases", handler.GetTestCases)
+			ExpectRoute(router, "GET", "/api/projects/:name/testcases/:id/history", handler.GetTestCaseHistory)
+			ExpectRoute(router, "GET", "/api/projects/:name/quarantine", handler.GetQuarantineRules)
+			ExpectRoute(router, "POST", "/api/projects/:name/quarantine", handler.CreateQuarantineRule)
+			ExpectRoute(router, "PUT", "/api/projects/:name/quarantine/:id", handler.UpdateQuarantineRule)
+			ExpectRoute(router, "DELETE", "/api/projects/:name/quarantine/:id", handler.LiftQuarantineRule)
+			ExpectRoute(router, "POST", "/api/admin/testcases/merge", handler.MergeTestCases)
+			ExpectRoute(router, "GET", "/api/admin/retention/dry-run", handler.GetRetentionDryRun)
 		})
 
 		It("should register report routes", func() {
@@ -67,6 +103,16 @@ var _ = Describe("RegisterRouters", func() {
 			// Check if report routes are registered correctly
 			ExpectRoute(router, "GET", "/reports/testruns/", handler.ReportTestRunAllHTML)
 			ExpectRoute(router, "GET", "/reports/testruns/:id", handler.ReportTestRunByIdHTML)
+			ExpectRoute(router, "GET", "/api/reports/insights/:name/labels/:key", handler.ReportLabelPassRates)
+			ExpectRoute(router, "GET", "/api/reports/insights/:name/owners", handler.ReportOwnerFailures)
+			ExpectRoute(router, "GET", "/api/reports/flaky/:project", handler.ReportFlakySpecs)
+			ExpectRoute(router, "GET", "/insights/:name/flaky", handler.ReportFlakySpecsHTML)
+			ExpectRoute(router, "GET", "/insights/:name/quarantine", handler.ReportQuarantineHTML)
+			ExpectRoute(router, "GET", "/api/reports/testruns/:id/failures", handler.ReportTestRunFailureGroups)
+			ExpectRoute(router, "GET", "/api/reports/failures/:project", handler.ReportFailureGroups)
+			ExpectRoute(router, "GET", "/insights/:name/failures", handler.ReportFailureGroupsHTML)
+			ExpectRoute(router, "GET", "/api/reports/compare", handler.CompareTestRuns)
+			ExpectRoute(router, "GET", "/reports/compare/", handler.CompareTestRunsHTML)
 		})
 	})
 
@@ -86,8 +132,36 @@ var _ = Describe("RegisterRouters", func() {
 			ExpectRoute(router, "GET", "/api/testrun/", handler.GetTestRunAll)
 			ExpectRoute(router, "GET", "/api/testrun/:id", handler.GetTestRunByID)
 			ExpectRoute(router, "POST", "/api/testrun/", handler.CreateTestRun)
+			ExpectRoute(router, "POST", "/api/testrun/junit", handler.CreateTestRunFromJUnit)
+			ExpectRoute(router, "POST", "/api/testrun/gotest", handler.CreateTestRunFromGoTest)
+			ExpectRoute(router, "POST", "/api/testrun/ginkgo", handler.CreateTestRunFromGinkgo)
+			ExpectRoute(router, "POST", "/api/testrun/cucumber", handler.CreateTestRunFromCucumber)
+			ExpectRoute(router, "POST", "/api/testrun/ctrf", handler.CreateTestRunFromCTRF)
+			ExpectRoute(router, "POST", "/api/testrun/bulk", handler.CreateTestRunsBulk)
+			ExpectRoute(router, "GET", "/api/testrun/:id/ctrf", handler.GetTestRunCTRF)
 			ExpectRoute(router, "PUT", "/api/testrun/:id", handler.UpdateTestRun)
 			ExpectRoute(router, "DELETE", "/api/testrun/:id", handler.DeleteTestRun)
+			ExpectRoute(router, "GET", "/api/testrun/trash", handler.GetTrashedTestRuns)
+			ExpectRoute(router, "POST", "/api/testrun/:id/restore", handler.RestoreTestRun)
+			ExpectRoute(router, "POST", "/api/testrun/:id/attachments", handler.UploadAttachment)
+			ExpectRoute(router, "GET", "/api/testrun/:id/attachments", handler.GetTestRunAttachments)
+			ExpectRoute(router, "GET", "/api/attachments/:id", handler.DownloadAttachment)
+			ExpectRoute(router, "DELETE", "/api/attachments/:id", handler.DeleteAttachment)
+			ExpectRoute(router, "GET", "/api/projects/", handler.GetProjects)
+			ExpectRoute(router, "POST", "/api/projects/", handler.CreateProject)
+			ExpectRoute(router, "GET", "/api/projects/:name", handler.GetProject)
+			ExpectRoute(router, "PUT", "/api/projects/:name", handler.UpdateProject)
+			ExpectRoute(router, "DELETE", "/api/projects/:name", handler.DeleteProject)
+			ExpectRoute(router, "GET", "/api/projects/:name/ownership", handler.GetOwnershipRules)
+			ExpectRoute(router, "PUT", "/api/projects/:name/ownership", handler.UpdateOwnershipRules)
+			ExpectRoute(router, "GET", "/api/projects/:name/testcases", handler.GetTestCases)
+			ExpectRoute(router, "GET", "/api/projects/:name/testcases/:id/history", handler.GetTestCaseHistory)
+			ExpectRoute(router, "GET", "/api/projects/:name/quarantine", handler.GetQuarantineRules)
+			ExpectRoute(router, "POST", "/api/projects/:name/quarantine", handler.CreateQuarantineRule)
+			ExpectRoute(router, "PUT", "/api/projects/:name/quarantine/:id", handler.UpdateQuarantineRule)
+			ExpectRoute(router, "DELETE", "/api/projects/:name/quarantine/:id", handler.LiftQuarantineRule)
+			ExpectRoute(router, "POST", "/api/admin/testcases/merge", handler.MergeTestCases)
+			ExpectRoute(router, "GET", "/api/admin/retention/dry-run", handler.GetRetentionDryRun)
 		})
 
 		It("should register report routes", func() {
@@ -104,6 +178,68 @@ var _ = Describe("RegisterRouters", func() {
 			// Check if report routes are registered correctly
 			ExpectRoute(router, "GET", "/reports/testruns/", handler.ReportTestRunAllHTML)
 			ExpectRoute(router, "GET", "/reports/testruns/:id", handler.ReportTestRunByIdHTML)
+			ExpectRoute(router, "GET", "/api/reports/insights/:name/labels/:key", handler.ReportLabelPassRates)
+			ExpectRoute(router, "GET", "/api/reports/insights/:name/owners", handler.ReportOwnerFailures)
+			ExpectRoute(router, "GET", "/api/reports/flaky/:project", handler.ReportFlakySpecs)
+			ExpectRoute(router, "GET", "/insights/:name/flaky", handler.ReportFlakySpecsHTML)
+			ExpectRoute(router, "GET", "/insights/:name/quarantine", handler.ReportQuarantineHTML)
+			ExpectRoute(router, "GET", "/api/reports/testruns/:id/failures", handler.ReportTestRunFailureGroups)
+			ExpectRoute(router, "GET", "/api/reports/failures/:project", handler.ReportFailureGroups)
+			ExpectRoute(router, "GET", "/insights/:name/failures", handler.ReportFailureGroupsHTML)
+			ExpectRoute(router, "GET", "/api/reports/compare", handler.CompareTestRuns)
+			ExpectRoute(router, "GET", "/reports/compare/", handler.CompareTestRunsHTML)
+		})
+	})
+
+	Context("Serving requests with auth", func() {
+		BeforeEach(func() {
+			os.Setenv("AUTH_ENABLED", "true")
+			_, err := config.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(func() {
+				os.Unsetenv("AUTH_ENABLED")
+				_, err := config.LoadConfig()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			router.Use(func(c *gin.Context) {
+				c.Set("scope", []interface{}{"fern.write", "fernproject.Checkout"})
+				c.Set("subject", "ci-bot")
+			})
+			routers.RegisterHandlerRoutes(router, handlers.NewHandler(gormDb))
+		})
+
+		It("should let a token that may write to the project delete a test run", func() {
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_runs" SET "deleted_at"=$1,"deleted_by"=$2 WHERE test_project_name = $3 AND "test_runs"."deleted_at" IS NULL AND "id" = $4`)).
+				WithArgs(sqlmock.AnyArg(), "ci-bot", "Checkout", 4).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("DELETE", "/api/testrun/4", nil)
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+
+		It("should let a token that may write to the project restore a test run without a body", func() {
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_runs" SET "deleted_at"=$1,"deleted_by"=$2 WHERE (id = $3 AND deleted_at IS NOT NULL) AND test_project_name = $4`)).
+				WithArgs(nil, "", "4", "Checkout").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND test_project_name = $2 AND "test_runs"."deleted_at" IS NULL`)).
+				WithArgs("4", "Checkout", 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name"}).AddRow(4, "Checkout"))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/testrun/4/restore", http.NoBody)
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
 		})
 	})
 })
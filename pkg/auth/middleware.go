@@ -3,8 +3,11 @@ package auth
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/guidewire/fern-reporter/config"
 	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/lestrrat-go/jwx/v2/jwt"
@@ -111,6 +114,9 @@ func JWTMiddleware(jwksUrl string, fetcher JWKSFetcher, validator JWTValidator)
 		}
 
 		c.Set("scope", scope)
+		if subject := token.Subject(); subject != "" {
+			c.Set("subject", subject)
+		}
 		c.Next()
 	}
 }
@@ -120,9 +126,14 @@ type RequestBody struct {
 }
 
 // ScopeMiddleware Middleware for checking if the user has the necessary scope for the request.
+// The project a request writes to is checked here when the request names it;
+// the handlers of requests that do not, such as bulk uploads, deletions or
+// restores of a test run, check the project of each run against the
+// fernProjectName set in the context.
 func ScopeMiddleware() gin.HandlerFunc {
 	permissions := map[string]string{
-		"POST": "fern.write",
+		"POST":   "fern.write",
+		"DELETE": "fern.write",
 	}
 
 	return func(c *gin.Context) {
@@ -138,28 +149,24 @@ func ScopeMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		scopes := convertToStringSlice(scope.([]interface{}))
+		claims, _ := scope.([]interface{})
+		scopes := convertToStringSlice(claims)
 
 		if !slices.Contains(scopes, requiredPermission) || !containsSubstring(scopes, FP) {
 			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient scope"})
 			return
 		}
 
-		bodyBytes, err := readRequestBody(c)
+		projectName, named, err := requestProject(c)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Unable to read request body"})
-			return
-		}
-
-		var requestBody RequestBody
-		if err := c.BindJSON(&requestBody); err != nil {
 			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
 			return
 		}
 
-		c.Request.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
-
-		fernProjectName, err := validateProjectName(scopes, requestBody.Project)
+		fernProjectName, err := scopeProjectName(scopes)
+		if err == nil && named && projectName != fernProjectName {
+			err = fmt.Errorf("project name does not match fern project scope claim")
+		}
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
 			return
@@ -170,30 +177,48 @@ func ScopeMiddleware() gin.HandlerFunc {
 	}
 }
 
-// readRequestBody reads and returns the request body bytes.
-func readRequestBody(c *gin.Context) ([]byte, error) {
-	bodyBytes, err := io.ReadAll(c.Request.Body)
-	if err != nil {
-		return nil, err
+// requestProject returns the project a request names, if any: the ?project=
+// query parameter of the report imports, or the project field of a JSON body.
+// Only the first JSON value of the body is read, and it is put back in front
+// of the rest, so that streamed bodies are not buffered in memory. Empty and
+// other bodies, such as XML reports, NDJSON or multipart uploads, name no
+// project.
+func requestProject(c *gin.Context) (string, bool, error) {
+	if projectName := c.Query("project"); projectName != "" {
+		return projectName, true, nil
+	}
+	if c.ContentType() != binding.MIMEJSON || c.Request.Body == nil || c.Request.Body == http.NoBody {
+		return "", false, nil
 	}
-	c.Request.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
-	return bodyBytes, nil
+
+	var consumed bytes.Buffer
+	var requestBody RequestBody
+	body := c.Request.Body
+	err := json.NewDecoder(io.TeeReader(body, &consumed)).Decode(&requestBody)
+	c.Request.Body = readCloser{Reader: io.MultiReader(&consumed, body), Closer: body}
+	if errors.Is(err, io.EOF) {
+		return "", false, nil
+	}
+	if err == nil && requestBody.Project == "" {
+		err = fmt.Errorf("project is required")
+	}
+	return requestBody.Project, true, err
 }
 
-// validateProjectName checks if the project name from the request body matches the scope claim and returns the fernProjectName.
-func validateProjectName(scopes []string, projectName string) (string, error) {
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// scopeProjectName returns the fern project of the scope claims.
+func scopeProjectName(scopes []string) (string, error) {
 	for _, v := range scopes {
 		if strings.HasPrefix(v, FP+".") {
 			parts := strings.SplitN(v, ".", 2)
 			if len(parts) != 2 || len(parts[1]) == 0 {
 				return "", fmt.Errorf("fern project scope claim is not formatted properly")
 			}
-
-			fernProjectName := parts[1]
-			if projectName != fernProjectName {
-				return "", fmt.Errorf("project name does not match fern project scope claim")
-			}
-			return fernProjectName, nil
+			return parts[1], nil
 		}
 	}
 	return "", fmt.Errorf("fern project scope claim not found")
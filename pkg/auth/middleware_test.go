@@ -5,6 +5,7 @@ import (
 	"github.com/guidewire/fern-reporter/config"
 	"github.com/guidewire/fern-reporter/pkg/auth"
 	"github.com/guidewire/fern-reporter/pkg/auth/mocks"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -123,14 +124,15 @@ var _ = Describe("ScopeMiddleware", func() {
 			c.Set("scope", "fern.write")
 		})
 		router.Use(auth.ScopeMiddleware())
-		router.DELETE("/", func(c *gin.Context) {
+		router.PATCH("/", func(c *gin.Context) {
 			c.JSON(http.StatusOK, gin.H{"message": "success"})
 		})
 
-		req, _ := http.NewRequest("DELETE", "/", nil)
+		req, _ := http.NewRequest("PATCH", "/", nil)
 		router.ServeHTTP(recorder, req)
 
 		Expect(recorder.Code).To(Equal(http.StatusForbidden))
+		Expect(recorder.Body.String()).To(ContainSubstring("invalid method"))
 	})
 
 	It("should abort with 403 if scope does not include required permission", func() {
@@ -148,4 +150,68 @@ var _ = Describe("ScopeMiddleware", func() {
 
 		Expect(recorder.Code).To(Equal(http.StatusForbidden))
 	})
+
+	Context("when the token may write to a project", func() {
+		var received string
+
+		BeforeEach(func() {
+			received = ""
+			router.Use(func(c *gin.Context) {
+				c.Set("scope", []interface{}{"fern.write", "fernproject.Checkout"})
+			})
+			router.Use(auth.ScopeMiddleware())
+			router.POST("/", func(c *gin.Context) {
+				body, _ := io.ReadAll(c.Request.Body)
+				received = string(body)
+				c.JSON(http.StatusOK, gin.H{"project": c.GetString("fernProjectName")})
+			})
+		})
+
+		It("should check the project of a JSON body and pass the body on", func() {
+			body := `{"project": "Checkout", "test_seed": 1}`
+			req, _ := http.NewRequest("POST", "/", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(received).To(Equal(body))
+		})
+
+		It("should abort with 403 if the project query parameter names another project", func() {
+			req, _ := http.NewRequest("POST", "/?project=Search", strings.NewReader("<testsuites/>"))
+			req.Header.Set("Content-Type", "application/xml")
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusForbidden))
+			Expect(received).To(BeEmpty())
+		})
+
+		It("should let requests without a body through", func() {
+			router.DELETE("/:id", func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"project": c.GetString("fernProjectName")})
+			})
+
+			req, _ := http.NewRequest("DELETE", "/2", nil)
+			router.ServeHTTP(recorder, req)
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Body.String()).To(ContainSubstring(`"project":"Checkout"`))
+
+			recorder = httptest.NewRecorder()
+			req, _ = http.NewRequest("POST", "/", strings.NewReader(""))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(recorder, req)
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should pass bodies that name no project on to the handler", func() {
+			body := "{\"test_project_name\": \"Checkout\"}\n{\"test_project_name\": \"Search\"}\n"
+			req, _ := http.NewRequest("POST", "/", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/x-ndjson")
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(received).To(Equal(body))
+			Expect(recorder.Body.String()).To(ContainSubstring(`"project":"Checkout"`))
+		})
+	})
 })
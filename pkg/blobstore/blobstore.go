@@ -0,0 +1,88 @@
+// Package blobstore keeps the contents of attachments outside the database.
+// A Store is either a local directory or an S3-compatible bucket, chosen by
+// the attachments configuration.
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/guidewire/fern-reporter/config"
+)
+
+const (
+	// KindLocal stores blobs in a directory of the local filesystem.
+	KindLocal = "local"
+	// KindS3 stores blobs in an S3-compatible bucket.
+	KindS3 = "s3"
+)
+
+// ErrNotFound is returned when a blob does not exist.
+var ErrNotFound = errors.New("blob not found")
+
+// Store keeps blobs by key. Keys are slash separated paths.
+type Store interface {
+	// Put stores size bytes read from r under key, replacing any previous
+	// blob.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens the blob stored under key.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob stored under key. Deleting a missing blob is
+	// not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+var store Store
+
+// Initialize creates the configured store. It must be called after the
+// configuration was loaded. When the store cannot be created the error is
+// logged and attachments are unavailable, rather than keeping the server from
+// starting.
+func Initialize() {
+	cfg := config.GetAttachments()
+	var created Store
+	var err error
+	switch cfg.Store {
+	case KindLocal, "":
+		created, err = NewLocalStore(cfg.LocalPath)
+	case KindS3:
+		created, err = NewS3Store(S3Options{
+			Endpoint:        cfg.S3.Endpoint,
+			Bucket:          cfg.S3.Bucket,
+			Region:          cfg.S3.Region,
+			AccessKeyID:     cfg.S3.AccessKeyID,
+			SecretAccessKey: cfg.S3.SecretAccessKey,
+			UsePathStyle:    cfg.S3.UsePathStyle,
+		})
+	default:
+		err = fmt.Errorf("unknown store %q, expected %s or %s", cfg.Store, KindLocal, KindS3)
+	}
+	if err != nil {
+		log.Printf("error creating attachment store, attachments are disabled: %v", err)
+		store = nil
+		return
+	}
+	store = created
+}
+
+// GetStore returns the store created by Initialize.
+func GetStore() Store {
+	return store
+}
+
+// DeleteAll removes the blobs whose attachment rows are gone. Failures are
+// only logged: the rows cannot be restored, so a failed delete leaves an
+// orphaned blob rather than failing the caller. A nil store has no blobs.
+func DeleteAll(ctx context.Context, store Store, keys []string) {
+	if store == nil {
+		return
+	}
+	for _, key := range keys {
+		if err := store.Delete(ctx, key); err != nil {
+			log.Printf("error deleting attachment content %s: %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package blobstore_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/config"
+	"github.com/guidewire/fern-reporter/pkg/blobstore"
+)
+
+var _ = Describe("Initialize", func() {
+	It("should create a local store in the configured directory", func() {
+		root := GinkgoT().TempDir()
+		GinkgoT().Setenv("FERN_ATTACHMENTS_STORE", blobstore.KindLocal)
+		GinkgoT().Setenv("FERN_ATTACHMENTS_PATH", filepath.Join(root, "attachments"))
+		_, err := config.LoadConfig()
+		Expect(err).NotTo(HaveOccurred())
+
+		blobstore.Initialize()
+
+		Expect(blobstore.GetStore()).NotTo(BeNil())
+		Expect(filepath.Join(root, "attachments")).To(BeADirectory())
+	})
+
+	It("should leave attachments unavailable when the store cannot be created", func() {
+		file := filepath.Join(GinkgoT().TempDir(), "not-a-directory")
+		Expect(os.WriteFile(file, nil, 0o600)).To(Succeed())
+		GinkgoT().Setenv("FERN_ATTACHMENTS_STORE", blobstore.KindLocal)
+		GinkgoT().Setenv("FERN_ATTACHMENTS_PATH", filepath.Join(file, "attachments"))
+		_, err := config.LoadConfig()
+		Expect(err).NotTo(HaveOccurred())
+
+		blobstore.Initialize()
+
+		Expect(blobstore.GetStore()).To(BeNil())
+	})
+})
+
+var _ = Describe("DeleteAll", func() {
+	It("should delete every blob, skipping missing ones", func() {
+		ctx := context.Background()
+		store, err := blobstore.NewLocalStore(GinkgoT().TempDir())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store.Put(ctx, "1/a.txt", strings.NewReader("a"), 1, "text/plain")).To(Succeed())
+		Expect(store.Put(ctx, "1/b.txt", strings.NewReader("b"), 1, "text/plain")).To(Succeed())
+
+		blobstore.DeleteAll(ctx, store, []string{"1/a.txt", "1/missing.txt", "1/b.txt"})
+
+		_, err = store.Get(ctx, "1/a.txt")
+		Expect(err).To(MatchError(blobstore.ErrNotFound))
+		_, err = store.Get(ctx, "1/b.txt")
+		Expect(err).To(MatchError(blobstore.ErrNotFound))
+	})
+
+	It("should do nothing without a store", func() {
+		Expect(func() { blobstore.DeleteAll(context.Background(), nil, []string{"1/a.txt"}) }).NotTo(Panic())
+	})
+})
@@ -0,0 +1,61 @@
+package blobstore_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/pkg/blobstore"
+)
+
+var _ = Describe("LocalStore", func() {
+	var (
+		ctx   context.Context
+		root  string
+		store *blobstore.LocalStore
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		root = GinkgoT().TempDir()
+		var err error
+		store, err = blobstore.NewLocalStore(filepath.Join(root, "attachments"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should store, read and delete blobs", func() {
+		Expect(store.Put(ctx, "testruns/1/abc", strings.NewReader("hello"), 5, "text/plain")).To(Succeed())
+
+		reader, err := store.Get(ctx, "testruns/1/abc")
+		Expect(err).NotTo(HaveOccurred())
+		data, err := io.ReadAll(reader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reader.Close()).To(Succeed())
+		Expect(string(data)).To(Equal("hello"))
+
+		Expect(store.Delete(ctx, "testruns/1/abc")).To(Succeed())
+		_, err = store.Get(ctx, "testruns/1/abc")
+		Expect(err).To(MatchError(blobstore.ErrNotFound))
+		Expect(store.Delete(ctx, "testruns/1/abc")).To(Succeed())
+	})
+
+	It("should not keep a blob whose size does not match", func() {
+		err := store.Put(ctx, "testruns/1/short", strings.NewReader("hi"), 5, "text/plain")
+
+		Expect(err).To(MatchError(ContainSubstring("expected 5 bytes")))
+		entries, err := os.ReadDir(filepath.Join(root, "attachments", "testruns", "1"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(BeEmpty())
+	})
+
+	It("should reject keys outside of its directory", func() {
+		for _, key := range []string{"", "/etc/passwd", "../secret", "testruns/../../secret", "testruns//1"} {
+			Expect(store.Put(ctx, key, strings.NewReader(""), 0, "")).To(MatchError(ContainSubstring("invalid blob key")), key)
+		}
+	})
+})
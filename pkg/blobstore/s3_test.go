@@ -0,0 +1,139 @@
+package blobstore_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/pkg/blobstore"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// fakeS3 is a stand-in for an S3-compatible service with path style
+// addressing. It keeps objects in memory and only checks that requests are
+// signed.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=fern/") {
+		http.Error(w, "AccessDenied", http.StatusForbidden)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = data
+		f.types[r.URL.Path] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+var _ = Describe("S3Store", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("should store, read and delete objects", func() {
+		fake := &fakeS3{objects: map[string][]byte{}, types: map[string]string{}}
+		server := httptest.NewServer(fake)
+		DeferCleanup(server.Close)
+
+		store, err := blobstore.NewS3Store(blobstore.S3Options{
+			Endpoint:        server.URL,
+			Bucket:          "attachments",
+			AccessKeyID:     "fern",
+			SecretAccessKey: "secret",
+			UsePathStyle:    true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(store.Put(ctx, "testruns/1/abc", strings.NewReader("hello"), 5, "text/plain")).To(Succeed())
+		Expect(fake.objects).To(HaveKeyWithValue("/attachments/testruns/1/abc", []byte("hello")))
+		Expect(fake.types).To(HaveKeyWithValue("/attachments/testruns/1/abc", "text/plain"))
+
+		reader, err := store.Get(ctx, "testruns/1/abc")
+		Expect(err).NotTo(HaveOccurred())
+		data, err := io.ReadAll(reader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reader.Close()).To(Succeed())
+		Expect(string(data)).To(Equal("hello"))
+
+		Expect(store.Delete(ctx, "testruns/1/abc")).To(Succeed())
+		_, err = store.Get(ctx, "testruns/1/abc")
+		Expect(err).To(MatchError(blobstore.ErrNotFound))
+	})
+
+	It("should report errors of the service", func() {
+		server := httptest.NewServer(&fakeS3{})
+		DeferCleanup(server.Close)
+
+		store, err := blobstore.NewS3Store(blobstore.S3Options{Endpoint: server.URL, Bucket: "attachments", AccessKeyID: "intruder", UsePathStyle: true})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = store.Put(ctx, "testruns/1/abc", strings.NewReader("hello"), 5, "text/plain")
+		Expect(err).To(MatchError(ContainSubstring("403 Forbidden: AccessDenied")))
+	})
+
+	It("should sign requests with AWS Signature Version 4", func() {
+		var sent *http.Request
+		store, err := blobstore.NewS3Store(blobstore.S3Options{
+			Endpoint:        "https://s3.example.com",
+			Bucket:          "fern",
+			Region:          "eu-west-1",
+			AccessKeyID:     "fern",
+			SecretAccessKey: "secret",
+			Client: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				sent = req
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+			})},
+			Now: func() time.Time { return time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC) },
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(store.Put(ctx, "testruns/1/report log.txt", strings.NewReader("hello"), 5, "text/plain")).To(Succeed())
+
+		Expect(sent.URL.String()).To(Equal("https://fern.s3.example.com/testruns/1/report%20log.txt"))
+		Expect(sent.Header.Get("X-Amz-Date")).To(Equal("20240420T120000Z"))
+		Expect(sent.Header.Get("Authorization")).To(Equal("AWS4-HMAC-SHA256 " +
+			"Credential=fern/20240420/eu-west-1/s3/aws4_request, " +
+			"SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date, " +
+			"Signature=b7c3e82af64ba728cac3c8f309aeef0937bc16dae58e4d1af06ba4c3f1393bc0"))
+	})
+
+	It("should require an endpoint and a bucket", func() {
+		_, err := blobstore.NewS3Store(blobstore.S3Options{Endpoint: "s3.example.com", Bucket: "fern"})
+		Expect(err).To(MatchError(ContainSubstring("scheme must be http or https")))
+
+		_, err = blobstore.NewS3Store(blobstore.S3Options{Endpoint: "https://s3.example.com"})
+		Expect(err).To(MatchError(ContainSubstring("needs an endpoint and a bucket")))
+	})
+})
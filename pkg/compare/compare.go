@@ -0,0 +1,286 @@
+// Package compare diffs two test runs of a project at the spec level, for
+// example the latest run of main against a pull request branch.
+//
+// Specs are matched by their test case, or by suite name and the full text of
+// their containers and description, rather than by their spec run ids, which
+// differ in every run. A spec that only ran in the head
+// run was added and one that only ran in the base run was removed. A spec of
+// both runs is newly failing when it only failed in the head run, newly
+// passing when it passed in the head run after failing in the base run,
+// unchanged when its status is the same and otherwise changed. Duration
+// changes are reported apart from status changes, when a spec ran in both
+// runs and its duration changed by at least MinDurationChange and by at least
+// DurationChangeRatio of its base duration.
+package compare
+
+import (
+	"time"
+
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+)
+
+// Changes of a spec between the base and the head run.
+const (
+	ChangeAdded         = "added"
+	ChangeRemoved       = "removed"
+	ChangeNewlyFailing  = "newly_failing"
+	ChangeNewlyPassing  = "newly_passing"
+	ChangeStatusChanged = "status_changed"
+	ChangeUnchanged     = "unchanged"
+)
+
+// Significant duration changes of a spec.
+const (
+	DurationSlower = "slower"
+	DurationFaster = "faster"
+)
+
+const (
+	// MinDurationChange is the smallest significant duration change.
+	MinDurationChange = time.Second
+	// DurationChangeRatio is the smallest significant duration change,
+	// relative to the base duration.
+	DurationChangeRatio = 0.5
+)
+
+// specKey identifies a spec across runs by its test case, or by its suite
+// name and full text. Occurrence tells apart the specs of a run that share
+// their key, in the order they ran.
+type specKey struct {
+	testCaseID uint64
+	suiteName  string
+	fullText   string
+	occurrence int
+}
+
+type spec struct {
+	// byTestCase is only set for the specs with a test case.
+	byTestCase *specKey
+	byName     specKey
+	suiteName  string
+	specRun    models.SpecRun
+}
+
+// specsOf lists the specs of a test run in order.
+func specsOf(testRun models.TestRun) []spec {
+	var specs []spec
+	occurrences := map[specKey]int{}
+	keyed := func(key specKey) specKey {
+		occurrence := occurrences[key]
+		occurrences[key]++
+		key.occurrence = occurrence
+		return key
+	}
+	for _, suiteRun := range testRun.SuiteRuns {
+		for _, specRun := range suiteRun.SpecRuns {
+			s := spec{
+				byName:    keyed(specKey{suiteName: suiteRun.SuiteName, fullText: specRun.Containers.FullText(specRun.SpecDescription)}),
+				suiteName: suiteRun.SuiteName,
+				specRun:   specRun,
+			}
+			if specRun.TestCaseID != nil {
+				byTestCase := keyed(specKey{testCaseID: *specRun.TestCaseID})
+				s.byTestCase = &byTestCase
+			}
+			specs = append(specs, s)
+		}
+	}
+	return specs
+}
+
+// Runs diffs the specs of the head run against the base run. Suites and specs
+// keep the order of the head run, followed by those only in the base run.
+func Runs(base, head models.TestRun) models.RunComparison {
+	comparison := models.RunComparison{Base: comparedRun(base), Head: comparedRun(head)}
+
+	var suites []*models.SuiteComparison
+	suitesByName := map[string]*models.SuiteComparison{}
+	suiteOf := func(name string) *models.SuiteComparison {
+		suite, ok := suitesByName[name]
+		if !ok {
+			suite = &models.SuiteComparison{SuiteName: name}
+			suitesByName[name] = suite
+			suites = append(suites, suite)
+		}
+		return suite
+	}
+
+	// Specs are matched by their test case when they have one in both runs,
+	// and by their suite name and full text otherwise.
+	baseSpecs := specsOf(base)
+	baseIndexes := map[specKey]int{}
+	for i, baseSpec := range baseSpecs {
+		if baseSpec.byTestCase != nil {
+			baseIndexes[*baseSpec.byTestCase] = i
+		}
+		baseIndexes[baseSpec.byName] = i
+	}
+	matched := make([]bool, len(baseSpecs))
+	baseIndexOf := func(headSpec spec) (int, bool) {
+		if headSpec.byTestCase != nil {
+			if i, ok := baseIndexes[*headSpec.byTestCase]; ok && !matched[i] {
+				return i, true
+			}
+		}
+		i, ok := baseIndexes[headSpec.byName]
+		return i, ok && !matched[i]
+	}
+	for _, headSpec := range specsOf(head) {
+		specComparison := models.SpecComparison{Change: ChangeAdded}
+		if i, ok := baseIndexOf(headSpec); ok {
+			matched[i] = true
+			specComparison = compareSpecRuns(baseSpecs[i].specRun, headSpec.specRun)
+		}
+		setHead(&specComparison, headSpec.specRun)
+		addSpec(suiteOf(headSpec.suiteName), headSpec, specComparison)
+	}
+	for i, baseSpec := range baseSpecs {
+		if matched[i] {
+			continue
+		}
+		specComparison := models.SpecComparison{Change: ChangeRemoved}
+		setBase(&specComparison, baseSpec.specRun)
+		addSpec(suiteOf(baseSpec.suiteName), baseSpec, specComparison)
+	}
+
+	baseSuites := suiteDurations(base)
+	headSuites := suiteDurations(head)
+	for _, suite := range suites {
+		baseDuration, inBase := baseSuites[suite.SuiteName]
+		headDuration, inHead := headSuites[suite.SuiteName]
+		suite.BaseDuration, suite.HeadDuration = baseDuration, headDuration
+		switch {
+		case !inBase:
+			suite.Change = ChangeAdded
+		case !inHead:
+			suite.Change = ChangeRemoved
+		}
+		comparison.Counts = addCounts(comparison.Counts, suite.Counts)
+		comparison.Suites = append(comparison.Suites, *suite)
+	}
+	return comparison
+}
+
+func comparedRun(testRun models.TestRun) models.ComparedRun {
+	return models.ComparedRun{
+		TestRunID:       testRun.ID,
+		TestProjectName: testRun.TestProjectName,
+		GitBranch:       testRun.GitBranch,
+		GitSha:          testRun.GitSha,
+		StartTime:       testRun.StartTime,
+		Status:          testRun.Status,
+	}
+}
+
+// compareSpecRuns compares the runs of a spec in the base and the head run.
+func compareSpecRuns(baseSpecRun, headSpecRun models.SpecRun) models.SpecComparison {
+	var specComparison models.SpecComparison
+	setBase(&specComparison, baseSpecRun)
+	setHead(&specComparison, headSpecRun)
+
+	switch {
+	case utils.IsFailedStatus(headSpecRun.Status) && !utils.IsFailedStatus(baseSpecRun.Status):
+		specComparison.Change = ChangeNewlyFailing
+	case utils.IsPassedStatus(headSpecRun.Status) && utils.IsFailedStatus(baseSpecRun.Status):
+		specComparison.Change = ChangeNewlyPassing
+	case headSpecRun.Status == baseSpecRun.Status:
+		specComparison.Change = ChangeUnchanged
+	default:
+		specComparison.Change = ChangeStatusChanged
+	}
+
+	if utils.IsExecutedStatus(baseSpecRun.Status) && utils.IsExecutedStatus(headSpecRun.Status) {
+		specComparison.DurationChange = durationChange(specComparison.BaseDuration, specComparison.HeadDuration)
+	}
+	return specComparison
+}
+
+func setBase(specComparison *models.SpecComparison, specRun models.SpecRun) {
+	specComparison.BaseStatus = specRun.Status
+	specComparison.BaseSpecRunID = specRun.ID
+	specComparison.BaseDuration = duration(specRun.StartTime, specRun.EndTime)
+}
+
+func setHead(specComparison *models.SpecComparison, specRun models.SpecRun) {
+	specComparison.HeadStatus = specRun.Status
+	specComparison.HeadSpecRunID = specRun.ID
+	specComparison.HeadDuration = duration(specRun.StartTime, specRun.EndTime)
+}
+
+// durationChange tells whether a duration changed significantly. Unknown
+// durations never do.
+func durationChange(baseDuration, headDuration float64) string {
+	if baseDuration <= 0 || headDuration <= 0 {
+		return ""
+	}
+	change := headDuration - baseDuration
+	if change < 0 {
+		change = -change
+	}
+	if change < MinDurationChange.Seconds() || change < DurationChangeRatio*baseDuration {
+		return ""
+	}
+	if headDuration > baseDuration {
+		return DurationSlower
+	}
+	return DurationFaster
+}
+
+// duration is the number of seconds between two times, or 0 when unknown.
+func duration(start, end time.Time) float64 {
+	if start.IsZero() || end.Before(start) {
+		return 0
+	}
+	return end.Sub(start).Seconds()
+}
+
+// suiteDurations adds up the durations of the suite runs of a test run by
+// suite name.
+func suiteDurations(testRun models.TestRun) map[string]float64 {
+	durations := map[string]float64{}
+	for _, suiteRun := range testRun.SuiteRuns {
+		durations[suiteRun.SuiteName] += duration(suiteRun.StartTime, suiteRun.EndTime)
+	}
+	return durations
+}
+
+func addSpec(suite *models.SuiteComparison, s spec, specComparison models.SpecComparison) {
+	specComparison.SuiteName = s.suiteName
+	specComparison.SpecDescription = s.specRun.SpecDescription
+	suite.Specs = append(suite.Specs, specComparison)
+
+	switch specComparison.Change {
+	case ChangeAdded:
+		suite.Counts.Added++
+	case ChangeRemoved:
+		suite.Counts.Removed++
+	case ChangeNewlyFailing:
+		suite.Counts.NewlyFailing++
+	case ChangeNewlyPassing:
+		suite.Counts.NewlyPassing++
+	case ChangeStatusChanged:
+		suite.Counts.StatusChanged++
+	case ChangeUnchanged:
+		suite.Counts.Unchanged++
+	}
+	switch specComparison.DurationChange {
+	case DurationSlower:
+		suite.Counts.Slower++
+	case DurationFaster:
+		suite.Counts.Faster++
+	}
+}
+
+func addCounts(a, b models.ComparisonCounts) models.ComparisonCounts {
+	return models.ComparisonCounts{
+		Added:         a.Added + b.Added,
+		Removed:       a.Removed + b.Removed,
+		NewlyFailing:  a.NewlyFailing + b.NewlyFailing,
+		NewlyPassing:  a.NewlyPassing + b.NewlyPassing,
+		StatusChanged: a.StatusChanged + b.StatusChanged,
+		Unchanged:     a.Unchanged + b.Unchanged,
+		Slower:        a.Slower + b.Slower,
+		Faster:        a.Faster + b.Faster,
+	}
+}
@@ -0,0 +1,143 @@
+package compare_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/pkg/compare"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Runs", func() {
+	start := time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+	specRun := func(id uint64, description, status string, seconds int) models.SpecRun {
+		return models.SpecRun{
+			ID:              id,
+			SpecDescription: description,
+			Status:          status,
+			StartTime:       start,
+			EndTime:         start.Add(time.Duration(seconds) * time.Second),
+		}
+	}
+	suiteRun := func(name string, seconds int, specRuns ...models.SpecRun) models.SuiteRun {
+		return models.SuiteRun{SuiteName: name, StartTime: start, EndTime: start.Add(time.Duration(seconds) * time.Second), SpecRuns: specRuns}
+	}
+
+	base := models.TestRun{ID: 1, GitBranch: "main", SuiteRuns: []models.SuiteRun{
+		suiteRun("Cart", 20,
+			specRun(10, "adds an item", "passed", 2),
+			specRun(11, "empties", "failed", 2),
+			specRun(12, "checks out", "passed", 10),
+			specRun(13, "applies a coupon", "passed", 1),
+			specRun(14, "pays", "passed", 1),
+			specRun(15, "pays", "failed", 1)),
+		suiteRun("Wishlist", 5, specRun(16, "saves an item", "passed", 1)),
+	}}
+	head := models.TestRun{ID: 2, GitBranch: "feature", SuiteRuns: []models.SuiteRun{
+		suiteRun("Cart", 30,
+			specRun(20, "adds an item", "failed", 2),
+			specRun(21, "empties", "passed", 2),
+			specRun(22, "checks out", "passed", 25),
+			specRun(23, "pays", "passed", 1),
+			specRun(24, "pays", "skipped", 0),
+			specRun(25, "ships", "passed", 1)),
+		suiteRun("Search", 3, specRun(26, "finds an item", "passed", 1)),
+	}}
+
+	It("should match specs by suite name and description", func() {
+		comparison := compare.Runs(base, head)
+
+		Expect(comparison.Base.TestRunID).To(Equal(uint64(1)))
+		Expect(comparison.Head.GitBranch).To(Equal("feature"))
+		Expect(comparison.Suites).To(HaveLen(3))
+
+		cart := comparison.Suites[0]
+		Expect(cart.SuiteName).To(Equal("Cart"))
+		Expect(cart.Change).To(BeEmpty())
+		Expect(cart.BaseDuration).To(Equal(20.0))
+		Expect(cart.HeadDuration).To(Equal(30.0))
+		changes := map[string][]string{}
+		for _, spec := range cart.Specs {
+			changes[spec.SpecDescription] = append(changes[spec.SpecDescription], spec.Change)
+		}
+		Expect(changes).To(Equal(map[string][]string{
+			"adds an item":     {compare.ChangeNewlyFailing},
+			"empties":          {compare.ChangeNewlyPassing},
+			"checks out":       {compare.ChangeUnchanged},
+			"pays":             {compare.ChangeUnchanged, compare.ChangeStatusChanged},
+			"ships":            {compare.ChangeAdded},
+			"applies a coupon": {compare.ChangeRemoved},
+		}))
+		Expect(cart.Specs[0].BaseSpecRunID).To(Equal(uint64(10)))
+		Expect(cart.Specs[0].HeadSpecRunID).To(Equal(uint64(20)))
+		Expect(cart.Specs[len(cart.Specs)-1].SpecDescription).To(Equal("applies a coupon"))
+		Expect(cart.Specs[len(cart.Specs)-1].HeadStatus).To(BeEmpty())
+
+		Expect(comparison.Suites[1].SuiteName).To(Equal("Search"))
+		Expect(comparison.Suites[1].Change).To(Equal(compare.ChangeAdded))
+		Expect(comparison.Suites[2].SuiteName).To(Equal("Wishlist"))
+		Expect(comparison.Suites[2].Change).To(Equal(compare.ChangeRemoved))
+		Expect(comparison.Suites[2].Counts.Removed).To(Equal(1))
+	})
+
+	It("should only report significant duration changes of specs that ran in both runs", func() {
+		comparison := compare.Runs(base, head)
+
+		cart := comparison.Suites[0]
+		Expect(cart.Specs[2].SpecDescription).To(Equal("checks out"))
+		Expect(cart.Specs[2].DurationChange).To(Equal(compare.DurationSlower))
+		Expect(cart.Specs[2].BaseDuration).To(Equal(10.0))
+		Expect(cart.Specs[2].HeadDuration).To(Equal(25.0))
+		for _, spec := range cart.Specs {
+			if spec.SpecDescription != "checks out" {
+				Expect(spec.DurationChange).To(BeEmpty(), spec.SpecDescription)
+			}
+		}
+
+		faster := compare.Runs(head, base)
+		Expect(faster.Suites[0].Specs[2].DurationChange).To(Equal(compare.DurationFaster))
+		Expect(faster.Counts.Faster).To(Equal(1))
+	})
+
+	It("should roll the counts up per suite and for the comparison", func() {
+		comparison := compare.Runs(base, head)
+
+		Expect(comparison.Suites[0].Counts).To(Equal(models.ComparisonCounts{
+			Added: 1, Removed: 1, NewlyFailing: 1, NewlyPassing: 1, StatusChanged: 1, Unchanged: 2, Slower: 1,
+		}))
+		Expect(comparison.Counts).To(Equal(models.ComparisonCounts{
+			Added: 2, Removed: 2, NewlyFailing: 1, NewlyPassing: 1, StatusChanged: 1, Unchanged: 2, Slower: 1,
+		}))
+	})
+
+	It("should tell specs apart by their containers and match them by their test case", func() {
+		inContainer := func(specRun models.SpecRun, container string) models.SpecRun {
+			specRun.Containers = models.Containers{{Text: container}}
+			return specRun
+		}
+		withTestCase := func(specRun models.SpecRun, testCaseID uint64) models.SpecRun {
+			specRun.TestCaseID = &testCaseID
+			return specRun
+		}
+		base := models.TestRun{ID: 1, SuiteRuns: []models.SuiteRun{suiteRun("Cart", 10,
+			inContainer(specRun(10, "is empty", "passed", 1), "when created"),
+			inContainer(specRun(11, "is empty", "passed", 1), "when emptied"),
+			withTestCase(specRun(12, "pays", "passed", 1), 7))}}
+		head := models.TestRun{ID: 2, SuiteRuns: []models.SuiteRun{suiteRun("Cart", 10,
+			inContainer(specRun(20, "is empty", "failed", 1), "when emptied"),
+			inContainer(specRun(21, "is empty", "passed", 1), "when created"),
+			withTestCase(specRun(22, "pays by card", "passed", 1), 7))}}
+
+		specs := compare.Runs(base, head).Suites[0].Specs
+
+		Expect(specs).To(HaveLen(3))
+		Expect(specs[0].BaseSpecRunID).To(Equal(uint64(11)))
+		Expect(specs[0].Change).To(Equal(compare.ChangeNewlyFailing))
+		Expect(specs[1].BaseSpecRunID).To(Equal(uint64(10)))
+		Expect(specs[1].Change).To(Equal(compare.ChangeUnchanged))
+		Expect(specs[2].SpecDescription).To(Equal("pays by card"))
+		Expect(specs[2].BaseSpecRunID).To(Equal(uint64(12)))
+	})
+})
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS test_runs_in_progress_idx;
+
+ALTER TABLE public.test_runs
+    DROP COLUMN IF EXISTS last_activity_time,
+    DROP COLUMN IF EXISTS status;
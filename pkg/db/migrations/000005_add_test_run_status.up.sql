@@ -0,0 +1,19 @@
+ALTER TABLE public.test_runs
+    ADD COLUMN status text NOT NULL DEFAULT '',
+    ADD COLUMN last_activity_time timestamp with time zone;
+
+-- Runs created before the lifecycle API were always posted complete
+UPDATE public.test_runs
+SET status = CASE
+        WHEN EXISTS (
+            SELECT 1
+            FROM public.suite_runs
+            INNER JOIN public.spec_runs ON suite_runs.id = spec_runs.suite_id
+            WHERE suite_runs.test_run_id = test_runs.id
+              AND spec_runs.status = 'failed'
+        ) THEN 'failed'
+        ELSE 'passed'
+    END,
+    last_activity_time = end_time;
+
+CREATE INDEX test_runs_in_progress_idx ON public.test_runs (last_activity_time) WHERE status = 'in_progress';
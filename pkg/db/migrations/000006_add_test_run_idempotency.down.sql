@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS public.test_runs_idempotency_key_idx;
+
+ALTER TABLE public.test_runs
+    DROP COLUMN IF EXISTS idempotency_key;
+
+ALTER TABLE public.tags
+    DROP CONSTRAINT IF EXISTS tags_name_key;
@@ -0,0 +1,25 @@
+-- Merge duplicate tags into the oldest one before enforcing unique names
+INSERT INTO public.spec_run_tags (spec_run_id, tag_id)
+SELECT spec_run_tags.spec_run_id, keep.id
+FROM public.spec_run_tags
+INNER JOIN public.tags ON tags.id = spec_run_tags.tag_id
+INNER JOIN (
+    SELECT name, MIN(id) AS id
+    FROM public.tags
+    GROUP BY name
+) keep ON keep.name = tags.name
+WHERE keep.id <> tags.id
+ON CONFLICT DO NOTHING;
+
+DELETE FROM public.tags
+USING public.tags keep
+WHERE tags.name = keep.name
+  AND tags.id > keep.id;
+
+ALTER TABLE public.tags
+    ADD CONSTRAINT tags_name_key UNIQUE (name);
+
+ALTER TABLE public.test_runs
+    ADD COLUMN idempotency_key text;
+
+CREATE UNIQUE INDEX test_runs_idempotency_key_idx ON public.test_runs (idempotency_key);
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS public.test_runs_git_sha_idx;
+DROP INDEX IF EXISTS public.test_runs_git_branch_idx;
+
+ALTER TABLE public.test_runs
+    DROP COLUMN IF EXISTS git_sha,
+    DROP COLUMN IF EXISTS git_branch,
+    DROP COLUMN IF EXISTS git_repo_url,
+    DROP COLUMN IF EXISTS pull_request_number,
+    DROP COLUMN IF EXISTS ci_provider,
+    DROP COLUMN IF EXISTS build_number,
+    DROP COLUMN IF EXISTS build_url,
+    DROP COLUMN IF EXISTS triggered_by,
+    DROP COLUMN IF EXISTS environment;
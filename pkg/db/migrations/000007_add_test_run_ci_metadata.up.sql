@@ -0,0 +1,13 @@
+ALTER TABLE public.test_runs
+    ADD COLUMN git_sha text NOT NULL DEFAULT '',
+    ADD COLUMN git_branch text NOT NULL DEFAULT '',
+    ADD COLUMN git_repo_url text NOT NULL DEFAULT '',
+    ADD COLUMN pull_request_number integer NOT NULL DEFAULT 0,
+    ADD COLUMN ci_provider text NOT NULL DEFAULT '',
+    ADD COLUMN build_number text NOT NULL DEFAULT '',
+    ADD COLUMN build_url text NOT NULL DEFAULT '',
+    ADD COLUMN triggered_by text NOT NULL DEFAULT '',
+    ADD COLUMN environment text NOT NULL DEFAULT '';
+
+CREATE INDEX test_runs_git_branch_idx ON public.test_runs (test_project_name, git_branch);
+CREATE INDEX test_runs_git_sha_idx ON public.test_runs (git_sha);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS public.spec_runs_labels_idx;
+DROP INDEX IF EXISTS public.suite_runs_labels_idx;
+DROP INDEX IF EXISTS public.test_runs_labels_idx;
+
+ALTER TABLE public.spec_runs DROP COLUMN IF EXISTS labels;
+ALTER TABLE public.suite_runs DROP COLUMN IF EXISTS labels;
+ALTER TABLE public.test_runs DROP COLUMN IF EXISTS labels;
@@ -0,0 +1,12 @@
+ALTER TABLE public.test_runs
+    ADD COLUMN labels jsonb NOT NULL DEFAULT '{}';
+
+ALTER TABLE public.suite_runs
+    ADD COLUMN labels jsonb NOT NULL DEFAULT '{}';
+
+ALTER TABLE public.spec_runs
+    ADD COLUMN labels jsonb NOT NULL DEFAULT '{}';
+
+CREATE INDEX test_runs_labels_idx ON public.test_runs USING gin (labels);
+CREATE INDEX suite_runs_labels_idx ON public.suite_runs USING gin (labels);
+CREATE INDEX spec_runs_labels_idx ON public.spec_runs USING gin (labels);
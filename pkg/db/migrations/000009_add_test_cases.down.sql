@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS public.spec_runs_test_case_id_idx;
+
+ALTER TABLE public.spec_runs
+    DROP COLUMN IF EXISTS test_case_id;
+
+DROP TABLE IF EXISTS public.test_cases;
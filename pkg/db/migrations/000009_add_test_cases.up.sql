@@ -0,0 +1,36 @@
+CREATE TABLE public.test_cases (
+    id bigserial PRIMARY KEY,
+    test_project_name text NOT NULL,
+    suite_name text NOT NULL DEFAULT '',
+    spec_description text NOT NULL DEFAULT '',
+    fingerprint text NOT NULL,
+    merged_into_id bigint REFERENCES public.test_cases(id) ON DELETE SET NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT test_cases_project_fingerprint_key UNIQUE (test_project_name, fingerprint)
+);
+
+ALTER TABLE public.spec_runs
+    ADD COLUMN test_case_id bigint REFERENCES public.test_cases(id) ON DELETE SET NULL;
+
+CREATE INDEX spec_runs_test_case_id_idx ON public.spec_runs (test_case_id);
+
+-- Link the existing spec runs using the default "exact" fingerprint, the
+-- SHA-256 of the suite name and spec description separated by a unit separator
+INSERT INTO public.test_cases (test_project_name, suite_name, spec_description, fingerprint)
+SELECT DISTINCT ON (COALESCE(tr.test_project_name, ''), fp.fingerprint)
+       COALESCE(tr.test_project_name, ''), COALESCE(s.suite_name, ''), COALESCE(sp.spec_description, ''), fp.fingerprint
+FROM public.spec_runs sp
+    JOIN public.suite_runs s ON s.id = sp.suite_id
+    JOIN public.test_runs tr ON tr.id = s.test_run_id
+    CROSS JOIN LATERAL (
+        SELECT encode(sha256(convert_to(COALESCE(s.suite_name, '') || chr(31) || COALESCE(sp.spec_description, ''), 'UTF8')), 'hex') AS fingerprint
+    ) fp
+ORDER BY COALESCE(tr.test_project_name, ''), fp.fingerprint, sp.id DESC;
+
+UPDATE public.spec_runs sp
+SET test_case_id = tc.id
+FROM public.suite_runs s, public.test_runs tr, public.test_cases tc
+WHERE s.id = sp.suite_id
+  AND tr.id = s.test_run_id
+  AND tc.test_project_name = COALESCE(tr.test_project_name, '')
+  AND tc.fingerprint = encode(sha256(convert_to(COALESCE(s.suite_name, '') || chr(31) || COALESCE(sp.spec_description, ''), 'UTF8')), 'hex');
@@ -0,0 +1 @@
+ALTER TABLE public.spec_runs DROP COLUMN IF EXISTS containers;
@@ -0,0 +1,3 @@
+-- Structured failure details, stored as gzip compressed JSON
+ALTER TABLE public.spec_runs
+    ADD COLUMN failure bytea;
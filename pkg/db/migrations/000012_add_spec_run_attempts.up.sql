@@ -0,0 +1,2 @@
+ALTER TABLE public.spec_runs
+    ADD COLUMN attempts jsonb NOT NULL DEFAULT '[]';
@@ -0,0 +1,13 @@
+CREATE TABLE public.attachments (
+    id bigserial PRIMARY KEY,
+    test_run_id bigint NOT NULL REFERENCES public.test_runs(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    spec_run_id bigint REFERENCES public.spec_runs(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    file_name text NOT NULL,
+    content_type text NOT NULL,
+    size bigint NOT NULL,
+    storage_key text NOT NULL UNIQUE,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX attachments_test_run_id_idx ON public.attachments (test_run_id);
+CREATE INDEX attachments_spec_run_id_idx ON public.attachments (spec_run_id);
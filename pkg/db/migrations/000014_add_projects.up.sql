@@ -0,0 +1,38 @@
+CREATE TABLE public.projects (
+    id bigserial PRIMARY KEY,
+    slug text NOT NULL UNIQUE,
+    name text NOT NULL UNIQUE,
+    display_name text NOT NULL DEFAULT '',
+    description text NOT NULL DEFAULT '',
+    team text NOT NULL DEFAULT '',
+    default_branch text NOT NULL DEFAULT '',
+    retention_days integer NOT NULL DEFAULT 0 CHECK (retention_days >= 0),
+    report_header text NOT NULL DEFAULT '',
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+-- Register every project that already reported runs. Names that slugify to
+-- the same slug are told apart by a suffix derived from the name.
+INSERT INTO public.projects (slug, name, display_name)
+SELECT CASE WHEN row_number() OVER (PARTITION BY base ORDER BY name) = 1 THEN base
+            ELSE base || '-' || substr(md5(name), 1, 8) END,
+       name, name
+FROM (
+    SELECT DISTINCT test_project_name AS name,
+           COALESCE(NULLIF(trim(BOTH '-' FROM regexp_replace(lower(test_project_name), '[^a-z0-9]+', '-', 'g')), ''), 'project') AS base
+    FROM public.test_runs
+    WHERE COALESCE(test_project_name, '') <> ''
+) discovered;
+
+-- The default branch of a backfilled project is the branch it ran most often
+UPDATE public.projects p
+SET default_branch = branches.git_branch
+FROM (
+    SELECT DISTINCT ON (test_project_name) test_project_name, git_branch
+    FROM public.test_runs
+    WHERE git_branch <> ''
+    GROUP BY test_project_name, git_branch
+    ORDER BY test_project_name, count(*) DESC, git_branch
+) branches
+WHERE branches.test_project_name = p.name;
@@ -0,0 +1,11 @@
+CREATE TABLE public.ownership_rules (
+    id bigserial PRIMARY KEY,
+    project_id bigint NOT NULL REFERENCES public.projects(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    position integer NOT NULL,
+    suite text NOT NULL DEFAULT '',
+    spec text NOT NULL DEFAULT '',
+    file text NOT NULL DEFAULT '',
+    tag text NOT NULL DEFAULT '',
+    owner text NOT NULL,
+    UNIQUE (project_id, position)
+);
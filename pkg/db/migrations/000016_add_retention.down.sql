@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS public.test_runs_start_time_idx;
+DROP TABLE IF EXISTS public.test_run_daily_stats;
+ALTER TABLE public.projects DROP COLUMN IF EXISTS retention_runs_per_branch;
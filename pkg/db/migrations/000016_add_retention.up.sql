@@ -0,0 +1,19 @@
+ALTER TABLE public.projects
+    ADD COLUMN retention_runs_per_branch integer NOT NULL DEFAULT 0 CHECK (retention_runs_per_branch >= 0);
+
+-- Purged test runs are folded into daily aggregates per project branch.
+CREATE TABLE public.test_run_daily_stats (
+    test_project_name text NOT NULL,
+    git_branch text NOT NULL DEFAULT '',
+    day date NOT NULL,
+    runs bigint NOT NULL DEFAULT 0,
+    spec_runs bigint NOT NULL DEFAULT 0,
+    passed bigint NOT NULL DEFAULT 0,
+    failed bigint NOT NULL DEFAULT 0,
+    skipped bigint NOT NULL DEFAULT 0,
+    flaky bigint NOT NULL DEFAULT 0,
+    duration_seconds double precision NOT NULL DEFAULT 0,
+    PRIMARY KEY (test_project_name, git_branch, day)
+);
+
+CREATE INDEX IF NOT EXISTS test_runs_start_time_idx ON public.test_runs (start_time);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS public.test_runs_deleted_at_idx;
+ALTER TABLE public.test_runs DROP COLUMN IF EXISTS deleted_by;
+ALTER TABLE public.test_runs DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,6 @@
+-- Deleted test runs stay in the trash until they are restored or purged.
+ALTER TABLE public.test_runs
+    ADD COLUMN deleted_at timestamp with time zone,
+    ADD COLUMN deleted_by text NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS test_runs_deleted_at_idx ON public.test_runs (deleted_at);
@@ -0,0 +1,15 @@
+CREATE TABLE public.quarantine_rules (
+    id bigserial PRIMARY KEY,
+    test_project_name text NOT NULL,
+    test_case_id bigint REFERENCES public.test_cases(id) ON DELETE SET NULL,
+    suite_name text NOT NULL DEFAULT '',
+    spec_description text NOT NULL DEFAULT '',
+    reason text NOT NULL,
+    source text NOT NULL DEFAULT 'manual',
+    score double precision NOT NULL DEFAULT 0,
+    created_by text NOT NULL DEFAULT '',
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    expires_at timestamp with time zone NOT NULL
+);
+
+CREATE INDEX quarantine_rules_project_expires_at_idx ON public.quarantine_rules (test_project_name, expires_at);
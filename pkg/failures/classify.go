@@ -0,0 +1,178 @@
+package failures
+
+import (
+	"time"
+
+	"github.com/guidewire/fern-reporter/pkg/flaky"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// Kinds of failure classification.
+const (
+	// ClassificationNew is a failed spec run whose spec passed in its
+	// previous run, or never ran before.
+	ClassificationNew = "new_failure"
+	// ClassificationStillFailing is a failed spec run whose spec failed in
+	// its previous run too.
+	ClassificationStillFailing = "still_failing"
+	// ClassificationFixed is a passed spec run whose spec failed in its
+	// previous run.
+	ClassificationFixed = "fixed"
+)
+
+// HistoryWindow is how far back the previous runs of a test run are looked up.
+// A failure streak that started earlier is failing since the oldest run of the
+// window.
+const HistoryWindow = 30 * 24 * time.Hour
+
+// latestFirst orders the previous executions of each spec, latest first.
+// Specs are told apart by their test case, or by their names when they have
+// none.
+const latestFirst = "(PARTITION BY spec_runs.test_case_id, " +
+	"CASE WHEN spec_runs.test_case_id IS NULL THEN suite_runs.suite_name END, " +
+	"CASE WHEN spec_runs.test_case_id IS NULL THEN spec_runs.spec_description END " +
+	"ORDER BY test_runs.start_time DESC, spec_runs.id DESC)"
+
+// Classify classifies the spec runs of a test run against the previous runs
+// of its project branch. Only the latest previous execution of each of its
+// specs and the start of their failure streak are loaded, and nothing is
+// loaded when no spec run passed or failed.
+func Classify(db *gorm.DB, testRun *models.TestRun) error {
+	if !hasOutcomes(*testRun) {
+		return nil
+	}
+	history, err := loadHistory(db, testRun)
+	if err != nil {
+		return err
+	}
+	ClassifyAgainst(testRun, history)
+	return nil
+}
+
+// loadHistory loads the previous executions of the specs of a test run that
+// ClassifyAgainst needs, oldest first: the latest one and, when it failed, the
+// first failure of its streak.
+func loadHistory(db *gorm.DB, testRun *models.TestRun) ([]flaky.Execution, error) {
+	var testCaseIDs []uint64
+	var suiteNames, specDescriptions []string
+	for _, suiteRun := range testRun.SuiteRuns {
+		for _, specRun := range suiteRun.SpecRuns {
+			switch {
+			case !utils.IsFailedStatus(specRun.Status) && !utils.IsPassedStatus(specRun.Status):
+			case specRun.TestCaseID != nil:
+				testCaseIDs = append(testCaseIDs, *specRun.TestCaseID)
+			default:
+				suiteNames = append(suiteNames, suiteRun.SuiteName)
+				specDescriptions = append(specDescriptions, specRun.SpecDescription)
+			}
+		}
+	}
+	specs := db.Where("spec_runs.test_case_id IN ?", testCaseIDs)
+	if len(suiteNames) > 0 {
+		specs = specs.Or("spec_runs.test_case_id IS NULL AND suite_runs.suite_name IN ? AND spec_runs.spec_description IN ?",
+			suiteNames, specDescriptions)
+	}
+
+	// passes counts the passed executions from the latest one back, so the
+	// failure streak is the executions none of which passed; it starts where
+	// the execution before passed or there is none.
+	executions := db.Table("spec_runs").
+		Joins("INNER JOIN suite_runs ON suite_runs.id = spec_runs.suite_id").
+		Joins("INNER JOIN test_runs ON test_runs.id = suite_runs.test_run_id").
+		Select("spec_runs.test_case_id, test_runs.id AS test_run_id, spec_runs.id AS spec_run_id, suite_runs.suite_name, "+
+			"spec_runs.spec_description, spec_runs.status, test_runs.start_time, test_runs.git_branch, test_runs.git_sha, "+
+			"ROW_NUMBER() OVER "+latestFirst+" AS recency, "+
+			"COUNT(*) FILTER (WHERE spec_runs.status IN ('passed','flaky')) OVER "+latestFirst+" AS passes, "+
+			"LEAD(spec_runs.status) OVER "+latestFirst+" AS previous_status").
+		Where("test_runs.test_project_name = ? AND test_runs.git_branch = ?", testRun.TestProjectName, testRun.GitBranch).
+		Where("test_runs.start_time >= ? AND test_runs.start_time < ?", testRun.StartTime.Add(-HistoryWindow), testRun.StartTime).
+		Where("test_runs.id <> ?", testRun.ID).
+		Where("test_runs.deleted_at IS NULL").
+		Where("spec_runs.status IN ?", []string{utils.StatusPassed, utils.StatusFlaky, utils.StatusFailed, utils.StatusErrored}).
+		Where(specs)
+
+	history := []flaky.Execution{}
+	err := db.Table("(?) AS executions", executions).
+		Select("test_case_id, test_run_id, spec_run_id, suite_name, spec_description, status, start_time, git_branch, git_sha").
+		Where("recency = 1 OR (passes = 0 AND (previous_status IS NULL OR previous_status IN ('passed','flaky')))").
+		Order("start_time, spec_run_id").
+		Scan(&history).Error
+	return history, err
+}
+
+// ClassifyAgainst classifies the spec runs of a test run against the
+// executions of its spec in previous runs, oldest first. Executions of other
+// branches, of the test run itself and of runs that did not start before it
+// are ignored. Spec runs that neither failed nor fixed a failure are left
+// unclassified.
+func ClassifyAgainst(testRun *models.TestRun, history []flaky.Execution) {
+	previous := map[specKey][]flaky.Execution{}
+	for _, execution := range history {
+		if execution.TestRunID == testRun.ID || execution.GitBranch != testRun.GitBranch ||
+			!execution.StartTime.Before(testRun.StartTime) {
+			continue
+		}
+		key := keyOf(execution.TestCaseID, execution.SuiteName, execution.SpecDescription)
+		previous[key] = append(previous[key], execution)
+	}
+
+	for i := range testRun.SuiteRuns {
+		suiteRun := &testRun.SuiteRuns[i]
+		for j := range suiteRun.SpecRuns {
+			specRun := &suiteRun.SpecRuns[j]
+			key := keyOf(specRun.TestCaseID, suiteRun.SuiteName, specRun.SpecDescription)
+			specRun.Classification = classify(specRun.Status, previous[key])
+		}
+	}
+}
+
+// classify classifies a spec run with the status from the previous
+// executions of its spec, oldest first.
+func classify(status string, previous []flaky.Execution) *models.FailureClassification {
+	failed := utils.IsFailedStatus(status)
+	if !failed && !utils.IsPassedStatus(status) {
+		return nil
+	}
+	if len(previous) == 0 {
+		if failed {
+			return &models.FailureClassification{Kind: ClassificationNew}
+		}
+		return nil
+	}
+
+	last := previous[len(previous)-1]
+	lastFailed := utils.IsFailedStatus(last.Status)
+	switch {
+	case failed && !lastFailed:
+		return &models.FailureClassification{Kind: ClassificationNew, PreviousTestRunID: last.TestRunID}
+	case failed:
+		since := len(previous) - 1
+		for since > 0 && utils.IsFailedStatus(previous[since-1].Status) {
+			since--
+		}
+		first := previous[since]
+		return &models.FailureClassification{
+			Kind:                  ClassificationStillFailing,
+			FailingSince:          &first.StartTime,
+			FailingSinceTestRunID: first.TestRunID,
+			FailingSinceGitSha:    first.GitSha,
+			PreviousTestRunID:     last.TestRunID,
+		}
+	case lastFailed:
+		return &models.FailureClassification{Kind: ClassificationFixed, PreviousTestRunID: last.TestRunID}
+	}
+	return nil
+}
+
+func hasOutcomes(testRun models.TestRun) bool {
+	for _, suiteRun := range testRun.SuiteRuns {
+		for _, specRun := range suiteRun.SpecRuns {
+			if utils.IsFailedStatus(specRun.Status) || utils.IsPassedStatus(specRun.Status) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package failures_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/pkg/failures"
+	"github.com/guidewire/fern-reporter/pkg/flaky"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("ClassifyAgainst", func() {
+	start := time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+	execution := func(run uint64, spec, status string) flaky.Execution {
+		return flaky.Execution{
+			TestRunID:       run,
+			SpecRunID:       run * 10,
+			SuiteName:       "Cart",
+			SpecDescription: spec,
+			Status:          status,
+			StartTime:       start.Add(time.Duration(run) * time.Hour),
+			GitBranch:       "main",
+			GitSha:          string(rune('a' + run)),
+		}
+	}
+	var testRun models.TestRun
+
+	BeforeEach(func() {
+		testRun = models.TestRun{
+			ID:        5,
+			GitBranch: "main",
+			StartTime: start.Add(5 * time.Hour),
+			SuiteRuns: []models.SuiteRun{{SuiteName: "Cart", SpecRuns: []models.SpecRun{
+				{SpecDescription: "adds an item", Status: "failed"},
+				{SpecDescription: "empties", Status: "failed"},
+				{SpecDescription: "checks out", Status: "passed"},
+				{SpecDescription: "pays", Status: "skipped"},
+				{SpecDescription: "ships", Status: "failed"},
+			}}},
+		}
+	})
+
+	It("should classify failures as new, still failing or fixed", func() {
+		failures.ClassifyAgainst(&testRun, []flaky.Execution{
+			execution(1, "adds an item", "failed"),
+			execution(1, "empties", "passed"),
+			execution(1, "checks out", "passed"),
+			execution(2, "adds an item", "passed"),
+			execution(2, "empties", "failed"),
+			execution(3, "empties", "errored"),
+			execution(3, "checks out", "failed"),
+			execution(4, "pays", "failed"),
+		})
+
+		specRuns := testRun.SuiteRuns[0].SpecRuns
+		Expect(specRuns[0].Classification.Kind).To(Equal(failures.ClassificationNew))
+		Expect(specRuns[0].Classification.PreviousTestRunID).To(Equal(uint64(2)))
+		Expect(specRuns[1].Classification.Kind).To(Equal(failures.ClassificationStillFailing))
+		Expect(specRuns[1].Classification.FailingSinceTestRunID).To(Equal(uint64(2)))
+		Expect(specRuns[1].Classification.FailingSinceGitSha).To(Equal("c"))
+		Expect(*specRuns[1].Classification.FailingSince).To(Equal(start.Add(2 * time.Hour)))
+		Expect(specRuns[1].Classification.PreviousTestRunID).To(Equal(uint64(3)))
+		Expect(specRuns[2].Classification.Kind).To(Equal(failures.ClassificationFixed))
+		Expect(specRuns[2].Classification.PreviousTestRunID).To(Equal(uint64(3)))
+		Expect(specRuns[3].Classification).To(BeNil())
+		Expect(specRuns[4].Classification.Kind).To(Equal(failures.ClassificationNew))
+		Expect(specRuns[4].Classification.PreviousTestRunID).To(BeZero())
+	})
+
+	It("should only compare with earlier runs of the same branch", func() {
+		otherBranch := execution(2, "adds an item", "failed")
+		otherBranch.GitBranch = "feature"
+		sameRun := execution(5, "adds an item", "failed")
+		later := execution(6, "adds an item", "failed")
+
+		failures.ClassifyAgainst(&testRun, []flaky.Execution{execution(1, "adds an item", "passed"), otherBranch, sameRun, later})
+
+		Expect(testRun.SuiteRuns[0].SpecRuns[0].Classification.Kind).To(Equal(failures.ClassificationNew))
+		Expect(testRun.SuiteRuns[0].SpecRuns[0].Classification.PreviousTestRunID).To(Equal(uint64(1)))
+	})
+
+	It("should follow a renamed spec through its test case", func() {
+		testCaseID := uint64(7)
+		renamed := execution(4, "adds one item", "failed")
+		renamed.TestCaseID = &testCaseID
+		testRun.SuiteRuns[0].SpecRuns[0].TestCaseID = &testCaseID
+
+		failures.ClassifyAgainst(&testRun, []flaky.Execution{renamed})
+
+		Expect(testRun.SuiteRuns[0].SpecRuns[0].Classification.Kind).To(Equal(failures.ClassificationStillFailing))
+	})
+})
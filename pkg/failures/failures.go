@@ -0,0 +1,504 @@
+// Package failures groups failed spec runs by the likely root cause of their
+// failure.
+//
+// Failure messages are first normalized: UUIDs, timestamps, memory and network
+// addresses, temporary paths and numbers vary from one run to the next and are
+// replaced by placeholders. Spec runs with the same normalized message fall in
+// the same group, and groups whose messages share most of their words are
+// merged, so that one backend going down shows up as one group of many specs
+// rather than many separate failures.
+//
+// Spec runs are also classified against the previous runs of their project
+// branch: a failure is new or still failing since an earlier run, and a pass
+// after a failure is a fix.
+package failures
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultWindow is analyzed when a query has no start time.
+	DefaultWindow = 7 * 24 * time.Hour
+	// DefaultLimit is used when a query sets no limit.
+	DefaultLimit = 50
+	// MaxLimit is the largest limit a query may set.
+	MaxLimit = 1000
+
+	// Similarity is the share of words two normalized messages must have in
+	// common for their groups to be merged.
+	Similarity = 0.8
+
+	// MaxSpecFailures bounds the distinct messages of distinct specs that a
+	// query loads and clusters; the most frequent are kept.
+	MaxSpecFailures = 2000
+
+	// maxPatternLength bounds the normalized messages that are compared, in
+	// bytes. Long messages are told apart by their beginning.
+	maxPatternLength = 500
+)
+
+// replacement replaces the matches of a pattern with a placeholder. When
+// applies is set, only the matches it accepts are replaced.
+type replacement struct {
+	pattern     *regexp.Regexp
+	placeholder string
+	applies     func(match string) bool
+}
+
+// Replacements applied by Normalize, in order. Earlier patterns would
+// otherwise be broken up by the later ones.
+var replacements = []replacement{
+	{pattern: regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`), placeholder: "<uuid>"},
+	// Long hexadecimal words such as commit hashes and object IDs, but not
+	// plain numbers nor the words that only use the letters a to f
+	{pattern: regexp.MustCompile(`\b[0-9a-fA-F]{8,}\b`), placeholder: "<hex>", applies: isHexID},
+	{pattern: regexp.MustCompile(`\d{4}-\d{2}-\d{2}(?:[T ]\d{2}:\d{2}(?::\d{2}(?:[.,]\d+)?)?(?:Z|[+-]\d{2}:?\d{2})?)?|\b\d{2}:\d{2}:\d{2}(?:[.,]\d+)?`), placeholder: "<time>"},
+	{pattern: regexp.MustCompile(`(?i)(?:/private)?/var/folders/\S*|(?:/var)?/tmp/\S*|[a-z]:\\(?:[^\s\\]+\\)*te?mp\\\S*`), placeholder: "<path>"},
+	{pattern: regexp.MustCompile(`0x[0-9a-fA-F]+|\b\d{1,3}(?:\.\d{1,3}){3}(?::\d+)?\b|\[[0-9a-fA-F:]*:[0-9a-fA-F:]+\](?::\d+)?`), placeholder: "<addr>"},
+	{pattern: regexp.MustCompile(`\d+(?:[.,]\d+)*`), placeholder: "<n>"},
+}
+
+var (
+	whitespace = regexp.MustCompile(`\s+`)
+	words      = regexp.MustCompile(`<[a-z]+>|[\p{L}\p{N}_]+`)
+)
+
+func isHexID(word string) bool {
+	return strings.ContainsAny(word, "0123456789") && strings.ContainsAny(word, "abcdefABCDEF")
+}
+
+// Normalize replaces the parts of a failure message that vary between runs
+// with placeholders and collapses whitespace.
+func Normalize(message string) string {
+	for _, r := range replacements {
+		message = r.pattern.ReplaceAllStringFunc(message, func(match string) string {
+			if r.applies != nil && !r.applies(match) {
+				return match
+			}
+			return r.placeholder
+		})
+	}
+	message = strings.TrimSpace(whitespace.ReplaceAllString(message, " "))
+	if len(message) > maxPatternLength {
+		cut := maxPatternLength
+		for cut > 0 && !utf8.RuneStart(message[cut]) {
+			cut--
+		}
+		message = message[:cut]
+	}
+	return message
+}
+
+// Failure is a failed spec run.
+type Failure struct {
+	TestRunID       uint64
+	SpecRunID       uint64
+	TestCaseID      *uint64
+	SuiteName       string
+	SpecDescription string
+	Message         string
+	// Time is when the spec run started, or its test run when it has no
+	// start time.
+	Time time.Time
+}
+
+// SpecFailures are the failures of one spec with the same message.
+type SpecFailures struct {
+	TestCaseID      *uint64
+	SuiteName       string
+	SpecDescription string
+	Message         string
+	Count           int
+	FirstSeen       time.Time
+	LastSeen        time.Time
+	// LastTestRunID and LastSpecRunID identify the latest failure.
+	LastTestRunID uint64
+	LastSpecRunID uint64
+	// TestRunIDs are the distinct test runs of the failures.
+	TestRunIDs []uint64
+}
+
+// specFailuresOf turns each failure into failures of its own.
+func specFailuresOf(failures []Failure) []SpecFailures {
+	specFailures := make([]SpecFailures, len(failures))
+	for i, failure := range failures {
+		specFailures[i] = SpecFailures{
+			TestCaseID:      failure.TestCaseID,
+			SuiteName:       failure.SuiteName,
+			SpecDescription: failure.SpecDescription,
+			Message:         failure.Message,
+			Count:           1,
+			FirstSeen:       failure.Time,
+			LastSeen:        failure.Time,
+			LastTestRunID:   failure.TestRunID,
+			LastSpecRunID:   failure.SpecRunID,
+			TestRunIDs:      []uint64{failure.TestRunID},
+		}
+	}
+	return specFailures
+}
+
+// FromTestRun returns the failed spec runs of a test run.
+func FromTestRun(testRun models.TestRun) []Failure {
+	var failures []Failure
+	for _, suiteRun := range testRun.SuiteRuns {
+		for _, specRun := range suiteRun.SpecRuns {
+			if !utils.IsFailedStatus(specRun.Status) {
+				continue
+			}
+			failure := Failure{
+				TestRunID:       testRun.ID,
+				SpecRunID:       specRun.ID,
+				TestCaseID:      specRun.TestCaseID,
+				SuiteName:       suiteRun.SuiteName,
+				SpecDescription: specRun.SpecDescription,
+				Message:         specRun.Message,
+				Time:            specRun.StartTime,
+			}
+			if failure.Message == "" && specRun.Failure != nil {
+				failure.Message = specRun.Failure.Message
+			}
+			if failure.Time.IsZero() {
+				failure.Time = testRun.StartTime
+			}
+			failures = append(failures, failure)
+		}
+	}
+	return failures
+}
+
+// ForTestRuns groups the failed spec runs of each test run, keyed by test run
+// ID. Runs without failures are left out.
+func ForTestRuns(testRuns []models.TestRun) map[uint64][]models.FailureGroup {
+	groups := map[uint64][]models.FailureGroup{}
+	for _, testRun := range testRuns {
+		if runGroups := Group(FromTestRun(testRun)); len(runGroups) > 0 {
+			groups[testRun.ID] = runGroups
+		}
+	}
+	return groups
+}
+
+// Query selects the failures of a project to group.
+type Query struct {
+	Project string
+	// Branch narrows the failures down to one branch; empty groups all.
+	Branch string
+	Start  time.Time
+	End    time.Time
+	// Limit bounds the number of groups returned.
+	Limit int
+}
+
+// WithDefaults fills the zero values of the query with the defaults, ending
+// the window at now.
+func (q Query) WithDefaults(now time.Time) Query {
+	if q.End.IsZero() {
+		q.End = now
+	}
+	if q.Start.IsZero() {
+		q.Start = q.End.Add(-DefaultWindow)
+	}
+	if q.Limit <= 0 {
+		q.Limit = DefaultLimit
+	}
+	return q
+}
+
+// specFailuresRow is the failures of a spec with the same message as loaded
+// by Load. The IDs of their test runs are joined by commas.
+type specFailuresRow struct {
+	TestCaseID      *uint64
+	SuiteName       string
+	SpecDescription string
+	Message         string
+	Count           int
+	FirstSeen       time.Time
+	LastSeen        time.Time
+	LastTestRunID   uint64
+	LastSpecRunID   uint64
+	TestRunIDs      string
+	// Failure is the structured failure of the spec runs without a message.
+	Failure *models.Failure
+}
+
+// failureTime is when a spec run started, or its test run when it has no
+// start time.
+const failureTime = "GREATEST(spec_runs.start_time, test_runs.start_time)"
+
+// structuredFailure is the structured failure of a spec run without a
+// message, whose message FromTestRun falls back to. Failures are compressed,
+// so their message is only read once loaded.
+const structuredFailure = "CASE WHEN spec_runs.message = '' THEN spec_runs.failure END"
+
+// Load loads the failed spec runs of the live test runs matching the query,
+// counted per spec and message in SQL. Spec runs without a message are
+// counted per structured failure and take its message, like in FromTestRun.
+// Only the MaxSpecFailures most frequent specs and messages of the whole
+// query are loaded.
+func Load(db *gorm.DB, query Query) ([]SpecFailures, error) {
+	var rows []specFailuresRow
+	latest := "ORDER BY test_runs.start_time DESC, spec_runs.id DESC"
+	tx := db.Table("spec_runs").
+		Joins("INNER JOIN suite_runs ON suite_runs.id = spec_runs.suite_id").
+		Joins("INNER JOIN test_runs ON test_runs.id = suite_runs.test_run_id").
+		Select("spec_runs.test_case_id, suite_runs.suite_name, spec_runs.spec_description, spec_runs.message, "+
+			structuredFailure+" AS failure, COUNT(*) AS count, MIN("+failureTime+") AS first_seen, MAX("+failureTime+") AS last_seen, "+
+			"(array_agg(test_runs.id "+latest+"))[1] AS last_test_run_id, "+
+			"(array_agg(spec_runs.id "+latest+"))[1] AS last_spec_run_id, "+
+			"string_agg(DISTINCT test_runs.id::text, ',') AS test_run_ids").
+		Where("test_runs.test_project_name = ?", query.Project).
+		Where("test_runs.start_time >= ? AND test_runs.start_time <= ?", query.Start, query.End).
+		Where("test_runs.deleted_at IS NULL").
+		Where("spec_runs.status IN ?", []string{utils.StatusFailed, utils.StatusErrored})
+	if query.Branch != "" {
+		tx = tx.Where("test_runs.git_branch = ?", query.Branch)
+	}
+	err := tx.Group("spec_runs.test_case_id, suite_runs.suite_name, spec_runs.spec_description, spec_runs.message, " + structuredFailure).
+		Order("count DESC, last_seen DESC").
+		Limit(MaxSpecFailures).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	specFailures := make([]SpecFailures, len(rows))
+	for i, row := range rows {
+		specFailures[i] = SpecFailures{
+			TestCaseID:      row.TestCaseID,
+			SuiteName:       row.SuiteName,
+			SpecDescription: row.SpecDescription,
+			Message:         row.Message,
+			Count:           row.Count,
+			FirstSeen:       row.FirstSeen,
+			LastSeen:        row.LastSeen,
+			LastTestRunID:   row.LastTestRunID,
+			LastSpecRunID:   row.LastSpecRunID,
+		}
+		if row.Message == "" && row.Failure != nil {
+			specFailures[i].Message = row.Failure.Message
+		}
+		for _, id := range strings.Split(row.TestRunIDs, ",") {
+			testRunID, err := strconv.ParseUint(id, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			specFailures[i].TestRunIDs = append(specFailures[i].TestRunIDs, testRunID)
+		}
+	}
+	return specFailures, nil
+}
+
+// Detect groups the failures of a project, largest group first. Zero values
+// of the query fall back to the defaults, ending now.
+func Detect(db *gorm.DB, query Query, now time.Time) ([]models.FailureGroup, error) {
+	query = query.WithDefaults(now)
+	specFailures, err := Load(db, query)
+	if err != nil {
+		return nil, err
+	}
+	groups := GroupSpecFailures(specFailures)
+	if len(groups) > query.Limit {
+		groups = groups[:query.Limit]
+	}
+	return groups, nil
+}
+
+// cluster is a group of normalized messages being built.
+type cluster struct {
+	words map[string]bool
+	group *models.FailureGroup
+	specs map[specKey]int
+	// specLastSeen is when each spec last failed with the messages so far.
+	specLastSeen map[specKey]time.Time
+	runs         map[uint64]bool
+}
+
+// specKey identifies a spec. Specs are told apart by their test case, or by
+// their names when they have none.
+type specKey struct {
+	testCaseID uint64
+	suite      string
+	spec       string
+}
+
+func keyOf(testCaseID *uint64, suiteName, specDescription string) specKey {
+	if testCaseID != nil {
+		return specKey{testCaseID: *testCaseID}
+	}
+	return specKey{suite: suiteName, spec: specDescription}
+}
+
+// Group clusters the failures by their normalized message and returns the
+// groups, largest first. The specs of each group come most failed first.
+func Group(failures []Failure) []models.FailureGroup {
+	return GroupSpecFailures(specFailuresOf(failures))
+}
+
+// GroupSpecFailures clusters the failures of specs by their normalized
+// message like Group. Each message is normalized once, however often it
+// failed.
+func GroupSpecFailures(specFailures []SpecFailures) []models.FailureGroup {
+	patterns := make([]string, len(specFailures))
+	counts := map[string]int{}
+	for i, failures := range specFailures {
+		patterns[i] = Normalize(failures.Message)
+		counts[patterns[i]] += failures.Count
+	}
+
+	// The most frequent messages seed the clusters that similar, rarer
+	// messages are merged into.
+	distinct := make([]string, 0, len(counts))
+	for pattern := range counts {
+		distinct = append(distinct, pattern)
+	}
+	sort.Slice(distinct, func(i, j int) bool {
+		if counts[distinct[i]] != counts[distinct[j]] {
+			return counts[distinct[i]] > counts[distinct[j]]
+		}
+		return distinct[i] < distinct[j]
+	})
+	var clusters []*cluster
+	byPattern := map[string]*cluster{}
+	for _, pattern := range distinct {
+		patternWords := wordsOf(pattern)
+		var best *cluster
+		bestSimilarity := Similarity
+		for _, c := range clusters {
+			if !similarSize(len(patternWords), len(c.words)) {
+				continue
+			}
+			if similarity := jaccard(patternWords, c.words); similarity >= bestSimilarity {
+				best, bestSimilarity = c, similarity
+				if similarity == 1 {
+					break
+				}
+			}
+		}
+		if best == nil {
+			best = &cluster{
+				words:        patternWords,
+				group:        &models.FailureGroup{Fingerprint: fingerprint(pattern), Pattern: pattern, Specs: []models.FailureGroupSpec{}},
+				specs:        map[specKey]int{},
+				specLastSeen: map[specKey]time.Time{},
+				runs:         map[uint64]bool{},
+			}
+			clusters = append(clusters, best)
+		}
+		byPattern[pattern] = best
+	}
+
+	for i, failures := range specFailures {
+		c := byPattern[patterns[i]]
+		group := c.group
+		group.Count += failures.Count
+		if group.FirstSeen.IsZero() || failures.FirstSeen.Before(group.FirstSeen) {
+			group.FirstSeen = failures.FirstSeen
+		}
+		if !failures.LastSeen.Before(group.LastSeen) {
+			group.LastSeen = failures.LastSeen
+			group.Message = failures.Message
+		}
+		for _, testRunID := range failures.TestRunIDs {
+			if !c.runs[testRunID] {
+				c.runs[testRunID] = true
+				group.TestRuns++
+			}
+		}
+
+		key := keyOf(failures.TestCaseID, failures.SuiteName, failures.SpecDescription)
+		index, ok := c.specs[key]
+		if !ok {
+			index = len(group.Specs)
+			c.specs[key] = index
+			group.Specs = append(group.Specs, models.FailureGroupSpec{
+				TestCaseID:      failures.TestCaseID,
+				SuiteName:       failures.SuiteName,
+				SpecDescription: failures.SpecDescription,
+			})
+		}
+		spec := &group.Specs[index]
+		spec.Count += failures.Count
+		if !failures.LastSeen.Before(c.specLastSeen[key]) {
+			c.specLastSeen[key] = failures.LastSeen
+			spec.LastTestRunID = failures.LastTestRunID
+			spec.LastSpecRunID = failures.LastSpecRunID
+		}
+	}
+
+	groups := make([]models.FailureGroup, len(clusters))
+	for i, c := range clusters {
+		specs := c.group.Specs
+		sort.SliceStable(specs, func(i, j int) bool {
+			if specs[i].Count != specs[j].Count {
+				return specs[i].Count > specs[j].Count
+			}
+			if specs[i].SuiteName != specs[j].SuiteName {
+				return specs[i].SuiteName < specs[j].SuiteName
+			}
+			return specs[i].SpecDescription < specs[j].SpecDescription
+		})
+		groups[i] = *c.group
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		if len(groups[i].Specs) != len(groups[j].Specs) {
+			return len(groups[i].Specs) > len(groups[j].Specs)
+		}
+		return groups[i].LastSeen.After(groups[j].LastSeen)
+	})
+	return groups
+}
+
+// similarSize tells whether two sets of words of the given sizes can be
+// similar enough to be merged, without comparing their words.
+func similarSize(a, b int) bool {
+	if a > b {
+		a, b = b, a
+	}
+	return b == 0 || float64(a) >= Similarity*float64(b)
+}
+
+func wordsOf(pattern string) map[string]bool {
+	set := map[string]bool{}
+	for _, word := range words.FindAllString(strings.ToLower(pattern), -1) {
+		set[word] = true
+	}
+	return set
+}
+
+// jaccard measures the similarity of two sets of words, from 0 (disjoint) to
+// 1 (equal). Two empty sets are equal.
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	shared := 0
+	for word := range a {
+		if b[word] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// fingerprint identifies a group across requests by the normalized message
+// that seeded it.
+func fingerprint(pattern string) string {
+	sum := sha256.Sum256([]byte(pattern))
+	return hex.EncodeToString(sum[:8])
+}
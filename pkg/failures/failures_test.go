@@ -0,0 +1,156 @@
+package failures_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/pkg/failures"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Normalize", func() {
+	DescribeTable("should replace the values that vary between runs",
+		func(message, expected string) {
+			Expect(failures.Normalize(message)).To(Equal(expected))
+		},
+		Entry("numbers", "Expected 3 to equal 4.5", "Expected <n> to equal <n>"),
+		Entry("UUIDs", "order 1b4e28ba-2fa1-11d2-883f-0016d3cca427 not found", "order <uuid> not found"),
+		Entry("timestamps", "deadline 2024-04-20T12:00:00.123Z passed at 12:00:01", "deadline <time> passed at <time>"),
+		Entry("network addresses", `Get "http://10.0.0.5:8080/users": dial tcp 10.0.0.5:8080: connect: connection refused`,
+			`Get "http://<addr>/users": dial tcp <addr>: connect: connection refused`),
+		Entry("memory addresses", "nil pointer dereference at 0xc000123abc", "nil pointer dereference at <addr>"),
+		Entry("hexadecimal IDs", "commit 9fceb02d0ae598e95dc970b74767f19372d61af8 missing", "commit <hex> missing"),
+		Entry("temporary paths", "open /tmp/ginkgo123/config.yaml: no such file", "open <path> no such file"),
+		Entry("Windows temporary paths", `open C:\Users\ci\AppData\Local\Temp\run42\out.txt failed`, "open <path> failed"),
+		Entry("whitespace", "  timed out\n\twaiting  ", "timed out waiting"),
+	)
+
+	It("should leave words alone", func() {
+		Expect(failures.Normalize("decade accepted")).To(Equal("decade accepted"))
+	})
+})
+
+var _ = Describe("Group", func() {
+	start := time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+	failure := func(run, spec uint64, message string) failures.Failure {
+		return failures.Failure{
+			TestRunID:       run,
+			SpecRunID:       run*100 + spec,
+			SuiteName:       "Cart",
+			SpecDescription: "spec " + string(rune('a'+spec)),
+			Message:         message,
+			Time:            start.Add(time.Duration(run) * time.Hour),
+		}
+	}
+
+	It("should group failures by their normalized message, largest group first", func() {
+		groups := failures.Group([]failures.Failure{
+			failure(1, 1, "Expected 1 to equal 2"),
+			failure(1, 2, `Get "http://10.0.0.5:8080/users/1": connection refused`),
+			failure(1, 3, `Get "http://10.0.0.5:8080/users/2": connection refused`),
+			failure(2, 2, `Get "http://10.0.0.6:8080/users/3": connection refused`),
+		})
+
+		Expect(groups).To(HaveLen(2))
+		Expect(groups[0].Pattern).To(Equal(`Get "http://<addr>/users/<n>": connection refused`))
+		Expect(groups[0].Count).To(Equal(3))
+		Expect(groups[0].TestRuns).To(Equal(2))
+		Expect(groups[0].FirstSeen).To(Equal(start.Add(time.Hour)))
+		Expect(groups[0].LastSeen).To(Equal(start.Add(2 * time.Hour)))
+		Expect(groups[0].Message).To(Equal(`Get "http://10.0.0.6:8080/users/3": connection refused`))
+		Expect(groups[0].Specs).To(HaveLen(2))
+		Expect(groups[0].Specs[0].SpecDescription).To(Equal("spec c"))
+		Expect(groups[0].Specs[0].Count).To(Equal(2))
+		Expect(groups[0].Specs[0].LastSpecRunID).To(Equal(uint64(202)))
+		Expect(groups[1].Pattern).To(Equal("Expected <n> to equal <n>"))
+		Expect(groups[1].Fingerprint).To(HaveLen(16))
+	})
+
+	It("should merge messages that share most of their words", func() {
+		groups := failures.Group([]failures.Failure{
+			failure(1, 1, `Get "http://10.0.0.5:8080/api/users/1": dial tcp: connect: connection refused`),
+			failure(1, 2, `Get "http://10.0.0.5:8080/api/users/2": dial tcp: connect: connection refused`),
+			failure(1, 3, `Get "http://10.0.0.5:8080/api/orders/3": dial tcp: connect: connection refused`),
+		})
+
+		Expect(groups).To(HaveLen(1))
+		Expect(groups[0].Pattern).To(ContainSubstring("/api/users/"))
+		Expect(groups[0].Count).To(Equal(3))
+		Expect(groups[0].Specs).To(HaveLen(3))
+	})
+
+	It("should keep different assertions apart", func() {
+		groups := failures.Group([]failures.Failure{
+			failure(1, 1, "Expected true to be false"),
+			failure(1, 2, "Expected <nil> not to be nil"),
+		})
+
+		Expect(groups).To(HaveLen(2))
+	})
+
+	It("should tell specs apart by their test case", func() {
+		testCaseID := uint64(7)
+		renamed := failure(2, 2, "timed out")
+		renamed.TestCaseID = &testCaseID
+		original := failure(1, 1, "timed out")
+		original.TestCaseID = &testCaseID
+
+		groups := failures.Group([]failures.Failure{original, renamed})
+
+		Expect(groups).To(HaveLen(1))
+		Expect(groups[0].Specs).To(HaveLen(1))
+		Expect(groups[0].Specs[0].Count).To(Equal(2))
+		Expect(groups[0].Specs[0].LastTestRunID).To(Equal(uint64(2)))
+	})
+})
+
+var _ = Describe("GroupSpecFailures", func() {
+	start := time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+
+	It("should add up the failures counted per spec and message", func() {
+		groups := failures.GroupSpecFailures([]failures.SpecFailures{
+			{SuiteName: "Cart", SpecDescription: "adds an item", Message: "connect to 10.0.0.5:5432 refused", Count: 3,
+				FirstSeen: start, LastSeen: start.Add(2 * time.Hour), LastTestRunID: 3, LastSpecRunID: 30, TestRunIDs: []uint64{1, 2, 3}},
+			{SuiteName: "Cart", SpecDescription: "adds an item", Message: "connect to 10.0.0.6:5432 refused", Count: 1,
+				FirstSeen: start.Add(time.Hour), LastSeen: start.Add(time.Hour), LastTestRunID: 2, LastSpecRunID: 20, TestRunIDs: []uint64{2}},
+			{SuiteName: "Cart", SpecDescription: "empties", Message: "connect to 10.0.0.6:5432 refused", Count: 2,
+				FirstSeen: start.Add(time.Hour), LastSeen: start.Add(3 * time.Hour), LastTestRunID: 4, LastSpecRunID: 41, TestRunIDs: []uint64{2, 4}},
+		})
+
+		Expect(groups).To(HaveLen(1))
+		Expect(groups[0].Count).To(Equal(6))
+		Expect(groups[0].TestRuns).To(Equal(4))
+		Expect(groups[0].FirstSeen).To(Equal(start))
+		Expect(groups[0].LastSeen).To(Equal(start.Add(3 * time.Hour)))
+		Expect(groups[0].Specs).To(HaveLen(2))
+		Expect(groups[0].Specs[0].SpecDescription).To(Equal("adds an item"))
+		Expect(groups[0].Specs[0].Count).To(Equal(4))
+		Expect(groups[0].Specs[0].LastSpecRunID).To(Equal(uint64(30)))
+		Expect(groups[0].Specs[1].LastSpecRunID).To(Equal(uint64(41)))
+	})
+})
+
+var _ = Describe("ForTestRuns", func() {
+	It("should group the failed spec runs of each run", func() {
+		runStart := time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+		testRuns := []models.TestRun{
+			{ID: 1, StartTime: runStart, SuiteRuns: []models.SuiteRun{{SuiteName: "Cart", SpecRuns: []models.SpecRun{
+				{ID: 11, SpecDescription: "adds an item", Status: "failed", Message: "Expected 1 to equal 2"},
+				{ID: 12, SpecDescription: "removes an item", Status: "errored", Failure: &models.Failure{Message: "panic: boom"}},
+				{ID: 13, SpecDescription: "empties the cart", Status: "passed", Message: "ok"},
+			}}}},
+			{ID: 2, SuiteRuns: []models.SuiteRun{{SuiteName: "Cart", SpecRuns: []models.SpecRun{
+				{ID: 21, SpecDescription: "adds an item", Status: "passed"},
+			}}}},
+		}
+
+		groups := failures.ForTestRuns(testRuns)
+
+		Expect(groups).To(HaveLen(1))
+		Expect(groups[1]).To(HaveLen(2))
+		Expect(groups[1][0].FirstSeen).To(Equal(runStart))
+		Expect([]string{groups[1][0].Pattern, groups[1][1].Pattern}).To(ConsistOf("Expected <n> to equal <n>", "panic: boom"))
+	})
+})
@@ -0,0 +1,311 @@
+// Package flaky finds the flaky specs of a project from their status history.
+//
+// The executions of every spec are analyzed per branch, oldest first, for
+// three signals: commits on which the spec both passed and failed, passes
+// that needed a retry, and how often the outcome alternates between
+// consecutive executions. A single change of outcome is a regression or a
+// fix rather than flakiness, so a spec is only reported with a commit flip, a
+// retried pass or at least two alternations.
+package flaky
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// Reasons why an execution is evidence of flakiness, strongest first.
+const (
+	ReasonCommitFlip  = "commit_flip"
+	ReasonRetried     = "retried"
+	ReasonAlternation = "alternation"
+)
+
+const (
+	// DefaultWindow is analyzed when a query has no start time.
+	DefaultWindow = 30 * 24 * time.Hour
+	// DefaultMinExecutions is used when a query sets no minimum.
+	DefaultMinExecutions = 3
+	// DefaultLimit is used when a query sets no limit.
+	DefaultLimit = 50
+	// MaxLimit is the largest limit a query may set.
+	MaxLimit = 1000
+
+	// MaxExecutions bounds the executions of each spec and branch that are
+	// analyzed; the latest are kept.
+	MaxExecutions = 200
+
+	// maxEvidence bounds the evidence listed for each spec.
+	maxEvidence = 10
+
+	// Weights of the signals in the score, adding up to 1.
+	commitFlipWeight  = 0.4
+	retryWeight       = 0.3
+	alternationWeight = 0.3
+)
+
+var reasonRanks = map[string]int{
+	ReasonCommitFlip:  3,
+	ReasonRetried:     2,
+	ReasonAlternation: 1,
+}
+
+// Query selects the executions to analyze.
+type Query struct {
+	Project string
+	// Branch narrows the analysis down to one branch; empty analyzes all.
+	Branch string
+	Start  time.Time
+	End    time.Time
+	// MinExecutions skips the specs that ran fewer times on a branch.
+	MinExecutions int
+	// Limit bounds the number of specs returned.
+	Limit int
+}
+
+// Execution is one execution of a spec.
+type Execution struct {
+	TestCaseID      *uint64
+	TestRunID       uint64
+	SpecRunID       uint64
+	SuiteName       string
+	SpecDescription string
+	Status          string
+	StartTime       time.Time
+	GitBranch       string
+	GitSha          string
+}
+
+// Detect ranks the flaky specs of a project, most flaky first. Zero values of
+// the query fall back to the defaults, ending now.
+func Detect(db *gorm.DB, query Query, now time.Time) ([]models.FlakySpec, error) {
+	query = query.WithDefaults(now)
+	executions, err := LoadExecutions(db, query)
+	if err != nil {
+		return nil, err
+	}
+	flakySpecs := Analyze(executions, query.MinExecutions)
+	if len(flakySpecs) > query.Limit {
+		flakySpecs = flakySpecs[:query.Limit]
+	}
+	return flakySpecs, nil
+}
+
+// WithDefaults fills the zero values of the query with the defaults, ending
+// the window at now.
+func (q Query) WithDefaults(now time.Time) Query {
+	if q.End.IsZero() {
+		q.End = now
+	}
+	if q.Start.IsZero() {
+		q.Start = q.End.Add(-DefaultWindow)
+	}
+	if q.MinExecutions <= 0 {
+		q.MinExecutions = DefaultMinExecutions
+	}
+	if q.Limit <= 0 {
+		q.Limit = DefaultLimit
+	}
+	return q
+}
+
+// bySpec partitions executions per spec and branch. Specs are told apart by
+// their test case, or by their names when they have none.
+const bySpec = "PARTITION BY test_runs.git_branch, spec_runs.test_case_id, " +
+	"CASE WHEN spec_runs.test_case_id IS NULL THEN suite_runs.suite_name END, " +
+	"CASE WHEN spec_runs.test_case_id IS NULL THEN spec_runs.spec_description END"
+
+// LoadExecutions loads the executed spec runs of the live test runs matching
+// the query, oldest first. Only the specs that ran at least MinExecutions
+// times on a branch and failed or needed a retry at least once can be flaky,
+// so only theirs are loaded, and at most their latest MaxExecutions.
+func LoadExecutions(db *gorm.DB, query Query) ([]Execution, error) {
+	tx := db.Table("spec_runs").
+		Joins("INNER JOIN suite_runs ON suite_runs.id = spec_runs.suite_id").
+		Joins("INNER JOIN test_runs ON test_runs.id = suite_runs.test_run_id").
+		Select("spec_runs.test_case_id, test_runs.id AS test_run_id, spec_runs.id AS spec_run_id, suite_runs.suite_name, "+
+			"spec_runs.spec_description, spec_runs.status, test_runs.start_time, test_runs.git_branch, test_runs.git_sha, "+
+			"ROW_NUMBER() OVER ("+bySpec+" ORDER BY test_runs.start_time DESC, spec_runs.id DESC) AS recency, "+
+			"COUNT(*) OVER ("+bySpec+") AS executions, "+
+			"COUNT(*) FILTER (WHERE spec_runs.status IN ('failed','errored','flaky')) OVER ("+bySpec+") AS unstable").
+		Where("test_runs.test_project_name = ?", query.Project).
+		Where("test_runs.start_time >= ? AND test_runs.start_time <= ?", query.Start, query.End).
+		Where("test_runs.deleted_at IS NULL").
+		Where("spec_runs.status IN ?", []string{utils.StatusPassed, utils.StatusFlaky, utils.StatusFailed, utils.StatusErrored})
+	if query.Branch != "" {
+		tx = tx.Where("test_runs.git_branch = ?", query.Branch)
+	}
+
+	executions := []Execution{}
+	err := db.Table("(?) AS executions", tx).
+		Select("test_case_id, test_run_id, spec_run_id, suite_name, spec_description, status, start_time, git_branch, git_sha").
+		Where("recency <= ? AND executions >= ? AND unstable > 0", MaxExecutions, query.MinExecutions).
+		Order("start_time, spec_run_id").
+		Scan(&executions).Error
+	return executions, err
+}
+
+// specKey identifies a spec on a branch. Specs are told apart by their test
+// case, or by their names when they have none.
+type specKey struct {
+	branch     string
+	testCaseID uint64
+	suite      string
+	spec       string
+}
+
+func keyOf(execution Execution) specKey {
+	if execution.TestCaseID != nil {
+		return specKey{branch: execution.GitBranch, testCaseID: *execution.TestCaseID}
+	}
+	return specKey{branch: execution.GitBranch, suite: execution.SuiteName, spec: execution.SpecDescription}
+}
+
+// Analyze scores the executions of every spec with at least minExecutions of
+// them and returns the flaky ones, most flaky first.
+func Analyze(executions []Execution, minExecutions int) []models.FlakySpec {
+	var keys []specKey
+	bySpec := map[specKey][]Execution{}
+	for _, execution := range executions {
+		key := keyOf(execution)
+		if _, ok := bySpec[key]; !ok {
+			keys = append(keys, key)
+		}
+		bySpec[key] = append(bySpec[key], execution)
+	}
+
+	flakySpecs := []models.FlakySpec{}
+	for _, key := range keys {
+		specExecutions := bySpec[key]
+		if len(specExecutions) < minExecutions {
+			continue
+		}
+		if flakySpec, ok := score(specExecutions); ok {
+			flakySpecs = append(flakySpecs, flakySpec)
+		}
+	}
+
+	sort.SliceStable(flakySpecs, func(i, j int) bool {
+		a, b := flakySpecs[i], flakySpecs[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Executions != b.Executions {
+			return a.Executions > b.Executions
+		}
+		if a.SuiteName != b.SuiteName {
+			return a.SuiteName < b.SuiteName
+		}
+		if a.SpecDescription != b.SpecDescription {
+			return a.SpecDescription < b.SpecDescription
+		}
+		return a.GitBranch < b.GitBranch
+	})
+	return flakySpecs
+}
+
+// score measures the flakiness of the executions of one spec. It reports
+// false when they show none.
+func score(executions []Execution) (models.FlakySpec, bool) {
+	sort.SliceStable(executions, func(i, j int) bool {
+		return executions[i].StartTime.Before(executions[j].StartTime)
+	})
+	latest := executions[len(executions)-1]
+	flakySpec := models.FlakySpec{
+		TestCaseID:      latest.TestCaseID,
+		SuiteName:       latest.SuiteName,
+		SpecDescription: latest.SpecDescription,
+		GitBranch:       latest.GitBranch,
+		Executions:      len(executions),
+	}
+
+	reasons := make([]string, len(executions))
+	mark := func(i int, reason string) {
+		if reasonRanks[reason] > reasonRanks[reasons[i]] {
+			reasons[i] = reason
+		}
+	}
+
+	var commits []string
+	byCommit := map[string][]int{}
+	for i, execution := range executions {
+		failed := utils.IsFailedStatus(execution.Status)
+		if failed {
+			flakySpec.Failures++
+		}
+		if execution.Status == utils.StatusFlaky {
+			flakySpec.RetriedPasses++
+			mark(i, ReasonRetried)
+		}
+		if i > 0 && failed != utils.IsFailedStatus(executions[i-1].Status) {
+			flakySpec.Alternations++
+			mark(i-1, ReasonAlternation)
+			mark(i, ReasonAlternation)
+		}
+		if execution.GitSha != "" {
+			if _, ok := byCommit[execution.GitSha]; !ok {
+				commits = append(commits, execution.GitSha)
+			}
+			byCommit[execution.GitSha] = append(byCommit[execution.GitSha], i)
+		}
+	}
+
+	for _, commit := range commits {
+		passed, failed := false, false
+		for _, i := range byCommit[commit] {
+			if utils.IsFailedStatus(executions[i].Status) {
+				failed = true
+			} else {
+				passed = true
+			}
+		}
+		if passed && failed {
+			flakySpec.CommitFlips++
+			for _, i := range byCommit[commit] {
+				mark(i, ReasonCommitFlip)
+			}
+		}
+	}
+
+	if flakySpec.CommitFlips == 0 && flakySpec.RetriedPasses == 0 && flakySpec.Alternations < 2 {
+		return flakySpec, false
+	}
+
+	var commitFlipRate float64
+	if len(commits) > 0 {
+		commitFlipRate = float64(flakySpec.CommitFlips) / float64(len(commits))
+	}
+	retryRate := float64(flakySpec.RetriedPasses) / float64(len(executions))
+	if len(executions) > 1 {
+		flakySpec.AlternationRate = round(float64(flakySpec.Alternations) / float64(len(executions)-1))
+	}
+	flakySpec.Score = round(commitFlipWeight*commitFlipRate + retryWeight*retryRate + alternationWeight*flakySpec.AlternationRate)
+
+	flakySpec.Evidence = []models.FlakyEvidence{}
+	for i := len(executions) - 1; i >= 0 && len(flakySpec.Evidence) < maxEvidence; i-- {
+		if reasons[i] == "" {
+			continue
+		}
+		execution := executions[i]
+		flakySpec.Evidence = append(flakySpec.Evidence, models.FlakyEvidence{
+			TestRunID: execution.TestRunID,
+			SpecRunID: execution.SpecRunID,
+			Status:    execution.Status,
+			GitSha:    execution.GitSha,
+			StartTime: execution.StartTime,
+			Reason:    reasons[i],
+			URL:       fmt.Sprintf("/reports/testruns/%d", execution.TestRunID),
+		})
+	}
+	return flakySpec, true
+}
+
+// round rounds a rate to three decimals.
+func round(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
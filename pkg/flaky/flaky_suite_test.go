@@ -0,0 +1,13 @@
+package flaky_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestFlaky(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Flaky Suite")
+}
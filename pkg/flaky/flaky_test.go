@@ -0,0 +1,116 @@
+package flaky_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/pkg/flaky"
+)
+
+var _ = Describe("Analyze", func() {
+	start := time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+	execution := func(run uint64, status, sha string) flaky.Execution {
+		return flaky.Execution{
+			TestRunID:       run,
+			SpecRunID:       run * 10,
+			SuiteName:       "Cart",
+			SpecDescription: "adds an item",
+			Status:          status,
+			StartTime:       start.Add(time.Duration(run) * time.Hour),
+			GitBranch:       "main",
+			GitSha:          sha,
+		}
+	}
+
+	It("should score a spec that passed and failed on the same commit", func() {
+		flakySpecs := flaky.Analyze([]flaky.Execution{
+			execution(1, "passed", "aaa"),
+			execution(2, "failed", "aaa"),
+			execution(3, "passed", "bbb"),
+		}, 3)
+
+		Expect(flakySpecs).To(HaveLen(1))
+		Expect(flakySpecs[0].CommitFlips).To(Equal(1))
+		Expect(flakySpecs[0].Alternations).To(Equal(2))
+		Expect(flakySpecs[0].AlternationRate).To(Equal(1.0))
+		Expect(flakySpecs[0].Score).To(Equal(0.5))
+		evidence := flakySpecs[0].Evidence
+		Expect(evidence).To(HaveLen(3))
+		Expect(evidence[0].TestRunID).To(Equal(uint64(3)))
+		Expect(evidence[0].Reason).To(Equal(flaky.ReasonAlternation))
+		Expect(evidence[1].Reason).To(Equal(flaky.ReasonCommitFlip))
+		Expect(evidence[1].URL).To(Equal("/reports/testruns/2"))
+	})
+
+	It("should score passes that needed a retry", func() {
+		flakySpecs := flaky.Analyze([]flaky.Execution{
+			execution(1, "passed", "aaa"),
+			execution(2, "flaky", "bbb"),
+			execution(3, "passed", "ccc"),
+		}, 3)
+
+		Expect(flakySpecs).To(HaveLen(1))
+		Expect(flakySpecs[0].RetriedPasses).To(Equal(1))
+		Expect(flakySpecs[0].Alternations).To(BeZero())
+		Expect(flakySpecs[0].Score).To(Equal(0.1))
+		Expect(flakySpecs[0].Evidence).To(HaveLen(1))
+		Expect(flakySpecs[0].Evidence[0].Reason).To(Equal(flaky.ReasonRetried))
+	})
+
+	It("should not report a spec that broke once and stayed broken", func() {
+		Expect(flaky.Analyze([]flaky.Execution{
+			execution(1, "passed", "aaa"),
+			execution(2, "passed", "bbb"),
+			execution(3, "failed", "ccc"),
+			execution(4, "errored", "ddd"),
+		}, 3)).To(BeEmpty())
+	})
+
+	It("should skip specs with too few executions", func() {
+		Expect(flaky.Analyze([]flaky.Execution{
+			execution(1, "passed", "aaa"),
+			execution(2, "failed", "aaa"),
+		}, 3)).To(BeEmpty())
+	})
+
+	It("should analyze each branch on its own and rank the flakiest first", func() {
+		executions := []flaky.Execution{
+			execution(1, "passed", "aaa"),
+			execution(2, "flaky", "bbb"),
+			execution(3, "passed", "ccc"),
+		}
+		for run, status := range []string{"passed", "failed", "passed", "failed"} {
+			branched := execution(uint64(run+4), status, "eee")
+			branched.GitBranch = "release"
+			executions = append(executions, branched)
+		}
+
+		flakySpecs := flaky.Analyze(executions, 3)
+
+		Expect(flakySpecs).To(HaveLen(2))
+		Expect(flakySpecs[0].GitBranch).To(Equal("release"))
+		Expect(flakySpecs[0].Executions).To(Equal(4))
+		Expect(flakySpecs[1].GitBranch).To(Equal("main"))
+	})
+
+	It("should follow a renamed spec through its test case", func() {
+		testCaseID := uint64(7)
+		executions := []flaky.Execution{
+			execution(1, "passed", "aaa"),
+			execution(2, "failed", "bbb"),
+			execution(3, "passed", "ccc"),
+		}
+		for i := range executions {
+			executions[i].TestCaseID = &testCaseID
+		}
+		executions[2].SpecDescription = "adds one item"
+
+		flakySpecs := flaky.Analyze(executions, 3)
+
+		Expect(flakySpecs).To(HaveLen(1))
+		Expect(*flakySpecs[0].TestCaseID).To(Equal(testCaseID))
+		Expect(flakySpecs[0].SpecDescription).To(Equal("adds one item"))
+	})
+})
@@ -40,6 +40,69 @@ type DirectiveRoot struct {
 }
 
 type ComplexityRoot struct {
+	Failure struct {
+		File       func(childComplexity int) int
+		Kind       func(childComplexity int) int
+		Line       func(childComplexity int) int
+		Message    func(childComplexity int) int
+		StackTrace func(childComplexity int) int
+		Stderr     func(childComplexity int) int
+		Stdout     func(childComplexity int) int
+	}
+
+	FailureClassification struct {
+		FailingSince          func(childComplexity int) int
+		FailingSinceGitSha    func(childComplexity int) int
+		FailingSinceTestRunID func(childComplexity int) int
+		Kind                  func(childComplexity int) int
+		PreviousTestRunID     func(childComplexity int) int
+	}
+
+	FailureGroup struct {
+		Count       func(childComplexity int) int
+		Fingerprint func(childComplexity int) int
+		FirstSeen   func(childComplexity int) int
+		LastSeen    func(childComplexity int) int
+		Message     func(childComplexity int) int
+		Pattern     func(childComplexity int) int
+		Specs       func(childComplexity int) int
+		TestRuns    func(childComplexity int) int
+	}
+
+	FailureGroupSpec struct {
+		Count           func(childComplexity int) int
+		LastSpecRunID   func(childComplexity int) int
+		LastTestRunID   func(childComplexity int) int
+		SpecDescription func(childComplexity int) int
+		SuiteName       func(childComplexity int) int
+		TestCaseID      func(childComplexity int) int
+	}
+
+	FlakyEvidence struct {
+		GitSha    func(childComplexity int) int
+		Reason    func(childComplexity int) int
+		SpecRunID func(childComplexity int) int
+		StartTime func(childComplexity int) int
+		Status    func(childComplexity int) int
+		TestRunID func(childComplexity int) int
+		URL       func(childComplexity int) int
+	}
+
+	FlakySpec struct {
+		AlternationRate func(childComplexity int) int
+		Alternations    func(childComplexity int) int
+		CommitFlips     func(childComplexity int) int
+		Evidence        func(childComplexity int) int
+		Executions      func(childComplexity int) int
+		Failures        func(childComplexity int) int
+		GitBranch       func(childComplexity int) int
+		RetriedPasses   func(childComplexity int) int
+		Score           func(childComplexity int) int
+		SpecDescription func(childComplexity int) int
+		SuiteName       func(childComplexity int) int
+		TestCaseID      func(childComplexity int) int
+	}
+
 	PageInfo struct {
 		EndCursor       func(childComplexity int) int
 		HasNextPage     func(childComplexity int) int
@@ -47,26 +110,53 @@ type ComplexityRoot struct {
 		StartCursor     func(childComplexity int) int
 	}
 
+	QuarantineRule struct {
+		CreatedAt       func(childComplexity int) int
+		CreatedBy       func(childComplexity int) int
+		ExpiresAt       func(childComplexity int) int
+		ID              func(childComplexity int) int
+		Reason          func(childComplexity int) int
+		Score           func(childComplexity int) int
+		Source          func(childComplexity int) int
+		SpecDescription func(childComplexity int) int
+		SuiteName       func(childComplexity int) int
+		TestCaseID      func(childComplexity int) int
+		TestProjectName func(childComplexity int) int
+	}
+
 	Query struct {
-		TestRun     func(childComplexity int, testRunFilter modelv2.TestRunFilter) int
-		TestRunByID func(childComplexity int, id int) int
-		TestRuns    func(childComplexity int, first *int, after *string) int
+		FailureGroups        func(childComplexity int, project string, branch *string, startTime *string, endTime *string, limit *int) int
+		FlakySpecs           func(childComplexity int, project string, branch *string, startTime *string, endTime *string, minExecutions *int, limit *int) int
+		QuarantineRules      func(childComplexity int, project string) int
+		TestRun              func(childComplexity int, testRunFilter modelv2.TestRunFilter) int
+		TestRunByID          func(childComplexity int, id int) int
+		TestRunFailureGroups func(childComplexity int, testRunID int) int
+		TestRuns             func(childComplexity int, first *int, after *string, branch *string, commit *string, labelSelector *string) int
 	}
 
 	SpecRun struct {
+		Attempts        func(childComplexity int) int
+		Classification  func(childComplexity int) int
+		Containers      func(childComplexity int) int
 		EndTime         func(childComplexity int) int
+		Failure         func(childComplexity int) int
 		ID              func(childComplexity int) int
+		Labels          func(childComplexity int) int
 		Message         func(childComplexity int) int
+		Owner           func(childComplexity int) int
+		Quarantined     func(childComplexity int) int
 		SpecDescription func(childComplexity int) int
 		StartTime       func(childComplexity int) int
 		Status          func(childComplexity int) int
 		SuiteID         func(childComplexity int) int
 		Tags            func(childComplexity int) int
+		TestCaseID      func(childComplexity int) int
 	}
 
 	SuiteRun struct {
 		EndTime   func(childComplexity int) int
 		ID        func(childComplexity int) int
+		Labels    func(childComplexity int) int
 		SpecRuns  func(childComplexity int) int
 		StartTime func(childComplexity int) int
 		SuiteName func(childComplexity int) int
@@ -79,12 +169,22 @@ type ComplexityRoot struct {
 	}
 
 	TestRun struct {
-		EndTime         func(childComplexity int) int
-		ID              func(childComplexity int) int
-		StartTime       func(childComplexity int) int
-		SuiteRuns       func(childComplexity int) int
-		TestProjectName func(childComplexity int) int
-		TestSeed        func(childComplexity int) int
+		BuildNumber       func(childComplexity int) int
+		BuildURL          func(childComplexity int) int
+		CiProvider        func(childComplexity int) int
+		EndTime           func(childComplexity int) int
+		Environment       func(childComplexity int) int
+		GitBranch         func(childComplexity int) int
+		GitRepoURL        func(childComplexity int) int
+		GitSha            func(childComplexity int) int
+		ID                func(childComplexity int) int
+		Labels            func(childComplexity int) int
+		PullRequestNumber func(childComplexity int) int
+		StartTime         func(childComplexity int) int
+		SuiteRuns         func(childComplexity int) int
+		TestProjectName   func(childComplexity int) int
+		TestSeed          func(childComplexity int) int
+		TriggeredBy       func(childComplexity int) int
 	}
 
 	TestRunConnection struct {
@@ -118,6 +218,321 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 	_ = ec
 	switch typeName + "." + field {
 
+	case "Failure.file":
+		if e.complexity.Failure.File == nil {
+			break
+		}
+
+		return e.complexity.Failure.File(childComplexity), true
+
+	case "Failure.kind":
+		if e.complexity.Failure.Kind == nil {
+			break
+		}
+
+		return e.complexity.Failure.Kind(childComplexity), true
+
+	case "Failure.line":
+		if e.complexity.Failure.Line == nil {
+			break
+		}
+
+		return e.complexity.Failure.Line(childComplexity), true
+
+	case "Failure.message":
+		if e.complexity.Failure.Message == nil {
+			break
+		}
+
+		return e.complexity.Failure.Message(childComplexity), true
+
+	case "Failure.stackTrace":
+		if e.complexity.Failure.StackTrace == nil {
+			break
+		}
+
+		return e.complexity.Failure.StackTrace(childComplexity), true
+
+	case "Failure.stderr":
+		if e.complexity.Failure.Stderr == nil {
+			break
+		}
+
+		return e.complexity.Failure.Stderr(childComplexity), true
+
+	case "Failure.stdout":
+		if e.complexity.Failure.Stdout == nil {
+			break
+		}
+
+		return e.complexity.Failure.Stdout(childComplexity), true
+
+	case "FailureClassification.failingSince":
+		if e.complexity.FailureClassification.FailingSince == nil {
+			break
+		}
+
+		return e.complexity.FailureClassification.FailingSince(childComplexity), true
+
+	case "FailureClassification.failingSinceGitSha":
+		if e.complexity.FailureClassification.FailingSinceGitSha == nil {
+			break
+		}
+
+		return e.complexity.FailureClassification.FailingSinceGitSha(childComplexity), true
+
+	case "FailureClassification.failingSinceTestRunId":
+		if e.complexity.FailureClassification.FailingSinceTestRunID == nil {
+			break
+		}
+
+		return e.complexity.FailureClassification.FailingSinceTestRunID(childComplexity), true
+
+	case "FailureClassification.kind":
+		if e.complexity.FailureClassification.Kind == nil {
+			break
+		}
+
+		return e.complexity.FailureClassification.Kind(childComplexity), true
+
+	case "FailureClassification.previousTestRunId":
+		if e.complexity.FailureClassification.PreviousTestRunID == nil {
+			break
+		}
+
+		return e.complexity.FailureClassification.PreviousTestRunID(childComplexity), true
+
+	case "FailureGroup.count":
+		if e.complexity.FailureGroup.Count == nil {
+			break
+		}
+
+		return e.complexity.FailureGroup.Count(childComplexity), true
+
+	case "FailureGroup.fingerprint":
+		if e.complexity.FailureGroup.Fingerprint == nil {
+			break
+		}
+
+		return e.complexity.FailureGroup.Fingerprint(childComplexity), true
+
+	case "FailureGroup.firstSeen":
+		if e.complexity.FailureGroup.FirstSeen == nil {
+			break
+		}
+
+		return e.complexity.FailureGroup.FirstSeen(childComplexity), true
+
+	case "FailureGroup.lastSeen":
+		if e.complexity.FailureGroup.LastSeen == nil {
+			break
+		}
+
+		return e.complexity.FailureGroup.LastSeen(childComplexity), true
+
+	case "FailureGroup.message":
+		if e.complexity.FailureGroup.Message == nil {
+			break
+		}
+
+		return e.complexity.FailureGroup.Message(childComplexity), true
+
+	case "FailureGroup.pattern":
+		if e.complexity.FailureGroup.Pattern == nil {
+			break
+		}
+
+		return e.complexity.FailureGroup.Pattern(childComplexity), true
+
+	case "FailureGroup.specs":
+		if e.complexity.FailureGroup.Specs == nil {
+			break
+		}
+
+		return e.complexity.FailureGroup.Specs(childComplexity), true
+
+	case "FailureGroup.testRuns":
+		if e.complexity.FailureGroup.TestRuns == nil {
+			break
+		}
+
+		return e.complexity.FailureGroup.TestRuns(childComplexity), true
+
+	case "FailureGroupSpec.count":
+		if e.complexity.FailureGroupSpec.Count == nil {
+			break
+		}
+
+		return e.complexity.FailureGroupSpec.Count(childComplexity), true
+
+	case "FailureGroupSpec.lastSpecRunId":
+		if e.complexity.FailureGroupSpec.LastSpecRunID == nil {
+			break
+		}
+
+		return e.complexity.FailureGroupSpec.LastSpecRunID(childComplexity), true
+
+	case "FailureGroupSpec.lastTestRunId":
+		if e.complexity.FailureGroupSpec.LastTestRunID == nil {
+			break
+		}
+
+		return e.complexity.FailureGroupSpec.LastTestRunID(childComplexity), true
+
+	case "FailureGroupSpec.specDescription":
+		if e.complexity.FailureGroupSpec.SpecDescription == nil {
+			break
+		}
+
+		return e.complexity.FailureGroupSpec.SpecDescription(childComplexity), true
+
+	case "FailureGroupSpec.suiteName":
+		if e.complexity.FailureGroupSpec.SuiteName == nil {
+			break
+		}
+
+		return e.complexity.FailureGroupSpec.SuiteName(childComplexity), true
+
+	case "FailureGroupSpec.testCaseId":
+		if e.complexity.FailureGroupSpec.TestCaseID == nil {
+			break
+		}
+
+		return e.complexity.FailureGroupSpec.TestCaseID(childComplexity), true
+
+	case "FlakyEvidence.gitSha":
+		if e.complexity.FlakyEvidence.GitSha == nil {
+			break
+		}
+
+		return e.complexity.FlakyEvidence.GitSha(childComplexity), true
+
+	case "FlakyEvidence.reason":
+		if e.complexity.FlakyEvidence.Reason == nil {
+			break
+		}
+
+		return e.complexity.FlakyEvidence.Reason(childComplexity), true
+
+	case "FlakyEvidence.specRunId":
+		if e.complexity.FlakyEvidence.SpecRunID == nil {
+			break
+		}
+
+		return e.complexity.FlakyEvidence.SpecRunID(childComplexity), true
+
+	case "FlakyEvidence.startTime":
+		if e.complexity.FlakyEvidence.StartTime == nil {
+			break
+		}
+
+		return e.complexity.FlakyEvidence.StartTime(childComplexity), true
+
+	case "FlakyEvidence.status":
+		if e.complexity.FlakyEvidence.Status == nil {
+			break
+		}
+
+		return e.complexity.FlakyEvidence.Status(childComplexity), true
+
+	case "FlakyEvidence.testRunId":
+		if e.complexity.FlakyEvidence.TestRunID == nil {
+			break
+		}
+
+		return e.complexity.FlakyEvidence.TestRunID(childComplexity), true
+
+	case "FlakyEvidence.url":
+		if e.complexity.FlakyEvidence.URL == nil {
+			break
+		}
+
+		return e.complexity.FlakyEvidence.URL(childComplexity), true
+
+	case "FlakySpec.alternationRate":
+		if e.complexity.FlakySpec.AlternationRate == nil {
+			break
+		}
+
+		return e.complexity.FlakySpec.AlternationRate(childComplexity), true
+
+	case "FlakySpec.alternations":
+		if e.complexity.FlakySpec.Alternations == nil {
+			break
+		}
+
+		return e.complexity.FlakySpec.Alternations(childComplexity), true
+
+	case "FlakySpec.commitFlips":
+		if e.complexity.FlakySpec.CommitFlips == nil {
+			break
+		}
+
+		return e.complexity.FlakySpec.CommitFlips(childComplexity), true
+
+	case "FlakySpec.evidence":
+		if e.complexity.FlakySpec.Evidence == nil {
+			break
+		}
+
+		return e.complexity.FlakySpec.Evidence(childComplexity), true
+
+	case "FlakySpec.executions":
+		if e.complexity.FlakySpec.Executions == nil {
+			break
+		}
+
+		return e.complexity.FlakySpec.Executions(childComplexity), true
+
+	case "FlakySpec.failures":
+		if e.complexity.FlakySpec.Failures == nil {
+			break
+		}
+
+		return e.complexity.FlakySpec.Failures(childComplexity), true
+
+	case "FlakySpec.gitBranch":
+		if e.complexity.FlakySpec.GitBranch == nil {
+			break
+		}
+
+		return e.complexity.FlakySpec.GitBranch(childComplexity), true
+
+	case "FlakySpec.retriedPasses":
+		if e.complexity.FlakySpec.RetriedPasses == nil {
+			break
+		}
+
+		return e.complexity.FlakySpec.RetriedPasses(childComplexity), true
+
+	case "FlakySpec.score":
+		if e.complexity.FlakySpec.Score == nil {
+			break
+		}
+
+		return e.complexity.FlakySpec.Score(childComplexity), true
+
+	case "FlakySpec.specDescription":
+		if e.complexity.FlakySpec.SpecDescription == nil {
+			break
+		}
+
+		return e.complexity.FlakySpec.SpecDescription(childComplexity), true
+
+	case "FlakySpec.suiteName":
+		if e.complexity.FlakySpec.SuiteName == nil {
+			break
+		}
+
+		return e.complexity.FlakySpec.SuiteName(childComplexity), true
+
+	case "FlakySpec.testCaseId":
+		if e.complexity.FlakySpec.TestCaseID == nil {
+			break
+		}
+
+		return e.complexity.FlakySpec.TestCaseID(childComplexity), true
+
 	case "PageInfo.endCursor":
 		if e.complexity.PageInfo.EndCursor == nil {
 			break
@@ -146,6 +561,119 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.PageInfo.StartCursor(childComplexity), true
 
+	case "QuarantineRule.createdAt":
+		if e.complexity.QuarantineRule.CreatedAt == nil {
+			break
+		}
+
+		return e.complexity.QuarantineRule.CreatedAt(childComplexity), true
+
+	case "QuarantineRule.createdBy":
+		if e.complexity.QuarantineRule.CreatedBy == nil {
+			break
+		}
+
+		return e.complexity.QuarantineRule.CreatedBy(childComplexity), true
+
+	case "QuarantineRule.expiresAt":
+		if e.complexity.QuarantineRule.ExpiresAt == nil {
+			break
+		}
+
+		return e.complexity.QuarantineRule.ExpiresAt(childComplexity), true
+
+	case "QuarantineRule.id":
+		if e.complexity.QuarantineRule.ID == nil {
+			break
+		}
+
+		return e.complexity.QuarantineRule.ID(childComplexity), true
+
+	case "QuarantineRule.reason":
+		if e.complexity.QuarantineRule.Reason == nil {
+			break
+		}
+
+		return e.complexity.QuarantineRule.Reason(childComplexity), true
+
+	case "QuarantineRule.score":
+		if e.complexity.QuarantineRule.Score == nil {
+			break
+		}
+
+		return e.complexity.QuarantineRule.Score(childComplexity), true
+
+	case "QuarantineRule.source":
+		if e.complexity.QuarantineRule.Source == nil {
+			break
+		}
+
+		return e.complexity.QuarantineRule.Source(childComplexity), true
+
+	case "QuarantineRule.specDescription":
+		if e.complexity.QuarantineRule.SpecDescription == nil {
+			break
+		}
+
+		return e.complexity.QuarantineRule.SpecDescription(childComplexity), true
+
+	case "QuarantineRule.suiteName":
+		if e.complexity.QuarantineRule.SuiteName == nil {
+			break
+		}
+
+		return e.complexity.QuarantineRule.SuiteName(childComplexity), true
+
+	case "QuarantineRule.testCaseId":
+		if e.complexity.QuarantineRule.TestCaseID == nil {
+			break
+		}
+
+		return e.complexity.QuarantineRule.TestCaseID(childComplexity), true
+
+	case "QuarantineRule.testProjectName":
+		if e.complexity.QuarantineRule.TestProjectName == nil {
+			break
+		}
+
+		return e.complexity.QuarantineRule.TestProjectName(childComplexity), true
+
+	case "Query.failureGroups":
+		if e.complexity.Query.FailureGroups == nil {
+			break
+		}
+
+		args, err := ec.field_Query_failureGroups_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.FailureGroups(childComplexity, args["project"].(string), args["branch"].(*string), args["startTime"].(*string), args["endTime"].(*string), args["limit"].(*int)), true
+
+	case "Query.flakySpecs":
+		if e.complexity.Query.FlakySpecs == nil {
+			break
+		}
+
+		args, err := ec.field_Query_flakySpecs_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.FlakySpecs(childComplexity, args["project"].(string), args["branch"].(*string), args["startTime"].(*string), args["endTime"].(*string), args["minExecutions"].(*int), args["limit"].(*int)), true
+
+	case "Query.quarantineRules":
+		if e.complexity.Query.QuarantineRules == nil {
+			break
+		}
+
+		args, err := ec.field_Query_quarantineRules_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.QuarantineRules(childComplexity, args["project"].(string)), true
+
 	case "Query.testRun":
 		if e.complexity.Query.TestRun == nil {
 			break
@@ -170,6 +698,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.TestRunByID(childComplexity, args["id"].(int)), true
 
+	case "Query.testRunFailureGroups":
+		if e.complexity.Query.TestRunFailureGroups == nil {
+			break
+		}
+
+		args, err := ec.field_Query_testRunFailureGroups_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.TestRunFailureGroups(childComplexity, args["testRunId"].(int)), true
+
 	case "Query.testRuns":
 		if e.complexity.Query.TestRuns == nil {
 			break
@@ -180,7 +720,28 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			return 0, false
 		}
 
-		return e.complexity.Query.TestRuns(childComplexity, args["first"].(*int), args["after"].(*string)), true
+		return e.complexity.Query.TestRuns(childComplexity, args["first"].(*int), args["after"].(*string), args["branch"].(*string), args["commit"].(*string), args["labelSelector"].(*string)), true
+
+	case "SpecRun.attempts":
+		if e.complexity.SpecRun.Attempts == nil {
+			break
+		}
+
+		return e.complexity.SpecRun.Attempts(childComplexity), true
+
+	case "SpecRun.classification":
+		if e.complexity.SpecRun.Classification == nil {
+			break
+		}
+
+		return e.complexity.SpecRun.Classification(childComplexity), true
+
+	case "SpecRun.containers":
+		if e.complexity.SpecRun.Containers == nil {
+			break
+		}
+
+		return e.complexity.SpecRun.Containers(childComplexity), true
 
 	case "SpecRun.endTime":
 		if e.complexity.SpecRun.EndTime == nil {
@@ -189,6 +750,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.SpecRun.EndTime(childComplexity), true
 
+	case "SpecRun.failure":
+		if e.complexity.SpecRun.Failure == nil {
+			break
+		}
+
+		return e.complexity.SpecRun.Failure(childComplexity), true
+
 	case "SpecRun.id":
 		if e.complexity.SpecRun.ID == nil {
 			break
@@ -196,6 +764,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.SpecRun.ID(childComplexity), true
 
+	case "SpecRun.labels":
+		if e.complexity.SpecRun.Labels == nil {
+			break
+		}
+
+		return e.complexity.SpecRun.Labels(childComplexity), true
+
 	case "SpecRun.message":
 		if e.complexity.SpecRun.Message == nil {
 			break
@@ -203,6 +778,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.SpecRun.Message(childComplexity), true
 
+	case "SpecRun.owner":
+		if e.complexity.SpecRun.Owner == nil {
+			break
+		}
+
+		return e.complexity.SpecRun.Owner(childComplexity), true
+
+	case "SpecRun.quarantined":
+		if e.complexity.SpecRun.Quarantined == nil {
+			break
+		}
+
+		return e.complexity.SpecRun.Quarantined(childComplexity), true
+
 	case "SpecRun.specDescription":
 		if e.complexity.SpecRun.SpecDescription == nil {
 			break
@@ -238,6 +827,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.SpecRun.Tags(childComplexity), true
 
+	case "SpecRun.testCaseId":
+		if e.complexity.SpecRun.TestCaseID == nil {
+			break
+		}
+
+		return e.complexity.SpecRun.TestCaseID(childComplexity), true
+
 	case "SuiteRun.endTime":
 		if e.complexity.SuiteRun.EndTime == nil {
 			break
@@ -252,6 +848,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.SuiteRun.ID(childComplexity), true
 
+	case "SuiteRun.labels":
+		if e.complexity.SuiteRun.Labels == nil {
+			break
+		}
+
+		return e.complexity.SuiteRun.Labels(childComplexity), true
+
 	case "SuiteRun.specRuns":
 		if e.complexity.SuiteRun.SpecRuns == nil {
 			break
@@ -294,6 +897,27 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Tag.Name(childComplexity), true
 
+	case "TestRun.buildNumber":
+		if e.complexity.TestRun.BuildNumber == nil {
+			break
+		}
+
+		return e.complexity.TestRun.BuildNumber(childComplexity), true
+
+	case "TestRun.buildUrl":
+		if e.complexity.TestRun.BuildURL == nil {
+			break
+		}
+
+		return e.complexity.TestRun.BuildURL(childComplexity), true
+
+	case "TestRun.ciProvider":
+		if e.complexity.TestRun.CiProvider == nil {
+			break
+		}
+
+		return e.complexity.TestRun.CiProvider(childComplexity), true
+
 	case "TestRun.endTime":
 		if e.complexity.TestRun.EndTime == nil {
 			break
@@ -301,6 +925,34 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.TestRun.EndTime(childComplexity), true
 
+	case "TestRun.environment":
+		if e.complexity.TestRun.Environment == nil {
+			break
+		}
+
+		return e.complexity.TestRun.Environment(childComplexity), true
+
+	case "TestRun.gitBranch":
+		if e.complexity.TestRun.GitBranch == nil {
+			break
+		}
+
+		return e.complexity.TestRun.GitBranch(childComplexity), true
+
+	case "TestRun.gitRepoUrl":
+		if e.complexity.TestRun.GitRepoURL == nil {
+			break
+		}
+
+		return e.complexity.TestRun.GitRepoURL(childComplexity), true
+
+	case "TestRun.gitSha":
+		if e.complexity.TestRun.GitSha == nil {
+			break
+		}
+
+		return e.complexity.TestRun.GitSha(childComplexity), true
+
 	case "TestRun.id":
 		if e.complexity.TestRun.ID == nil {
 			break
@@ -308,6 +960,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.TestRun.ID(childComplexity), true
 
+	case "TestRun.labels":
+		if e.complexity.TestRun.Labels == nil {
+			break
+		}
+
+		return e.complexity.TestRun.Labels(childComplexity), true
+
+	case "TestRun.pullRequestNumber":
+		if e.complexity.TestRun.PullRequestNumber == nil {
+			break
+		}
+
+		return e.complexity.TestRun.PullRequestNumber(childComplexity), true
+
 	case "TestRun.startTime":
 		if e.complexity.TestRun.StartTime == nil {
 			break
@@ -336,6 +1002,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.TestRun.TestSeed(childComplexity), true
 
+	case "TestRun.triggeredBy":
+		if e.complexity.TestRun.TriggeredBy == nil {
+			break
+		}
+
+		return e.complexity.TestRun.TriggeredBy(childComplexity), true
+
 	case "TestRunConnection.edges":
 		if e.complexity.TestRunConnection.Edges == nil {
 			break
@@ -462,11 +1135,57 @@ func (ec *executionContext) introspectType(name string) (*introspection.Type, er
 }
 
 var sources = []*ast.Source{
-	{Name: "../schemas/schema.graphql", Input: `type Tag {
+	{Name: "../schemas/schema.graphql", Input: `"""
+Key/value labels, as a JSON object of strings.
+"""
+scalar Labels
+
+"""
+The Describe/Context containers of a spec, outermost first, as a JSON list of
+{"text": String, "labels": [String]} objects.
+"""
+scalar Containers
+
+"""
+The attempts of a retried spec in the order they ran, as a JSON list of
+{"status": String, "message": String, "start_time": String, "end_time": String}
+objects.
+"""
+scalar Attempts
+
+type Tag {
   id: Int
   name: String
 }
 
+"""
+Why a spec run failed. kind is one of assertion, panic, timeout, interrupted
+or setup when known.
+"""
+type Failure {
+  message: String
+  kind: String
+  file: String
+  line: Int
+  stackTrace: String
+  stdout: String
+  stderr: String
+}
+
+"""
+Compares a spec run with the previous runs of its spec on the same project
+branch. kind is new_failure, still_failing or fixed. previousTestRunId is the
+previous run of the spec, and failingSince* the first run of the failure streak
+of a still failing spec.
+"""
+type FailureClassification {
+  kind: String!
+  failingSince: String
+  failingSinceTestRunId: Int
+  failingSinceGitSha: String
+  previousTestRunId: Int
+}
+
 type SpecRun {
   id: Int
   suiteId: Int
@@ -475,7 +1194,18 @@ type SpecRun {
   message: String
   startTime: String
   endTime: String
+  labels: Labels
+  containers: Containers
+  failure: Failure
+  attempts: Attempts
   tags: [Tag]
+  testCaseId: Int
+  owner: String
+  quarantined: Boolean
+  """
+  Only set by testRunById.
+  """
+  classification: FailureClassification
 }
 
 type SuiteRun {
@@ -484,6 +1214,7 @@ type SuiteRun {
   suiteName: String
   startTime: String
   endTime: String
+  labels: Labels
   specRuns: [SpecRun]
 }
 
@@ -493,18 +1224,112 @@ type TestRun {
   testSeed: Int
   startTime: String
   endTime: String
+  gitSha: String
+  gitBranch: String
+  gitRepoUrl: String
+  pullRequestNumber: Int
+  ciProvider: String
+  buildNumber: String
+  buildUrl: String
+  triggeredBy: String
+  environment: String
+  labels: Labels
   suiteRuns: [SuiteRun!]!
 }
 
+"""
+An execution of a flaky spec that shows its flakiness. reason is one of
+commit_flip, retried or alternation, and url links to the report of the run.
+"""
+type FlakyEvidence {
+  testRunId: Int!
+  specRunId: Int!
+  status: String
+  gitSha: String
+  startTime: String
+  reason: String
+  url: String
+}
+
+"""
+A spec of a branch whose outcome changes without a code change, scored from 0
+(stable) to 1 (most flaky).
+"""
+type FlakySpec {
+  testCaseId: Int
+  suiteName: String
+  specDescription: String
+  gitBranch: String
+  score: Float!
+  executions: Int!
+  failures: Int!
+  retriedPasses: Int!
+  commitFlips: Int!
+  alternations: Int!
+  alternationRate: Float!
+  evidence: [FlakyEvidence!]!
+}
+
+"""
+Quarantines a spec of a project until expiresAt. source is manual, or auto when
+the rule was created for a flakiness score above the configured threshold.
+"""
+type QuarantineRule {
+  id: Int!
+  testProjectName: String!
+  testCaseId: Int
+  suiteName: String!
+  specDescription: String!
+  reason: String!
+  source: String!
+  score: Float
+  createdBy: String
+  createdAt: String!
+  expiresAt: String!
+}
+
+"""
+A spec affected by a failure group, with the number of its failures in the
+group and the latest one.
+"""
+type FailureGroupSpec {
+  testCaseId: Int
+  suiteName: String!
+  specDescription: String!
+  count: Int!
+  lastTestRunId: Int!
+  lastSpecRunId: Int!
+}
+
+"""
+Failed spec runs whose failure messages are alike once the values that vary
+between runs are normalized away. pattern is the normalized message and
+message the latest original one.
+"""
+type FailureGroup {
+  fingerprint: String!
+  pattern: String!
+  message: String!
+  count: Int!
+  testRuns: Int!
+  firstSeen: String!
+  lastSeen: String!
+  specs: [FailureGroupSpec!]!
+}
+
 input TestRunFilter {
   id: Int
   testProjectName: String
 }
 
 type Query {
-  testRuns(first: Int, after: String): TestRunConnection!
+  testRuns(first: Int, after: String, branch: String, commit: String, labelSelector: String): TestRunConnection!
   testRun(testRunFilter: TestRunFilter!): [TestRun!]!
   testRunById(id: Int!): TestRun
+  flakySpecs(project: String!, branch: String, startTime: String, endTime: String, minExecutions: Int, limit: Int): [FlakySpec!]!
+  quarantineRules(project: String!): [QuarantineRule!]!
+  failureGroups(project: String!, branch: String, startTime: String, endTime: String, limit: Int): [FailureGroup!]!
+  testRunFailureGroups(testRunId: Int!): [FailureGroup!]!
 }
 
 type PageInfo {
@@ -13,15 +13,20 @@ import (
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/introspection"
 	"github.com/guidewire/fern-reporter/pkg/graph/modelv2"
+	"github.com/guidewire/fern-reporter/pkg/models"
 	"github.com/vektah/gqlparser/v2/ast"
 )
 
 // region    ************************** generated!.gotpl **************************
 
 type QueryResolver interface {
-	TestRuns(ctx context.Context, first *int, after *string) (*modelv2.TestRunConnection, error)
+	TestRuns(ctx context.Context, first *int, after *string, branch *string, commit *string, labelSelector *string) (*modelv2.TestRunConnection, error)
 	TestRun(ctx context.Context, testRunFilter modelv2.TestRunFilter) ([]*modelv2.TestRun, error)
 	TestRunByID(ctx context.Context, id int) (*modelv2.TestRun, error)
+	FlakySpecs(ctx context.Context, project string, branch *string, startTime *string, endTime *string, minExecutions *int, limit *int) ([]*modelv2.FlakySpec, error)
+	QuarantineRules(ctx context.Context, project string) ([]*modelv2.QuarantineRule, error)
+	FailureGroups(ctx context.Context, project string, branch *string, startTime *string, endTime *string, limit *int) ([]*modelv2.FailureGroup, error)
+	TestRunFailureGroups(ctx context.Context, testRunID int) ([]*modelv2.FailureGroup, error)
 }
 
 // endregion ************************** generated!.gotpl **************************
@@ -43,6 +48,132 @@ func (ec *executionContext) field_Query___type_args(ctx context.Context, rawArgs
 	return args, nil
 }
 
+func (ec *executionContext) field_Query_failureGroups_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["project"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("project"))
+		arg0, err = ec.unmarshalNString2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["project"] = arg0
+	var arg1 *string
+	if tmp, ok := rawArgs["branch"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("branch"))
+		arg1, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["branch"] = arg1
+	var arg2 *string
+	if tmp, ok := rawArgs["startTime"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("startTime"))
+		arg2, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["startTime"] = arg2
+	var arg3 *string
+	if tmp, ok := rawArgs["endTime"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("endTime"))
+		arg3, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["endTime"] = arg3
+	var arg4 *int
+	if tmp, ok := rawArgs["limit"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("limit"))
+		arg4, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["limit"] = arg4
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_flakySpecs_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["project"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("project"))
+		arg0, err = ec.unmarshalNString2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["project"] = arg0
+	var arg1 *string
+	if tmp, ok := rawArgs["branch"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("branch"))
+		arg1, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["branch"] = arg1
+	var arg2 *string
+	if tmp, ok := rawArgs["startTime"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("startTime"))
+		arg2, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["startTime"] = arg2
+	var arg3 *string
+	if tmp, ok := rawArgs["endTime"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("endTime"))
+		arg3, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["endTime"] = arg3
+	var arg4 *int
+	if tmp, ok := rawArgs["minExecutions"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("minExecutions"))
+		arg4, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["minExecutions"] = arg4
+	var arg5 *int
+	if tmp, ok := rawArgs["limit"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("limit"))
+		arg5, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["limit"] = arg5
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_quarantineRules_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["project"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("project"))
+		arg0, err = ec.unmarshalNString2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["project"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Query_testRunById_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -58,6 +189,21 @@ func (ec *executionContext) field_Query_testRunById_args(ctx context.Context, ra
 	return args, nil
 }
 
+func (ec *executionContext) field_Query_testRunFailureGroups_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 int
+	if tmp, ok := rawArgs["testRunId"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("testRunId"))
+		arg0, err = ec.unmarshalNInt2int(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["testRunId"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Query_testRun_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -94,6 +240,33 @@ func (ec *executionContext) field_Query_testRuns_args(ctx context.Context, rawAr
 		}
 	}
 	args["after"] = arg1
+	var arg2 *string
+	if tmp, ok := rawArgs["branch"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("branch"))
+		arg2, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["branch"] = arg2
+	var arg3 *string
+	if tmp, ok := rawArgs["commit"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("commit"))
+		arg3, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["commit"] = arg3
+	var arg4 *string
+	if tmp, ok := rawArgs["labelSelector"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("labelSelector"))
+		arg4, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["labelSelector"] = arg4
 	return args, nil
 }
 
@@ -105,8 +278,8 @@ func (ec *executionContext) field_Query_testRuns_args(ctx context.Context, rawAr
 
 // region    **************************** field.gotpl *****************************
 
-func (ec *executionContext) _PageInfo_hasNextPage(ctx context.Context, field graphql.CollectedField, obj *modelv2.PageInfo) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PageInfo_hasNextPage(ctx, field)
+func (ec *executionContext) _Failure_message(ctx context.Context, field graphql.CollectedField, obj *models.Failure) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Failure_message(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -119,38 +292,35 @@ func (ec *executionContext) _PageInfo_hasNextPage(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.HasNextPage, nil
+		return obj.Message, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PageInfo_hasNextPage(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Failure_message(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PageInfo",
+		Object:     "Failure",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PageInfo_hasPreviousPage(ctx context.Context, field graphql.CollectedField, obj *modelv2.PageInfo) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PageInfo_hasPreviousPage(ctx, field)
+func (ec *executionContext) _Failure_kind(ctx context.Context, field graphql.CollectedField, obj *models.Failure) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Failure_kind(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -163,38 +333,35 @@ func (ec *executionContext) _PageInfo_hasPreviousPage(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.HasPreviousPage, nil
+		return obj.Kind, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PageInfo_hasPreviousPage(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Failure_kind(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PageInfo",
+		Object:     "Failure",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PageInfo_startCursor(ctx context.Context, field graphql.CollectedField, obj *modelv2.PageInfo) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PageInfo_startCursor(ctx, field)
+func (ec *executionContext) _Failure_file(ctx context.Context, field graphql.CollectedField, obj *models.Failure) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Failure_file(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -207,26 +374,23 @@ func (ec *executionContext) _PageInfo_startCursor(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.StartCursor, nil
+		return obj.File, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PageInfo_startCursor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Failure_file(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PageInfo",
+		Object:     "Failure",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -237,8 +401,8 @@ func (ec *executionContext) fieldContext_PageInfo_startCursor(_ context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _PageInfo_endCursor(ctx context.Context, field graphql.CollectedField, obj *modelv2.PageInfo) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PageInfo_endCursor(ctx, field)
+func (ec *executionContext) _Failure_line(ctx context.Context, field graphql.CollectedField, obj *models.Failure) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Failure_line(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -251,38 +415,35 @@ func (ec *executionContext) _PageInfo_endCursor(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.EndCursor, nil
+		return obj.Line, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PageInfo_endCursor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Failure_line(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PageInfo",
+		Object:     "Failure",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_testRuns(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_testRuns(ctx, field)
+func (ec *executionContext) _Failure_stackTrace(ctx context.Context, field graphql.CollectedField, obj *models.Failure) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Failure_stackTrace(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -295,57 +456,35 @@ func (ec *executionContext) _Query_testRuns(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().TestRuns(rctx, fc.Args["first"].(*int), fc.Args["after"].(*string))
+		return obj.StackTrace, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(*modelv2.TestRunConnection)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNTestRunConnection2ᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐTestRunConnection(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_testRuns(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Failure_stackTrace(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Failure",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "edges":
-				return ec.fieldContext_TestRunConnection_edges(ctx, field)
-			case "pageInfo":
-				return ec.fieldContext_TestRunConnection_pageInfo(ctx, field)
-			case "totalCount":
-				return ec.fieldContext_TestRunConnection_totalCount(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type TestRunConnection", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_testRuns_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_testRun(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_testRun(ctx, field)
+func (ec *executionContext) _Failure_stdout(ctx context.Context, field graphql.CollectedField, obj *models.Failure) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Failure_stdout(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -358,63 +497,76 @@ func (ec *executionContext) _Query_testRun(ctx context.Context, field graphql.Co
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().TestRun(rctx, fc.Args["testRunFilter"].(modelv2.TestRunFilter))
+		return obj.Stdout, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.([]*modelv2.TestRun)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNTestRun2ᚕᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐTestRunᚄ(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_testRun(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Failure_stdout(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Failure",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_TestRun_id(ctx, field)
-			case "testProjectName":
-				return ec.fieldContext_TestRun_testProjectName(ctx, field)
-			case "testSeed":
-				return ec.fieldContext_TestRun_testSeed(ctx, field)
-			case "startTime":
-				return ec.fieldContext_TestRun_startTime(ctx, field)
-			case "endTime":
-				return ec.fieldContext_TestRun_endTime(ctx, field)
-			case "suiteRuns":
-				return ec.fieldContext_TestRun_suiteRuns(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type TestRun", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Failure_stderr(ctx context.Context, field graphql.CollectedField, obj *models.Failure) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Failure_stderr(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
 	defer func() {
 		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
 		}
 	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_testRun_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Stderr, nil
+	})
+	if err != nil {
 		ec.Error(ctx, err)
-		return fc, err
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Failure_stderr(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Failure",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_testRunById(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_testRunById(ctx, field)
+func (ec *executionContext) _FailureClassification_kind(ctx context.Context, field graphql.CollectedField, obj *modelv2.FailureClassification) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FailureClassification_kind(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -427,60 +579,38 @@ func (ec *executionContext) _Query_testRunById(ctx context.Context, field graphq
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().TestRunByID(rctx, fc.Args["id"].(int))
+		return obj.Kind, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*modelv2.TestRun)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOTestRun2ᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐTestRun(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_testRunById(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FailureClassification_kind(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "FailureClassification",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_TestRun_id(ctx, field)
-			case "testProjectName":
-				return ec.fieldContext_TestRun_testProjectName(ctx, field)
-			case "testSeed":
-				return ec.fieldContext_TestRun_testSeed(ctx, field)
-			case "startTime":
-				return ec.fieldContext_TestRun_startTime(ctx, field)
-			case "endTime":
-				return ec.fieldContext_TestRun_endTime(ctx, field)
-			case "suiteRuns":
-				return ec.fieldContext_TestRun_suiteRuns(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type TestRun", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_testRunById_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query___type(ctx, field)
+func (ec *executionContext) _FailureClassification_failingSince(ctx context.Context, field graphql.CollectedField, obj *modelv2.FailureClassification) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FailureClassification_failingSince(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -493,7 +623,7 @@ func (ec *executionContext) _Query___type(ctx context.Context, field graphql.Col
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.introspectType(fc.Args["name"].(string))
+		return obj.FailingSince, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -502,59 +632,26 @@ func (ec *executionContext) _Query___type(ctx context.Context, field graphql.Col
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Type)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query___type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FailureClassification_failingSince(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "FailureClassification",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "kind":
-				return ec.fieldContext___Type_kind(ctx, field)
-			case "name":
-				return ec.fieldContext___Type_name(ctx, field)
-			case "description":
-				return ec.fieldContext___Type_description(ctx, field)
-			case "fields":
-				return ec.fieldContext___Type_fields(ctx, field)
-			case "interfaces":
-				return ec.fieldContext___Type_interfaces(ctx, field)
-			case "possibleTypes":
-				return ec.fieldContext___Type_possibleTypes(ctx, field)
-			case "enumValues":
-				return ec.fieldContext___Type_enumValues(ctx, field)
-			case "inputFields":
-				return ec.fieldContext___Type_inputFields(ctx, field)
-			case "ofType":
-				return ec.fieldContext___Type_ofType(ctx, field)
-			case "specifiedByURL":
-				return ec.fieldContext___Type_specifiedByURL(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query___type_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query___schema(ctx, field)
+func (ec *executionContext) _FailureClassification_failingSinceTestRunId(ctx context.Context, field graphql.CollectedField, obj *modelv2.FailureClassification) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FailureClassification_failingSinceTestRunId(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -567,7 +664,7 @@ func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.introspectSchema()
+		return obj.FailingSinceTestRunID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -576,40 +673,26 @@ func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.C
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Schema)
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query___schema(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FailureClassification_failingSinceTestRunId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "FailureClassification",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "description":
-				return ec.fieldContext___Schema_description(ctx, field)
-			case "types":
-				return ec.fieldContext___Schema_types(ctx, field)
-			case "queryType":
-				return ec.fieldContext___Schema_queryType(ctx, field)
-			case "mutationType":
-				return ec.fieldContext___Schema_mutationType(ctx, field)
-			case "subscriptionType":
-				return ec.fieldContext___Schema_subscriptionType(ctx, field)
-			case "directives":
-				return ec.fieldContext___Schema_directives(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type __Schema", field.Name)
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _SpecRun_id(ctx context.Context, field graphql.CollectedField, obj *modelv2.SpecRun) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_SpecRun_id(ctx, field)
+func (ec *executionContext) _FailureClassification_failingSinceGitSha(ctx context.Context, field graphql.CollectedField, obj *modelv2.FailureClassification) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FailureClassification_failingSinceGitSha(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -622,7 +705,7 @@ func (ec *executionContext) _SpecRun_id(ctx context.Context, field graphql.Colle
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.FailingSinceGitSha, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -631,26 +714,26 @@ func (ec *executionContext) _SpecRun_id(ctx context.Context, field graphql.Colle
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_SpecRun_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FailureClassification_failingSinceGitSha(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "SpecRun",
+		Object:     "FailureClassification",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _SpecRun_suiteId(ctx context.Context, field graphql.CollectedField, obj *modelv2.SpecRun) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_SpecRun_suiteId(ctx, field)
+func (ec *executionContext) _FailureClassification_previousTestRunId(ctx context.Context, field graphql.CollectedField, obj *modelv2.FailureClassification) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FailureClassification_previousTestRunId(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -663,7 +746,7 @@ func (ec *executionContext) _SpecRun_suiteId(ctx context.Context, field graphql.
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.SuiteID, nil
+		return obj.PreviousTestRunID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -677,9 +760,9 @@ func (ec *executionContext) _SpecRun_suiteId(ctx context.Context, field graphql.
 	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_SpecRun_suiteId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FailureClassification_previousTestRunId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "SpecRun",
+		Object:     "FailureClassification",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -690,8 +773,8 @@ func (ec *executionContext) fieldContext_SpecRun_suiteId(_ context.Context, fiel
 	return fc, nil
 }
 
-func (ec *executionContext) _SpecRun_specDescription(ctx context.Context, field graphql.CollectedField, obj *modelv2.SpecRun) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_SpecRun_specDescription(ctx, field)
+func (ec *executionContext) _FailureGroup_fingerprint(ctx context.Context, field graphql.CollectedField, obj *modelv2.FailureGroup) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FailureGroup_fingerprint(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -704,23 +787,26 @@ func (ec *executionContext) _SpecRun_specDescription(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.SpecDescription, nil
+		return obj.Fingerprint, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_SpecRun_specDescription(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FailureGroup_fingerprint(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "SpecRun",
+		Object:     "FailureGroup",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -731,8 +817,8 @@ func (ec *executionContext) fieldContext_SpecRun_specDescription(_ context.Conte
 	return fc, nil
 }
 
-func (ec *executionContext) _SpecRun_status(ctx context.Context, field graphql.CollectedField, obj *modelv2.SpecRun) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_SpecRun_status(ctx, field)
+func (ec *executionContext) _FailureGroup_pattern(ctx context.Context, field graphql.CollectedField, obj *modelv2.FailureGroup) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FailureGroup_pattern(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -745,23 +831,26 @@ func (ec *executionContext) _SpecRun_status(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Status, nil
+		return obj.Pattern, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_SpecRun_status(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FailureGroup_pattern(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "SpecRun",
+		Object:     "FailureGroup",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -772,8 +861,8 @@ func (ec *executionContext) fieldContext_SpecRun_status(_ context.Context, field
 	return fc, nil
 }
 
-func (ec *executionContext) _SpecRun_message(ctx context.Context, field graphql.CollectedField, obj *modelv2.SpecRun) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_SpecRun_message(ctx, field)
+func (ec *executionContext) _FailureGroup_message(ctx context.Context, field graphql.CollectedField, obj *modelv2.FailureGroup) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FailureGroup_message(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -793,16 +882,19 @@ func (ec *executionContext) _SpecRun_message(ctx context.Context, field graphql.
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_SpecRun_message(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FailureGroup_message(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "SpecRun",
+		Object:     "FailureGroup",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -813,8 +905,8 @@ func (ec *executionContext) fieldContext_SpecRun_message(_ context.Context, fiel
 	return fc, nil
 }
 
-func (ec *executionContext) _SpecRun_startTime(ctx context.Context, field graphql.CollectedField, obj *modelv2.SpecRun) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_SpecRun_startTime(ctx, field)
+func (ec *executionContext) _FailureGroup_count(ctx context.Context, field graphql.CollectedField, obj *modelv2.FailureGroup) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FailureGroup_count(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -827,35 +919,38 @@ func (ec *executionContext) _SpecRun_startTime(ctx context.Context, field graphq
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.StartTime, nil
+		return obj.Count, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_SpecRun_startTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FailureGroup_count(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "SpecRun",
+		Object:     "FailureGroup",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _SpecRun_endTime(ctx context.Context, field graphql.CollectedField, obj *modelv2.SpecRun) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_SpecRun_endTime(ctx, field)
+func (ec *executionContext) _FailureGroup_testRuns(ctx context.Context, field graphql.CollectedField, obj *modelv2.FailureGroup) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FailureGroup_testRuns(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -868,35 +963,38 @@ func (ec *executionContext) _SpecRun_endTime(ctx context.Context, field graphql.
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.EndTime, nil
+		return obj.TestRuns, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_SpecRun_endTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FailureGroup_testRuns(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "SpecRun",
+		Object:     "FailureGroup",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _SpecRun_tags(ctx context.Context, field graphql.CollectedField, obj *modelv2.SpecRun) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_SpecRun_tags(ctx, field)
+func (ec *executionContext) _FailureGroup_firstSeen(ctx context.Context, field graphql.CollectedField, obj *modelv2.FailureGroup) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FailureGroup_firstSeen(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -909,41 +1007,38 @@ func (ec *executionContext) _SpecRun_tags(ctx context.Context, field graphql.Col
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Tags, nil
+		return obj.FirstSeen, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.([]*modelv2.Tag)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOTag2ᚕᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐTag(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_SpecRun_tags(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FailureGroup_firstSeen(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "SpecRun",
+		Object:     "FailureGroup",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Tag_id(ctx, field)
-			case "name":
-				return ec.fieldContext_Tag_name(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Tag", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _SuiteRun_id(ctx context.Context, field graphql.CollectedField, obj *modelv2.SuiteRun) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_SuiteRun_id(ctx, field)
+func (ec *executionContext) _FailureGroup_lastSeen(ctx context.Context, field graphql.CollectedField, obj *modelv2.FailureGroup) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FailureGroup_lastSeen(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -956,7 +1051,7 @@ func (ec *executionContext) _SuiteRun_id(ctx context.Context, field graphql.Coll
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.LastSeen, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -968,26 +1063,26 @@ func (ec *executionContext) _SuiteRun_id(ctx context.Context, field graphql.Coll
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_SuiteRun_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FailureGroup_lastSeen(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "SuiteRun",
+		Object:     "FailureGroup",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _SuiteRun_testRunId(ctx context.Context, field graphql.CollectedField, obj *modelv2.SuiteRun) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_SuiteRun_testRunId(ctx, field)
+func (ec *executionContext) _FailureGroup_specs(ctx context.Context, field graphql.CollectedField, obj *modelv2.FailureGroup) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FailureGroup_specs(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1000,7 +1095,7 @@ func (ec *executionContext) _SuiteRun_testRunId(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TestRunID, nil
+		return obj.Specs, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1012,26 +1107,40 @@ func (ec *executionContext) _SuiteRun_testRunId(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.([]*modelv2.FailureGroupSpec)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNFailureGroupSpec2ᚕᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐFailureGroupSpecᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_SuiteRun_testRunId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FailureGroup_specs(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "SuiteRun",
+		Object:     "FailureGroup",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			switch field.Name {
+			case "testCaseId":
+				return ec.fieldContext_FailureGroupSpec_testCaseId(ctx, field)
+			case "suiteName":
+				return ec.fieldContext_FailureGroupSpec_suiteName(ctx, field)
+			case "specDescription":
+				return ec.fieldContext_FailureGroupSpec_specDescription(ctx, field)
+			case "count":
+				return ec.fieldContext_FailureGroupSpec_count(ctx, field)
+			case "lastTestRunId":
+				return ec.fieldContext_FailureGroupSpec_lastTestRunId(ctx, field)
+			case "lastSpecRunId":
+				return ec.fieldContext_FailureGroupSpec_lastSpecRunId(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type FailureGroupSpec", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _SuiteRun_suiteName(ctx context.Context, field graphql.CollectedField, obj *modelv2.SuiteRun) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_SuiteRun_suiteName(ctx, field)
+func (ec *executionContext) _FailureGroupSpec_testCaseId(ctx context.Context, field graphql.CollectedField, obj *modelv2.FailureGroupSpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FailureGroupSpec_testCaseId(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1044,7 +1153,7 @@ func (ec *executionContext) _SuiteRun_suiteName(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.SuiteName, nil
+		return obj.TestCaseID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1053,26 +1162,26 @@ func (ec *executionContext) _SuiteRun_suiteName(ctx context.Context, field graph
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_SuiteRun_suiteName(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FailureGroupSpec_testCaseId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "SuiteRun",
+		Object:     "FailureGroupSpec",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _SuiteRun_startTime(ctx context.Context, field graphql.CollectedField, obj *modelv2.SuiteRun) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_SuiteRun_startTime(ctx, field)
+func (ec *executionContext) _FailureGroupSpec_suiteName(ctx context.Context, field graphql.CollectedField, obj *modelv2.FailureGroupSpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FailureGroupSpec_suiteName(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1085,23 +1194,26 @@ func (ec *executionContext) _SuiteRun_startTime(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.StartTime, nil
+		return obj.SuiteName, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_SuiteRun_startTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FailureGroupSpec_suiteName(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "SuiteRun",
+		Object:     "FailureGroupSpec",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -1112,8 +1224,8 @@ func (ec *executionContext) fieldContext_SuiteRun_startTime(_ context.Context, f
 	return fc, nil
 }
 
-func (ec *executionContext) _SuiteRun_endTime(ctx context.Context, field graphql.CollectedField, obj *modelv2.SuiteRun) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_SuiteRun_endTime(ctx, field)
+func (ec *executionContext) _FailureGroupSpec_specDescription(ctx context.Context, field graphql.CollectedField, obj *modelv2.FailureGroupSpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FailureGroupSpec_specDescription(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1126,23 +1238,26 @@ func (ec *executionContext) _SuiteRun_endTime(ctx context.Context, field graphql
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.EndTime, nil
+		return obj.SpecDescription, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		return graphql.Null
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_SuiteRun_endTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FailureGroupSpec_specDescription(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "SuiteRun",
+		Object:     "FailureGroupSpec",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -1153,8 +1268,8 @@ func (ec *executionContext) fieldContext_SuiteRun_endTime(_ context.Context, fie
 	return fc, nil
 }
 
-func (ec *executionContext) _SuiteRun_specRuns(ctx context.Context, field graphql.CollectedField, obj *modelv2.SuiteRun) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_SuiteRun_specRuns(ctx, field)
+func (ec *executionContext) _FailureGroupSpec_count(ctx context.Context, field graphql.CollectedField, obj *modelv2.FailureGroupSpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FailureGroupSpec_count(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1167,53 +1282,38 @@ func (ec *executionContext) _SuiteRun_specRuns(ctx context.Context, field graphq
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.SpecRuns, nil
+		return obj.Count, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.([]*modelv2.SpecRun)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalOSpecRun2ᚕᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐSpecRun(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_SuiteRun_specRuns(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FailureGroupSpec_count(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "SuiteRun",
+		Object:     "FailureGroupSpec",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_SpecRun_id(ctx, field)
-			case "suiteId":
-				return ec.fieldContext_SpecRun_suiteId(ctx, field)
-			case "specDescription":
-				return ec.fieldContext_SpecRun_specDescription(ctx, field)
-			case "status":
-				return ec.fieldContext_SpecRun_status(ctx, field)
-			case "message":
-				return ec.fieldContext_SpecRun_message(ctx, field)
-			case "startTime":
-				return ec.fieldContext_SpecRun_startTime(ctx, field)
-			case "endTime":
-				return ec.fieldContext_SpecRun_endTime(ctx, field)
-			case "tags":
-				return ec.fieldContext_SpecRun_tags(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type SpecRun", field.Name)
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Tag_id(ctx context.Context, field graphql.CollectedField, obj *modelv2.Tag) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Tag_id(ctx, field)
+func (ec *executionContext) _FailureGroupSpec_lastTestRunId(ctx context.Context, field graphql.CollectedField, obj *modelv2.FailureGroupSpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FailureGroupSpec_lastTestRunId(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1226,23 +1326,26 @@ func (ec *executionContext) _Tag_id(ctx context.Context, field graphql.Collected
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.LastTestRunID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Tag_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FailureGroupSpec_lastTestRunId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Tag",
+		Object:     "FailureGroupSpec",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -1253,8 +1356,8 @@ func (ec *executionContext) fieldContext_Tag_id(_ context.Context, field graphql
 	return fc, nil
 }
 
-func (ec *executionContext) _Tag_name(ctx context.Context, field graphql.CollectedField, obj *modelv2.Tag) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Tag_name(ctx, field)
+func (ec *executionContext) _FailureGroupSpec_lastSpecRunId(ctx context.Context, field graphql.CollectedField, obj *modelv2.FailureGroupSpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FailureGroupSpec_lastSpecRunId(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1267,35 +1370,38 @@ func (ec *executionContext) _Tag_name(ctx context.Context, field graphql.Collect
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return obj.LastSpecRunID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Tag_name(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FailureGroupSpec_lastSpecRunId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Tag",
+		Object:     "FailureGroupSpec",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _TestRun_id(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRun) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_TestRun_id(ctx, field)
+func (ec *executionContext) _FlakyEvidence_testRunId(ctx context.Context, field graphql.CollectedField, obj *modelv2.FlakyEvidence) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FlakyEvidence_testRunId(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1308,7 +1414,7 @@ func (ec *executionContext) _TestRun_id(ctx context.Context, field graphql.Colle
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.TestRunID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1325,9 +1431,9 @@ func (ec *executionContext) _TestRun_id(ctx context.Context, field graphql.Colle
 	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_TestRun_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FlakyEvidence_testRunId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "TestRun",
+		Object:     "FlakyEvidence",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -1338,8 +1444,8 @@ func (ec *executionContext) fieldContext_TestRun_id(_ context.Context, field gra
 	return fc, nil
 }
 
-func (ec *executionContext) _TestRun_testProjectName(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRun) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_TestRun_testProjectName(ctx, field)
+func (ec *executionContext) _FlakyEvidence_specRunId(ctx context.Context, field graphql.CollectedField, obj *modelv2.FlakyEvidence) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FlakyEvidence_specRunId(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1352,7 +1458,51 @@ func (ec *executionContext) _TestRun_testProjectName(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TestProjectName, nil
+		return obj.SpecRunID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_FlakyEvidence_specRunId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "FlakyEvidence",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _FlakyEvidence_status(ctx context.Context, field graphql.CollectedField, obj *modelv2.FlakyEvidence) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FlakyEvidence_status(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Status, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1366,9 +1516,9 @@ func (ec *executionContext) _TestRun_testProjectName(ctx context.Context, field
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_TestRun_testProjectName(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FlakyEvidence_status(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "TestRun",
+		Object:     "FlakyEvidence",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -1379,8 +1529,8 @@ func (ec *executionContext) fieldContext_TestRun_testProjectName(_ context.Conte
 	return fc, nil
 }
 
-func (ec *executionContext) _TestRun_testSeed(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRun) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_TestRun_testSeed(ctx, field)
+func (ec *executionContext) _FlakyEvidence_gitSha(ctx context.Context, field graphql.CollectedField, obj *modelv2.FlakyEvidence) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FlakyEvidence_gitSha(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1393,7 +1543,7 @@ func (ec *executionContext) _TestRun_testSeed(ctx context.Context, field graphql
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TestSeed, nil
+		return obj.GitSha, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1402,26 +1552,26 @@ func (ec *executionContext) _TestRun_testSeed(ctx context.Context, field graphql
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_TestRun_testSeed(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FlakyEvidence_gitSha(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "TestRun",
+		Object:     "FlakyEvidence",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _TestRun_startTime(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRun) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_TestRun_startTime(ctx, field)
+func (ec *executionContext) _FlakyEvidence_startTime(ctx context.Context, field graphql.CollectedField, obj *modelv2.FlakyEvidence) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FlakyEvidence_startTime(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1448,9 +1598,9 @@ func (ec *executionContext) _TestRun_startTime(ctx context.Context, field graphq
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_TestRun_startTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FlakyEvidence_startTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "TestRun",
+		Object:     "FlakyEvidence",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -1461,8 +1611,8 @@ func (ec *executionContext) fieldContext_TestRun_startTime(_ context.Context, fi
 	return fc, nil
 }
 
-func (ec *executionContext) _TestRun_endTime(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRun) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_TestRun_endTime(ctx, field)
+func (ec *executionContext) _FlakyEvidence_reason(ctx context.Context, field graphql.CollectedField, obj *modelv2.FlakyEvidence) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FlakyEvidence_reason(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1475,7 +1625,7 @@ func (ec *executionContext) _TestRun_endTime(ctx context.Context, field graphql.
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.EndTime, nil
+		return obj.Reason, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1489,9 +1639,9 @@ func (ec *executionContext) _TestRun_endTime(ctx context.Context, field graphql.
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_TestRun_endTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FlakyEvidence_reason(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "TestRun",
+		Object:     "FlakyEvidence",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -1502,8 +1652,8 @@ func (ec *executionContext) fieldContext_TestRun_endTime(_ context.Context, fiel
 	return fc, nil
 }
 
-func (ec *executionContext) _TestRun_suiteRuns(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRun) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_TestRun_suiteRuns(ctx, field)
+func (ec *executionContext) _FlakyEvidence_url(ctx context.Context, field graphql.CollectedField, obj *modelv2.FlakyEvidence) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FlakyEvidence_url(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1516,52 +1666,35 @@ func (ec *executionContext) _TestRun_suiteRuns(ctx context.Context, field graphq
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.SuiteRuns, nil
+		return obj.URL, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.([]*modelv2.SuiteRun)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNSuiteRun2ᚕᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐSuiteRunᚄ(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_TestRun_suiteRuns(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FlakyEvidence_url(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "TestRun",
+		Object:     "FlakyEvidence",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_SuiteRun_id(ctx, field)
-			case "testRunId":
-				return ec.fieldContext_SuiteRun_testRunId(ctx, field)
-			case "suiteName":
-				return ec.fieldContext_SuiteRun_suiteName(ctx, field)
-			case "startTime":
-				return ec.fieldContext_SuiteRun_startTime(ctx, field)
-			case "endTime":
-				return ec.fieldContext_SuiteRun_endTime(ctx, field)
-			case "specRuns":
-				return ec.fieldContext_SuiteRun_specRuns(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type SuiteRun", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _TestRunConnection_edges(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRunConnection) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_TestRunConnection_edges(ctx, field)
+func (ec *executionContext) _FlakySpec_testCaseId(ctx context.Context, field graphql.CollectedField, obj *modelv2.FlakySpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FlakySpec_testCaseId(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1574,44 +1707,35 @@ func (ec *executionContext) _TestRunConnection_edges(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Edges, nil
+		return obj.TestCaseID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.([]*modelv2.TestRunEdge)
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalNTestRunEdge2ᚕᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐTestRunEdgeᚄ(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_TestRunConnection_edges(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FlakySpec_testCaseId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "TestRunConnection",
+		Object:     "FlakySpec",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "cursor":
-				return ec.fieldContext_TestRunEdge_cursor(ctx, field)
-			case "testRun":
-				return ec.fieldContext_TestRunEdge_testRun(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type TestRunEdge", field.Name)
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _TestRunConnection_pageInfo(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRunConnection) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_TestRunConnection_pageInfo(ctx, field)
+func (ec *executionContext) _FlakySpec_suiteName(ctx context.Context, field graphql.CollectedField, obj *modelv2.FlakySpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FlakySpec_suiteName(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1624,48 +1748,35 @@ func (ec *executionContext) _TestRunConnection_pageInfo(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PageInfo, nil
+		return obj.SuiteName, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(*modelv2.PageInfo)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNPageInfo2ᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐPageInfo(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_TestRunConnection_pageInfo(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FlakySpec_suiteName(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "TestRunConnection",
+		Object:     "FlakySpec",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "hasNextPage":
-				return ec.fieldContext_PageInfo_hasNextPage(ctx, field)
-			case "hasPreviousPage":
-				return ec.fieldContext_PageInfo_hasPreviousPage(ctx, field)
-			case "startCursor":
-				return ec.fieldContext_PageInfo_startCursor(ctx, field)
-			case "endCursor":
-				return ec.fieldContext_PageInfo_endCursor(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type PageInfo", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _TestRunConnection_totalCount(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRunConnection) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_TestRunConnection_totalCount(ctx, field)
+func (ec *executionContext) _FlakySpec_specDescription(ctx context.Context, field graphql.CollectedField, obj *modelv2.FlakySpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FlakySpec_specDescription(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1678,38 +1789,35 @@ func (ec *executionContext) _TestRunConnection_totalCount(ctx context.Context, f
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TotalCount, nil
+		return obj.SpecDescription, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_TestRunConnection_totalCount(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FlakySpec_specDescription(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "TestRunConnection",
+		Object:     "FlakySpec",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _TestRunEdge_cursor(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRunEdge) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_TestRunEdge_cursor(ctx, field)
+func (ec *executionContext) _FlakySpec_gitBranch(ctx context.Context, field graphql.CollectedField, obj *modelv2.FlakySpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FlakySpec_gitBranch(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1722,26 +1830,23 @@ func (ec *executionContext) _TestRunEdge_cursor(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Cursor, nil
+		return obj.GitBranch, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_TestRunEdge_cursor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FlakySpec_gitBranch(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "TestRunEdge",
+		Object:     "FlakySpec",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -1752,8 +1857,8 @@ func (ec *executionContext) fieldContext_TestRunEdge_cursor(_ context.Context, f
 	return fc, nil
 }
 
-func (ec *executionContext) _TestRunEdge_testRun(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRunEdge) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_TestRunEdge_testRun(ctx, field)
+func (ec *executionContext) _FlakySpec_score(ctx context.Context, field graphql.CollectedField, obj *modelv2.FlakySpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FlakySpec_score(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1766,7 +1871,7 @@ func (ec *executionContext) _TestRunEdge_testRun(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TestRun, nil
+		return obj.Score, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1778,112 +1883,4239 @@ func (ec *executionContext) _TestRunEdge_testRun(ctx context.Context, field grap
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*modelv2.TestRun)
+	res := resTmp.(float64)
 	fc.Result = res
-	return ec.marshalNTestRun2ᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐTestRun(ctx, field.Selections, res)
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_TestRunEdge_testRun(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FlakySpec_score(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "TestRunEdge",
+		Object:     "FlakySpec",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_TestRun_id(ctx, field)
-			case "testProjectName":
-				return ec.fieldContext_TestRun_testProjectName(ctx, field)
-			case "testSeed":
-				return ec.fieldContext_TestRun_testSeed(ctx, field)
-			case "startTime":
-				return ec.fieldContext_TestRun_startTime(ctx, field)
-			case "endTime":
+			return nil, errors.New("field of type Float does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _FlakySpec_executions(ctx context.Context, field graphql.CollectedField, obj *modelv2.FlakySpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FlakySpec_executions(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Executions, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_FlakySpec_executions(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "FlakySpec",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _FlakySpec_failures(ctx context.Context, field graphql.CollectedField, obj *modelv2.FlakySpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FlakySpec_failures(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Failures, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_FlakySpec_failures(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "FlakySpec",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _FlakySpec_retriedPasses(ctx context.Context, field graphql.CollectedField, obj *modelv2.FlakySpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FlakySpec_retriedPasses(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.RetriedPasses, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_FlakySpec_retriedPasses(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "FlakySpec",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _FlakySpec_commitFlips(ctx context.Context, field graphql.CollectedField, obj *modelv2.FlakySpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FlakySpec_commitFlips(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.CommitFlips, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_FlakySpec_commitFlips(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "FlakySpec",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _FlakySpec_alternations(ctx context.Context, field graphql.CollectedField, obj *modelv2.FlakySpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FlakySpec_alternations(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Alternations, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_FlakySpec_alternations(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "FlakySpec",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _FlakySpec_alternationRate(ctx context.Context, field graphql.CollectedField, obj *modelv2.FlakySpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FlakySpec_alternationRate(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.AlternationRate, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	fc.Result = res
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_FlakySpec_alternationRate(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "FlakySpec",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Float does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _FlakySpec_evidence(ctx context.Context, field graphql.CollectedField, obj *modelv2.FlakySpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_FlakySpec_evidence(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Evidence, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*modelv2.FlakyEvidence)
+	fc.Result = res
+	return ec.marshalNFlakyEvidence2ᚕᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐFlakyEvidenceᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_FlakySpec_evidence(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "FlakySpec",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "testRunId":
+				return ec.fieldContext_FlakyEvidence_testRunId(ctx, field)
+			case "specRunId":
+				return ec.fieldContext_FlakyEvidence_specRunId(ctx, field)
+			case "status":
+				return ec.fieldContext_FlakyEvidence_status(ctx, field)
+			case "gitSha":
+				return ec.fieldContext_FlakyEvidence_gitSha(ctx, field)
+			case "startTime":
+				return ec.fieldContext_FlakyEvidence_startTime(ctx, field)
+			case "reason":
+				return ec.fieldContext_FlakyEvidence_reason(ctx, field)
+			case "url":
+				return ec.fieldContext_FlakyEvidence_url(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type FlakyEvidence", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _PageInfo_hasNextPage(ctx context.Context, field graphql.CollectedField, obj *modelv2.PageInfo) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PageInfo_hasNextPage(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.HasNextPage, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_PageInfo_hasNextPage(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "PageInfo",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _PageInfo_hasPreviousPage(ctx context.Context, field graphql.CollectedField, obj *modelv2.PageInfo) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PageInfo_hasPreviousPage(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.HasPreviousPage, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_PageInfo_hasPreviousPage(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "PageInfo",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _PageInfo_startCursor(ctx context.Context, field graphql.CollectedField, obj *modelv2.PageInfo) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PageInfo_startCursor(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.StartCursor, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_PageInfo_startCursor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "PageInfo",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _PageInfo_endCursor(ctx context.Context, field graphql.CollectedField, obj *modelv2.PageInfo) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PageInfo_endCursor(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.EndCursor, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_PageInfo_endCursor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "PageInfo",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _QuarantineRule_id(ctx context.Context, field graphql.CollectedField, obj *modelv2.QuarantineRule) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_QuarantineRule_id(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_QuarantineRule_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "QuarantineRule",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _QuarantineRule_testProjectName(ctx context.Context, field graphql.CollectedField, obj *modelv2.QuarantineRule) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_QuarantineRule_testProjectName(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.TestProjectName, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_QuarantineRule_testProjectName(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "QuarantineRule",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _QuarantineRule_testCaseId(ctx context.Context, field graphql.CollectedField, obj *modelv2.QuarantineRule) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_QuarantineRule_testCaseId(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.TestCaseID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*int)
+	fc.Result = res
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_QuarantineRule_testCaseId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "QuarantineRule",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _QuarantineRule_suiteName(ctx context.Context, field graphql.CollectedField, obj *modelv2.QuarantineRule) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_QuarantineRule_suiteName(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.SuiteName, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_QuarantineRule_suiteName(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "QuarantineRule",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _QuarantineRule_specDescription(ctx context.Context, field graphql.CollectedField, obj *modelv2.QuarantineRule) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_QuarantineRule_specDescription(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.SpecDescription, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_QuarantineRule_specDescription(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "QuarantineRule",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _QuarantineRule_reason(ctx context.Context, field graphql.CollectedField, obj *modelv2.QuarantineRule) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_QuarantineRule_reason(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Reason, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_QuarantineRule_reason(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "QuarantineRule",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _QuarantineRule_source(ctx context.Context, field graphql.CollectedField, obj *modelv2.QuarantineRule) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_QuarantineRule_source(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Source, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_QuarantineRule_source(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "QuarantineRule",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _QuarantineRule_score(ctx context.Context, field graphql.CollectedField, obj *modelv2.QuarantineRule) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_QuarantineRule_score(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Score, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*float64)
+	fc.Result = res
+	return ec.marshalOFloat2ᚖfloat64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_QuarantineRule_score(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "QuarantineRule",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Float does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _QuarantineRule_createdBy(ctx context.Context, field graphql.CollectedField, obj *modelv2.QuarantineRule) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_QuarantineRule_createdBy(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.CreatedBy, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_QuarantineRule_createdBy(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "QuarantineRule",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _QuarantineRule_createdAt(ctx context.Context, field graphql.CollectedField, obj *modelv2.QuarantineRule) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_QuarantineRule_createdAt(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.CreatedAt, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_QuarantineRule_createdAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "QuarantineRule",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _QuarantineRule_expiresAt(ctx context.Context, field graphql.CollectedField, obj *modelv2.QuarantineRule) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_QuarantineRule_expiresAt(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ExpiresAt, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_QuarantineRule_expiresAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "QuarantineRule",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_testRuns(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_testRuns(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().TestRuns(rctx, fc.Args["first"].(*int), fc.Args["after"].(*string), fc.Args["branch"].(*string), fc.Args["commit"].(*string), fc.Args["labelSelector"].(*string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*modelv2.TestRunConnection)
+	fc.Result = res
+	return ec.marshalNTestRunConnection2ᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐTestRunConnection(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Query_testRuns(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "edges":
+				return ec.fieldContext_TestRunConnection_edges(ctx, field)
+			case "pageInfo":
+				return ec.fieldContext_TestRunConnection_pageInfo(ctx, field)
+			case "totalCount":
+				return ec.fieldContext_TestRunConnection_totalCount(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type TestRunConnection", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_testRuns_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_testRun(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_testRun(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().TestRun(rctx, fc.Args["testRunFilter"].(modelv2.TestRunFilter))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*modelv2.TestRun)
+	fc.Result = res
+	return ec.marshalNTestRun2ᚕᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐTestRunᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Query_testRun(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_TestRun_id(ctx, field)
+			case "testProjectName":
+				return ec.fieldContext_TestRun_testProjectName(ctx, field)
+			case "testSeed":
+				return ec.fieldContext_TestRun_testSeed(ctx, field)
+			case "startTime":
+				return ec.fieldContext_TestRun_startTime(ctx, field)
+			case "endTime":
+				return ec.fieldContext_TestRun_endTime(ctx, field)
+			case "gitSha":
+				return ec.fieldContext_TestRun_gitSha(ctx, field)
+			case "gitBranch":
+				return ec.fieldContext_TestRun_gitBranch(ctx, field)
+			case "gitRepoUrl":
+				return ec.fieldContext_TestRun_gitRepoUrl(ctx, field)
+			case "pullRequestNumber":
+				return ec.fieldContext_TestRun_pullRequestNumber(ctx, field)
+			case "ciProvider":
+				return ec.fieldContext_TestRun_ciProvider(ctx, field)
+			case "buildNumber":
+				return ec.fieldContext_TestRun_buildNumber(ctx, field)
+			case "buildUrl":
+				return ec.fieldContext_TestRun_buildUrl(ctx, field)
+			case "triggeredBy":
+				return ec.fieldContext_TestRun_triggeredBy(ctx, field)
+			case "environment":
+				return ec.fieldContext_TestRun_environment(ctx, field)
+			case "labels":
+				return ec.fieldContext_TestRun_labels(ctx, field)
+			case "suiteRuns":
+				return ec.fieldContext_TestRun_suiteRuns(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type TestRun", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_testRun_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_testRunById(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_testRunById(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().TestRunByID(rctx, fc.Args["id"].(int))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*modelv2.TestRun)
+	fc.Result = res
+	return ec.marshalOTestRun2ᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐTestRun(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Query_testRunById(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_TestRun_id(ctx, field)
+			case "testProjectName":
+				return ec.fieldContext_TestRun_testProjectName(ctx, field)
+			case "testSeed":
+				return ec.fieldContext_TestRun_testSeed(ctx, field)
+			case "startTime":
+				return ec.fieldContext_TestRun_startTime(ctx, field)
+			case "endTime":
+				return ec.fieldContext_TestRun_endTime(ctx, field)
+			case "gitSha":
+				return ec.fieldContext_TestRun_gitSha(ctx, field)
+			case "gitBranch":
+				return ec.fieldContext_TestRun_gitBranch(ctx, field)
+			case "gitRepoUrl":
+				return ec.fieldContext_TestRun_gitRepoUrl(ctx, field)
+			case "pullRequestNumber":
+				return ec.fieldContext_TestRun_pullRequestNumber(ctx, field)
+			case "ciProvider":
+				return ec.fieldContext_TestRun_ciProvider(ctx, field)
+			case "buildNumber":
+				return ec.fieldContext_TestRun_buildNumber(ctx, field)
+			case "buildUrl":
+				return ec.fieldContext_TestRun_buildUrl(ctx, field)
+			case "triggeredBy":
+				return ec.fieldContext_TestRun_triggeredBy(ctx, field)
+			case "environment":
+				return ec.fieldContext_TestRun_environment(ctx, field)
+			case "labels":
+				return ec.fieldContext_TestRun_labels(ctx, field)
+			case "suiteRuns":
+				return ec.fieldContext_TestRun_suiteRuns(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type TestRun", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_testRunById_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_flakySpecs(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_flakySpecs(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().FlakySpecs(rctx, fc.Args["project"].(string), fc.Args["branch"].(*string), fc.Args["startTime"].(*string), fc.Args["endTime"].(*string), fc.Args["minExecutions"].(*int), fc.Args["limit"].(*int))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*modelv2.FlakySpec)
+	fc.Result = res
+	return ec.marshalNFlakySpec2ᚕᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐFlakySpecᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Query_flakySpecs(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "testCaseId":
+				return ec.fieldContext_FlakySpec_testCaseId(ctx, field)
+			case "suiteName":
+				return ec.fieldContext_FlakySpec_suiteName(ctx, field)
+			case "specDescription":
+				return ec.fieldContext_FlakySpec_specDescription(ctx, field)
+			case "gitBranch":
+				return ec.fieldContext_FlakySpec_gitBranch(ctx, field)
+			case "score":
+				return ec.fieldContext_FlakySpec_score(ctx, field)
+			case "executions":
+				return ec.fieldContext_FlakySpec_executions(ctx, field)
+			case "failures":
+				return ec.fieldContext_FlakySpec_failures(ctx, field)
+			case "retriedPasses":
+				return ec.fieldContext_FlakySpec_retriedPasses(ctx, field)
+			case "commitFlips":
+				return ec.fieldContext_FlakySpec_commitFlips(ctx, field)
+			case "alternations":
+				return ec.fieldContext_FlakySpec_alternations(ctx, field)
+			case "alternationRate":
+				return ec.fieldContext_FlakySpec_alternationRate(ctx, field)
+			case "evidence":
+				return ec.fieldContext_FlakySpec_evidence(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type FlakySpec", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_flakySpecs_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_quarantineRules(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_quarantineRules(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().QuarantineRules(rctx, fc.Args["project"].(string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*modelv2.QuarantineRule)
+	fc.Result = res
+	return ec.marshalNQuarantineRule2ᚕᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐQuarantineRuleᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Query_quarantineRules(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_QuarantineRule_id(ctx, field)
+			case "testProjectName":
+				return ec.fieldContext_QuarantineRule_testProjectName(ctx, field)
+			case "testCaseId":
+				return ec.fieldContext_QuarantineRule_testCaseId(ctx, field)
+			case "suiteName":
+				return ec.fieldContext_QuarantineRule_suiteName(ctx, field)
+			case "specDescription":
+				return ec.fieldContext_QuarantineRule_specDescription(ctx, field)
+			case "reason":
+				return ec.fieldContext_QuarantineRule_reason(ctx, field)
+			case "source":
+				return ec.fieldContext_QuarantineRule_source(ctx, field)
+			case "score":
+				return ec.fieldContext_QuarantineRule_score(ctx, field)
+			case "createdBy":
+				return ec.fieldContext_QuarantineRule_createdBy(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_QuarantineRule_createdAt(ctx, field)
+			case "expiresAt":
+				return ec.fieldContext_QuarantineRule_expiresAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type QuarantineRule", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_quarantineRules_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_failureGroups(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_failureGroups(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().FailureGroups(rctx, fc.Args["project"].(string), fc.Args["branch"].(*string), fc.Args["startTime"].(*string), fc.Args["endTime"].(*string), fc.Args["limit"].(*int))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*modelv2.FailureGroup)
+	fc.Result = res
+	return ec.marshalNFailureGroup2ᚕᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐFailureGroupᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Query_failureGroups(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "fingerprint":
+				return ec.fieldContext_FailureGroup_fingerprint(ctx, field)
+			case "pattern":
+				return ec.fieldContext_FailureGroup_pattern(ctx, field)
+			case "message":
+				return ec.fieldContext_FailureGroup_message(ctx, field)
+			case "count":
+				return ec.fieldContext_FailureGroup_count(ctx, field)
+			case "testRuns":
+				return ec.fieldContext_FailureGroup_testRuns(ctx, field)
+			case "firstSeen":
+				return ec.fieldContext_FailureGroup_firstSeen(ctx, field)
+			case "lastSeen":
+				return ec.fieldContext_FailureGroup_lastSeen(ctx, field)
+			case "specs":
+				return ec.fieldContext_FailureGroup_specs(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type FailureGroup", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_failureGroups_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_testRunFailureGroups(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_testRunFailureGroups(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().TestRunFailureGroups(rctx, fc.Args["testRunId"].(int))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*modelv2.FailureGroup)
+	fc.Result = res
+	return ec.marshalNFailureGroup2ᚕᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐFailureGroupᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Query_testRunFailureGroups(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "fingerprint":
+				return ec.fieldContext_FailureGroup_fingerprint(ctx, field)
+			case "pattern":
+				return ec.fieldContext_FailureGroup_pattern(ctx, field)
+			case "message":
+				return ec.fieldContext_FailureGroup_message(ctx, field)
+			case "count":
+				return ec.fieldContext_FailureGroup_count(ctx, field)
+			case "testRuns":
+				return ec.fieldContext_FailureGroup_testRuns(ctx, field)
+			case "firstSeen":
+				return ec.fieldContext_FailureGroup_firstSeen(ctx, field)
+			case "lastSeen":
+				return ec.fieldContext_FailureGroup_lastSeen(ctx, field)
+			case "specs":
+				return ec.fieldContext_FailureGroup_specs(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type FailureGroup", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_testRunFailureGroups_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query___type(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.introspectType(fc.Args["name"].(string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*introspection.Type)
+	fc.Result = res
+	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Query___type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "kind":
+				return ec.fieldContext___Type_kind(ctx, field)
+			case "name":
+				return ec.fieldContext___Type_name(ctx, field)
+			case "description":
+				return ec.fieldContext___Type_description(ctx, field)
+			case "fields":
+				return ec.fieldContext___Type_fields(ctx, field)
+			case "interfaces":
+				return ec.fieldContext___Type_interfaces(ctx, field)
+			case "possibleTypes":
+				return ec.fieldContext___Type_possibleTypes(ctx, field)
+			case "enumValues":
+				return ec.fieldContext___Type_enumValues(ctx, field)
+			case "inputFields":
+				return ec.fieldContext___Type_inputFields(ctx, field)
+			case "ofType":
+				return ec.fieldContext___Type_ofType(ctx, field)
+			case "specifiedByURL":
+				return ec.fieldContext___Type_specifiedByURL(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query___type_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query___schema(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.introspectSchema()
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*introspection.Schema)
+	fc.Result = res
+	return ec.marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Query___schema(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "description":
+				return ec.fieldContext___Schema_description(ctx, field)
+			case "types":
+				return ec.fieldContext___Schema_types(ctx, field)
+			case "queryType":
+				return ec.fieldContext___Schema_queryType(ctx, field)
+			case "mutationType":
+				return ec.fieldContext___Schema_mutationType(ctx, field)
+			case "subscriptionType":
+				return ec.fieldContext___Schema_subscriptionType(ctx, field)
+			case "directives":
+				return ec.fieldContext___Schema_directives(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type __Schema", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SpecRun_id(ctx context.Context, field graphql.CollectedField, obj *modelv2.SpecRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SpecRun_id(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*int)
+	fc.Result = res
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SpecRun_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SpecRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SpecRun_suiteId(ctx context.Context, field graphql.CollectedField, obj *modelv2.SpecRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SpecRun_suiteId(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.SuiteID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*int)
+	fc.Result = res
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SpecRun_suiteId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SpecRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SpecRun_specDescription(ctx context.Context, field graphql.CollectedField, obj *modelv2.SpecRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SpecRun_specDescription(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.SpecDescription, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SpecRun_specDescription(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SpecRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SpecRun_status(ctx context.Context, field graphql.CollectedField, obj *modelv2.SpecRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SpecRun_status(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Status, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SpecRun_status(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SpecRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SpecRun_message(ctx context.Context, field graphql.CollectedField, obj *modelv2.SpecRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SpecRun_message(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Message, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SpecRun_message(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SpecRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SpecRun_startTime(ctx context.Context, field graphql.CollectedField, obj *modelv2.SpecRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SpecRun_startTime(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.StartTime, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SpecRun_startTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SpecRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SpecRun_endTime(ctx context.Context, field graphql.CollectedField, obj *modelv2.SpecRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SpecRun_endTime(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.EndTime, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SpecRun_endTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SpecRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SpecRun_labels(ctx context.Context, field graphql.CollectedField, obj *modelv2.SpecRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SpecRun_labels(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Labels, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.Labels)
+	fc.Result = res
+	return ec.marshalOLabels2githubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋmodelsᚐLabels(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SpecRun_labels(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SpecRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Labels does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SpecRun_containers(ctx context.Context, field graphql.CollectedField, obj *modelv2.SpecRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SpecRun_containers(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Containers, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.Containers)
+	fc.Result = res
+	return ec.marshalOContainers2githubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋmodelsᚐContainers(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SpecRun_containers(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SpecRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Containers does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SpecRun_failure(ctx context.Context, field graphql.CollectedField, obj *modelv2.SpecRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SpecRun_failure(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Failure, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.Failure)
+	fc.Result = res
+	return ec.marshalOFailure2ᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋmodelsᚐFailure(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SpecRun_failure(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SpecRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "message":
+				return ec.fieldContext_Failure_message(ctx, field)
+			case "kind":
+				return ec.fieldContext_Failure_kind(ctx, field)
+			case "file":
+				return ec.fieldContext_Failure_file(ctx, field)
+			case "line":
+				return ec.fieldContext_Failure_line(ctx, field)
+			case "stackTrace":
+				return ec.fieldContext_Failure_stackTrace(ctx, field)
+			case "stdout":
+				return ec.fieldContext_Failure_stdout(ctx, field)
+			case "stderr":
+				return ec.fieldContext_Failure_stderr(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Failure", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SpecRun_attempts(ctx context.Context, field graphql.CollectedField, obj *modelv2.SpecRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SpecRun_attempts(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Attempts, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.SpecAttempts)
+	fc.Result = res
+	return ec.marshalOAttempts2githubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋmodelsᚐSpecAttempts(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SpecRun_attempts(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SpecRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Attempts does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SpecRun_tags(ctx context.Context, field graphql.CollectedField, obj *modelv2.SpecRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SpecRun_tags(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Tags, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*modelv2.Tag)
+	fc.Result = res
+	return ec.marshalOTag2ᚕᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐTag(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SpecRun_tags(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SpecRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Tag_id(ctx, field)
+			case "name":
+				return ec.fieldContext_Tag_name(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Tag", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SpecRun_testCaseId(ctx context.Context, field graphql.CollectedField, obj *modelv2.SpecRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SpecRun_testCaseId(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.TestCaseID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*int)
+	fc.Result = res
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SpecRun_testCaseId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SpecRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SpecRun_owner(ctx context.Context, field graphql.CollectedField, obj *modelv2.SpecRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SpecRun_owner(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Owner, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SpecRun_owner(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SpecRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SpecRun_quarantined(ctx context.Context, field graphql.CollectedField, obj *modelv2.SpecRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SpecRun_quarantined(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Quarantined, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*bool)
+	fc.Result = res
+	return ec.marshalOBoolean2ᚖbool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SpecRun_quarantined(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SpecRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SpecRun_classification(ctx context.Context, field graphql.CollectedField, obj *modelv2.SpecRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SpecRun_classification(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Classification, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*modelv2.FailureClassification)
+	fc.Result = res
+	return ec.marshalOFailureClassification2ᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐFailureClassification(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SpecRun_classification(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SpecRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "kind":
+				return ec.fieldContext_FailureClassification_kind(ctx, field)
+			case "failingSince":
+				return ec.fieldContext_FailureClassification_failingSince(ctx, field)
+			case "failingSinceTestRunId":
+				return ec.fieldContext_FailureClassification_failingSinceTestRunId(ctx, field)
+			case "failingSinceGitSha":
+				return ec.fieldContext_FailureClassification_failingSinceGitSha(ctx, field)
+			case "previousTestRunId":
+				return ec.fieldContext_FailureClassification_previousTestRunId(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type FailureClassification", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SuiteRun_id(ctx context.Context, field graphql.CollectedField, obj *modelv2.SuiteRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SuiteRun_id(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SuiteRun_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SuiteRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SuiteRun_testRunId(ctx context.Context, field graphql.CollectedField, obj *modelv2.SuiteRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SuiteRun_testRunId(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.TestRunID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SuiteRun_testRunId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SuiteRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SuiteRun_suiteName(ctx context.Context, field graphql.CollectedField, obj *modelv2.SuiteRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SuiteRun_suiteName(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.SuiteName, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SuiteRun_suiteName(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SuiteRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SuiteRun_startTime(ctx context.Context, field graphql.CollectedField, obj *modelv2.SuiteRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SuiteRun_startTime(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.StartTime, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SuiteRun_startTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SuiteRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SuiteRun_endTime(ctx context.Context, field graphql.CollectedField, obj *modelv2.SuiteRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SuiteRun_endTime(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.EndTime, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SuiteRun_endTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SuiteRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SuiteRun_labels(ctx context.Context, field graphql.CollectedField, obj *modelv2.SuiteRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SuiteRun_labels(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Labels, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.Labels)
+	fc.Result = res
+	return ec.marshalOLabels2githubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋmodelsᚐLabels(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SuiteRun_labels(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SuiteRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Labels does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SuiteRun_specRuns(ctx context.Context, field graphql.CollectedField, obj *modelv2.SuiteRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SuiteRun_specRuns(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.SpecRuns, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*modelv2.SpecRun)
+	fc.Result = res
+	return ec.marshalOSpecRun2ᚕᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐSpecRun(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SuiteRun_specRuns(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SuiteRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_SpecRun_id(ctx, field)
+			case "suiteId":
+				return ec.fieldContext_SpecRun_suiteId(ctx, field)
+			case "specDescription":
+				return ec.fieldContext_SpecRun_specDescription(ctx, field)
+			case "status":
+				return ec.fieldContext_SpecRun_status(ctx, field)
+			case "message":
+				return ec.fieldContext_SpecRun_message(ctx, field)
+			case "startTime":
+				return ec.fieldContext_SpecRun_startTime(ctx, field)
+			case "endTime":
+				return ec.fieldContext_SpecRun_endTime(ctx, field)
+			case "labels":
+				return ec.fieldContext_SpecRun_labels(ctx, field)
+			case "containers":
+				return ec.fieldContext_SpecRun_containers(ctx, field)
+			case "failure":
+				return ec.fieldContext_SpecRun_failure(ctx, field)
+			case "attempts":
+				return ec.fieldContext_SpecRun_attempts(ctx, field)
+			case "tags":
+				return ec.fieldContext_SpecRun_tags(ctx, field)
+			case "testCaseId":
+				return ec.fieldContext_SpecRun_testCaseId(ctx, field)
+			case "owner":
+				return ec.fieldContext_SpecRun_owner(ctx, field)
+			case "quarantined":
+				return ec.fieldContext_SpecRun_quarantined(ctx, field)
+			case "classification":
+				return ec.fieldContext_SpecRun_classification(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type SpecRun", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Tag_id(ctx context.Context, field graphql.CollectedField, obj *modelv2.Tag) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Tag_id(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*int)
+	fc.Result = res
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Tag_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Tag",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Tag_name(ctx context.Context, field graphql.CollectedField, obj *modelv2.Tag) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Tag_name(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Name, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Tag_name(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Tag",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TestRun_id(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TestRun_id(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TestRun_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TestRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TestRun_testProjectName(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TestRun_testProjectName(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.TestProjectName, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TestRun_testProjectName(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TestRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TestRun_testSeed(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TestRun_testSeed(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.TestSeed, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*int)
+	fc.Result = res
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TestRun_testSeed(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TestRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TestRun_startTime(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TestRun_startTime(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.StartTime, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TestRun_startTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TestRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TestRun_endTime(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TestRun_endTime(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.EndTime, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TestRun_endTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TestRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TestRun_gitSha(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TestRun_gitSha(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.GitSha, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TestRun_gitSha(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TestRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TestRun_gitBranch(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TestRun_gitBranch(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.GitBranch, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TestRun_gitBranch(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TestRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TestRun_gitRepoUrl(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TestRun_gitRepoUrl(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.GitRepoURL, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TestRun_gitRepoUrl(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TestRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TestRun_pullRequestNumber(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TestRun_pullRequestNumber(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.PullRequestNumber, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*int)
+	fc.Result = res
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TestRun_pullRequestNumber(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TestRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TestRun_ciProvider(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TestRun_ciProvider(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.CiProvider, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TestRun_ciProvider(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TestRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TestRun_buildNumber(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TestRun_buildNumber(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.BuildNumber, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TestRun_buildNumber(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TestRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TestRun_buildUrl(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TestRun_buildUrl(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.BuildURL, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TestRun_buildUrl(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TestRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TestRun_triggeredBy(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TestRun_triggeredBy(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.TriggeredBy, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TestRun_triggeredBy(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TestRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TestRun_environment(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TestRun_environment(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Environment, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TestRun_environment(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TestRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TestRun_labels(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TestRun_labels(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Labels, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.Labels)
+	fc.Result = res
+	return ec.marshalOLabels2githubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋmodelsᚐLabels(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TestRun_labels(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TestRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Labels does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TestRun_suiteRuns(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRun) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TestRun_suiteRuns(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.SuiteRuns, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*modelv2.SuiteRun)
+	fc.Result = res
+	return ec.marshalNSuiteRun2ᚕᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐSuiteRunᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TestRun_suiteRuns(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TestRun",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_SuiteRun_id(ctx, field)
+			case "testRunId":
+				return ec.fieldContext_SuiteRun_testRunId(ctx, field)
+			case "suiteName":
+				return ec.fieldContext_SuiteRun_suiteName(ctx, field)
+			case "startTime":
+				return ec.fieldContext_SuiteRun_startTime(ctx, field)
+			case "endTime":
+				return ec.fieldContext_SuiteRun_endTime(ctx, field)
+			case "labels":
+				return ec.fieldContext_SuiteRun_labels(ctx, field)
+			case "specRuns":
+				return ec.fieldContext_SuiteRun_specRuns(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type SuiteRun", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TestRunConnection_edges(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRunConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TestRunConnection_edges(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Edges, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*modelv2.TestRunEdge)
+	fc.Result = res
+	return ec.marshalNTestRunEdge2ᚕᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐTestRunEdgeᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TestRunConnection_edges(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TestRunConnection",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "cursor":
+				return ec.fieldContext_TestRunEdge_cursor(ctx, field)
+			case "testRun":
+				return ec.fieldContext_TestRunEdge_testRun(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type TestRunEdge", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TestRunConnection_pageInfo(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRunConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TestRunConnection_pageInfo(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.PageInfo, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*modelv2.PageInfo)
+	fc.Result = res
+	return ec.marshalNPageInfo2ᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐPageInfo(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TestRunConnection_pageInfo(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TestRunConnection",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "hasNextPage":
+				return ec.fieldContext_PageInfo_hasNextPage(ctx, field)
+			case "hasPreviousPage":
+				return ec.fieldContext_PageInfo_hasPreviousPage(ctx, field)
+			case "startCursor":
+				return ec.fieldContext_PageInfo_startCursor(ctx, field)
+			case "endCursor":
+				return ec.fieldContext_PageInfo_endCursor(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PageInfo", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TestRunConnection_totalCount(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRunConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TestRunConnection_totalCount(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.TotalCount, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TestRunConnection_totalCount(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TestRunConnection",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TestRunEdge_cursor(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRunEdge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TestRunEdge_cursor(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Cursor, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TestRunEdge_cursor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TestRunEdge",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TestRunEdge_testRun(ctx context.Context, field graphql.CollectedField, obj *modelv2.TestRunEdge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TestRunEdge_testRun(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.TestRun, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*modelv2.TestRun)
+	fc.Result = res
+	return ec.marshalNTestRun2ᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐTestRun(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TestRunEdge_testRun(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TestRunEdge",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_TestRun_id(ctx, field)
+			case "testProjectName":
+				return ec.fieldContext_TestRun_testProjectName(ctx, field)
+			case "testSeed":
+				return ec.fieldContext_TestRun_testSeed(ctx, field)
+			case "startTime":
+				return ec.fieldContext_TestRun_startTime(ctx, field)
+			case "endTime":
 				return ec.fieldContext_TestRun_endTime(ctx, field)
+			case "gitSha":
+				return ec.fieldContext_TestRun_gitSha(ctx, field)
+			case "gitBranch":
+				return ec.fieldContext_TestRun_gitBranch(ctx, field)
+			case "gitRepoUrl":
+				return ec.fieldContext_TestRun_gitRepoUrl(ctx, field)
+			case "pullRequestNumber":
+				return ec.fieldContext_TestRun_pullRequestNumber(ctx, field)
+			case "ciProvider":
+				return ec.fieldContext_TestRun_ciProvider(ctx, field)
+			case "buildNumber":
+				return ec.fieldContext_TestRun_buildNumber(ctx, field)
+			case "buildUrl":
+				return ec.fieldContext_TestRun_buildUrl(ctx, field)
+			case "triggeredBy":
+				return ec.fieldContext_TestRun_triggeredBy(ctx, field)
+			case "environment":
+				return ec.fieldContext_TestRun_environment(ctx, field)
+			case "labels":
+				return ec.fieldContext_TestRun_labels(ctx, field)
 			case "suiteRuns":
 				return ec.fieldContext_TestRun_suiteRuns(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type TestRun", field.Name)
-		},
+			return nil, fmt.Errorf("no field named %q was found under type TestRun", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+// endregion **************************** field.gotpl *****************************
+
+// region    **************************** input.gotpl *****************************
+
+func (ec *executionContext) unmarshalInputTestRunFilter(ctx context.Context, obj interface{}) (modelv2.TestRunFilter, error) {
+	var it modelv2.TestRunFilter
+	asMap := map[string]interface{}{}
+	for k, v := range obj.(map[string]interface{}) {
+		asMap[k] = v
+	}
+
+	fieldsInOrder := [...]string{"id", "testProjectName"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "id":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+			data, err := ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ID = data
+		case "testProjectName":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("testProjectName"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.TestProjectName = data
+		}
+	}
+
+	return it, nil
+}
+
+// endregion **************************** input.gotpl *****************************
+
+// region    ************************** interface.gotpl ***************************
+
+// endregion ************************** interface.gotpl ***************************
+
+// region    **************************** object.gotpl ****************************
+
+var failureImplementors = []string{"Failure"}
+
+func (ec *executionContext) _Failure(ctx context.Context, sel ast.SelectionSet, obj *models.Failure) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, failureImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Failure")
+		case "message":
+			out.Values[i] = ec._Failure_message(ctx, field, obj)
+		case "kind":
+			out.Values[i] = ec._Failure_kind(ctx, field, obj)
+		case "file":
+			out.Values[i] = ec._Failure_file(ctx, field, obj)
+		case "line":
+			out.Values[i] = ec._Failure_line(ctx, field, obj)
+		case "stackTrace":
+			out.Values[i] = ec._Failure_stackTrace(ctx, field, obj)
+		case "stdout":
+			out.Values[i] = ec._Failure_stdout(ctx, field, obj)
+		case "stderr":
+			out.Values[i] = ec._Failure_stderr(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var failureClassificationImplementors = []string{"FailureClassification"}
+
+func (ec *executionContext) _FailureClassification(ctx context.Context, sel ast.SelectionSet, obj *modelv2.FailureClassification) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, failureClassificationImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("FailureClassification")
+		case "kind":
+			out.Values[i] = ec._FailureClassification_kind(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "failingSince":
+			out.Values[i] = ec._FailureClassification_failingSince(ctx, field, obj)
+		case "failingSinceTestRunId":
+			out.Values[i] = ec._FailureClassification_failingSinceTestRunId(ctx, field, obj)
+		case "failingSinceGitSha":
+			out.Values[i] = ec._FailureClassification_failingSinceGitSha(ctx, field, obj)
+		case "previousTestRunId":
+			out.Values[i] = ec._FailureClassification_previousTestRunId(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var failureGroupImplementors = []string{"FailureGroup"}
+
+func (ec *executionContext) _FailureGroup(ctx context.Context, sel ast.SelectionSet, obj *modelv2.FailureGroup) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, failureGroupImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("FailureGroup")
+		case "fingerprint":
+			out.Values[i] = ec._FailureGroup_fingerprint(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "pattern":
+			out.Values[i] = ec._FailureGroup_pattern(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "message":
+			out.Values[i] = ec._FailureGroup_message(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "count":
+			out.Values[i] = ec._FailureGroup_count(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "testRuns":
+			out.Values[i] = ec._FailureGroup_testRuns(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "firstSeen":
+			out.Values[i] = ec._FailureGroup_firstSeen(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "lastSeen":
+			out.Values[i] = ec._FailureGroup_lastSeen(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "specs":
+			out.Values[i] = ec._FailureGroup_specs(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
 	}
-	return fc, nil
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
 }
 
-// endregion **************************** field.gotpl *****************************
+var failureGroupSpecImplementors = []string{"FailureGroupSpec"}
 
-// region    **************************** input.gotpl *****************************
+func (ec *executionContext) _FailureGroupSpec(ctx context.Context, sel ast.SelectionSet, obj *modelv2.FailureGroupSpec) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, failureGroupSpecImplementors)
 
-func (ec *executionContext) unmarshalInputTestRunFilter(ctx context.Context, obj interface{}) (modelv2.TestRunFilter, error) {
-	var it modelv2.TestRunFilter
-	asMap := map[string]interface{}{}
-	for k, v := range obj.(map[string]interface{}) {
-		asMap[k] = v
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("FailureGroupSpec")
+		case "testCaseId":
+			out.Values[i] = ec._FailureGroupSpec_testCaseId(ctx, field, obj)
+		case "suiteName":
+			out.Values[i] = ec._FailureGroupSpec_suiteName(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "specDescription":
+			out.Values[i] = ec._FailureGroupSpec_specDescription(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "count":
+			out.Values[i] = ec._FailureGroupSpec_count(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "lastTestRunId":
+			out.Values[i] = ec._FailureGroupSpec_lastTestRunId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "lastSpecRunId":
+			out.Values[i] = ec._FailureGroupSpec_lastSpecRunId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
 	}
 
-	fieldsInOrder := [...]string{"id", "testProjectName"}
-	for _, k := range fieldsInOrder {
-		v, ok := asMap[k]
-		if !ok {
-			continue
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var flakyEvidenceImplementors = []string{"FlakyEvidence"}
+
+func (ec *executionContext) _FlakyEvidence(ctx context.Context, sel ast.SelectionSet, obj *modelv2.FlakyEvidence) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, flakyEvidenceImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("FlakyEvidence")
+		case "testRunId":
+			out.Values[i] = ec._FlakyEvidence_testRunId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "specRunId":
+			out.Values[i] = ec._FlakyEvidence_specRunId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "status":
+			out.Values[i] = ec._FlakyEvidence_status(ctx, field, obj)
+		case "gitSha":
+			out.Values[i] = ec._FlakyEvidence_gitSha(ctx, field, obj)
+		case "startTime":
+			out.Values[i] = ec._FlakyEvidence_startTime(ctx, field, obj)
+		case "reason":
+			out.Values[i] = ec._FlakyEvidence_reason(ctx, field, obj)
+		case "url":
+			out.Values[i] = ec._FlakyEvidence_url(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
 		}
-		switch k {
-		case "id":
-			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
-			data, err := ec.unmarshalOInt2ᚖint(ctx, v)
-			if err != nil {
-				return it, err
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var flakySpecImplementors = []string{"FlakySpec"}
+
+func (ec *executionContext) _FlakySpec(ctx context.Context, sel ast.SelectionSet, obj *modelv2.FlakySpec) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, flakySpecImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("FlakySpec")
+		case "testCaseId":
+			out.Values[i] = ec._FlakySpec_testCaseId(ctx, field, obj)
+		case "suiteName":
+			out.Values[i] = ec._FlakySpec_suiteName(ctx, field, obj)
+		case "specDescription":
+			out.Values[i] = ec._FlakySpec_specDescription(ctx, field, obj)
+		case "gitBranch":
+			out.Values[i] = ec._FlakySpec_gitBranch(ctx, field, obj)
+		case "score":
+			out.Values[i] = ec._FlakySpec_score(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
 			}
-			it.ID = data
-		case "testProjectName":
-			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("testProjectName"))
-			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
-			if err != nil {
-				return it, err
+		case "executions":
+			out.Values[i] = ec._FlakySpec_executions(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
 			}
-			it.TestProjectName = data
+		case "failures":
+			out.Values[i] = ec._FlakySpec_failures(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "retriedPasses":
+			out.Values[i] = ec._FlakySpec_retriedPasses(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "commitFlips":
+			out.Values[i] = ec._FlakySpec_commitFlips(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "alternations":
+			out.Values[i] = ec._FlakySpec_alternations(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "alternationRate":
+			out.Values[i] = ec._FlakySpec_alternationRate(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "evidence":
+			out.Values[i] = ec._FlakySpec_evidence(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
 		}
 	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
 
-	return it, nil
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
 }
 
-// endregion **************************** input.gotpl *****************************
+var pageInfoImplementors = []string{"PageInfo"}
+
+func (ec *executionContext) _PageInfo(ctx context.Context, sel ast.SelectionSet, obj *modelv2.PageInfo) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, pageInfoImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("PageInfo")
+		case "hasNextPage":
+			out.Values[i] = ec._PageInfo_hasNextPage(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "hasPreviousPage":
+			out.Values[i] = ec._PageInfo_hasPreviousPage(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "startCursor":
+			out.Values[i] = ec._PageInfo_startCursor(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "endCursor":
+			out.Values[i] = ec._PageInfo_endCursor(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
 
-// region    ************************** interface.gotpl ***************************
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
 
-// endregion ************************** interface.gotpl ***************************
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
 
-// region    **************************** object.gotpl ****************************
+	return out
+}
 
-var pageInfoImplementors = []string{"PageInfo"}
+var quarantineRuleImplementors = []string{"QuarantineRule"}
 
-func (ec *executionContext) _PageInfo(ctx context.Context, sel ast.SelectionSet, obj *modelv2.PageInfo) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, pageInfoImplementors)
+func (ec *executionContext) _QuarantineRule(ctx context.Context, sel ast.SelectionSet, obj *modelv2.QuarantineRule) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, quarantineRuleImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	deferred := make(map[string]*graphql.FieldSet)
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("PageInfo")
-		case "hasNextPage":
-			out.Values[i] = ec._PageInfo_hasNextPage(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("QuarantineRule")
+		case "id":
+			out.Values[i] = ec._QuarantineRule_id(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "hasPreviousPage":
-			out.Values[i] = ec._PageInfo_hasPreviousPage(ctx, field, obj)
+		case "testProjectName":
+			out.Values[i] = ec._QuarantineRule_testProjectName(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "startCursor":
-			out.Values[i] = ec._PageInfo_startCursor(ctx, field, obj)
+		case "testCaseId":
+			out.Values[i] = ec._QuarantineRule_testCaseId(ctx, field, obj)
+		case "suiteName":
+			out.Values[i] = ec._QuarantineRule_suiteName(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "endCursor":
-			out.Values[i] = ec._PageInfo_endCursor(ctx, field, obj)
+		case "specDescription":
+			out.Values[i] = ec._QuarantineRule_specDescription(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "reason":
+			out.Values[i] = ec._QuarantineRule_reason(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "source":
+			out.Values[i] = ec._QuarantineRule_source(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "score":
+			out.Values[i] = ec._QuarantineRule_score(ctx, field, obj)
+		case "createdBy":
+			out.Values[i] = ec._QuarantineRule_createdBy(ctx, field, obj)
+		case "createdAt":
+			out.Values[i] = ec._QuarantineRule_createdAt(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "expiresAt":
+			out.Values[i] = ec._QuarantineRule_expiresAt(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
@@ -1991,15 +6223,261 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
 			}
 
-			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
-		case "__type":
-			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._Query___type(ctx, field)
-			})
-		case "__schema":
-			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._Query___schema(ctx, field)
-			})
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "flakySpecs":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_flakySpecs(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "quarantineRules":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_quarantineRules(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "failureGroups":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_failureGroups(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "testRunFailureGroups":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_testRunFailureGroups(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "__type":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Query___type(ctx, field)
+			})
+		case "__schema":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Query___schema(ctx, field)
+			})
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var specRunImplementors = []string{"SpecRun"}
+
+func (ec *executionContext) _SpecRun(ctx context.Context, sel ast.SelectionSet, obj *modelv2.SpecRun) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, specRunImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("SpecRun")
+		case "id":
+			out.Values[i] = ec._SpecRun_id(ctx, field, obj)
+		case "suiteId":
+			out.Values[i] = ec._SpecRun_suiteId(ctx, field, obj)
+		case "specDescription":
+			out.Values[i] = ec._SpecRun_specDescription(ctx, field, obj)
+		case "status":
+			out.Values[i] = ec._SpecRun_status(ctx, field, obj)
+		case "message":
+			out.Values[i] = ec._SpecRun_message(ctx, field, obj)
+		case "startTime":
+			out.Values[i] = ec._SpecRun_startTime(ctx, field, obj)
+		case "endTime":
+			out.Values[i] = ec._SpecRun_endTime(ctx, field, obj)
+		case "labels":
+			out.Values[i] = ec._SpecRun_labels(ctx, field, obj)
+		case "containers":
+			out.Values[i] = ec._SpecRun_containers(ctx, field, obj)
+		case "failure":
+			out.Values[i] = ec._SpecRun_failure(ctx, field, obj)
+		case "attempts":
+			out.Values[i] = ec._SpecRun_attempts(ctx, field, obj)
+		case "tags":
+			out.Values[i] = ec._SpecRun_tags(ctx, field, obj)
+		case "testCaseId":
+			out.Values[i] = ec._SpecRun_testCaseId(ctx, field, obj)
+		case "owner":
+			out.Values[i] = ec._SpecRun_owner(ctx, field, obj)
+		case "quarantined":
+			out.Values[i] = ec._SpecRun_quarantined(ctx, field, obj)
+		case "classification":
+			out.Values[i] = ec._SpecRun_classification(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var suiteRunImplementors = []string{"SuiteRun"}
+
+func (ec *executionContext) _SuiteRun(ctx context.Context, sel ast.SelectionSet, obj *modelv2.SuiteRun) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, suiteRunImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("SuiteRun")
+		case "id":
+			out.Values[i] = ec._SuiteRun_id(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "testRunId":
+			out.Values[i] = ec._SuiteRun_testRunId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "suiteName":
+			out.Values[i] = ec._SuiteRun_suiteName(ctx, field, obj)
+		case "startTime":
+			out.Values[i] = ec._SuiteRun_startTime(ctx, field, obj)
+		case "endTime":
+			out.Values[i] = ec._SuiteRun_endTime(ctx, field, obj)
+		case "labels":
+			out.Values[i] = ec._SuiteRun_labels(ctx, field, obj)
+		case "specRuns":
+			out.Values[i] = ec._SuiteRun_specRuns(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var tagImplementors = []string{"Tag"}
+
+func (ec *executionContext) _Tag(ctx context.Context, sel ast.SelectionSet, obj *modelv2.Tag) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, tagImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Tag")
+		case "id":
+			out.Values[i] = ec._Tag_id(ctx, field, obj)
+		case "name":
+			out.Values[i] = ec._Tag_name(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -2023,33 +6501,55 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 	return out
 }
 
-var specRunImplementors = []string{"SpecRun"}
+var testRunImplementors = []string{"TestRun"}
 
-func (ec *executionContext) _SpecRun(ctx context.Context, sel ast.SelectionSet, obj *modelv2.SpecRun) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, specRunImplementors)
+func (ec *executionContext) _TestRun(ctx context.Context, sel ast.SelectionSet, obj *modelv2.TestRun) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, testRunImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	deferred := make(map[string]*graphql.FieldSet)
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("SpecRun")
+			out.Values[i] = graphql.MarshalString("TestRun")
 		case "id":
-			out.Values[i] = ec._SpecRun_id(ctx, field, obj)
-		case "suiteId":
-			out.Values[i] = ec._SpecRun_suiteId(ctx, field, obj)
-		case "specDescription":
-			out.Values[i] = ec._SpecRun_specDescription(ctx, field, obj)
-		case "status":
-			out.Values[i] = ec._SpecRun_status(ctx, field, obj)
-		case "message":
-			out.Values[i] = ec._SpecRun_message(ctx, field, obj)
+			out.Values[i] = ec._TestRun_id(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "testProjectName":
+			out.Values[i] = ec._TestRun_testProjectName(ctx, field, obj)
+		case "testSeed":
+			out.Values[i] = ec._TestRun_testSeed(ctx, field, obj)
 		case "startTime":
-			out.Values[i] = ec._SpecRun_startTime(ctx, field, obj)
+			out.Values[i] = ec._TestRun_startTime(ctx, field, obj)
 		case "endTime":
-			out.Values[i] = ec._SpecRun_endTime(ctx, field, obj)
-		case "tags":
-			out.Values[i] = ec._SpecRun_tags(ctx, field, obj)
+			out.Values[i] = ec._TestRun_endTime(ctx, field, obj)
+		case "gitSha":
+			out.Values[i] = ec._TestRun_gitSha(ctx, field, obj)
+		case "gitBranch":
+			out.Values[i] = ec._TestRun_gitBranch(ctx, field, obj)
+		case "gitRepoUrl":
+			out.Values[i] = ec._TestRun_gitRepoUrl(ctx, field, obj)
+		case "pullRequestNumber":
+			out.Values[i] = ec._TestRun_pullRequestNumber(ctx, field, obj)
+		case "ciProvider":
+			out.Values[i] = ec._TestRun_ciProvider(ctx, field, obj)
+		case "buildNumber":
+			out.Values[i] = ec._TestRun_buildNumber(ctx, field, obj)
+		case "buildUrl":
+			out.Values[i] = ec._TestRun_buildUrl(ctx, field, obj)
+		case "triggeredBy":
+			out.Values[i] = ec._TestRun_triggeredBy(ctx, field, obj)
+		case "environment":
+			out.Values[i] = ec._TestRun_environment(ctx, field, obj)
+		case "labels":
+			out.Values[i] = ec._TestRun_labels(ctx, field, obj)
+		case "suiteRuns":
+			out.Values[i] = ec._TestRun_suiteRuns(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -2073,35 +6573,32 @@ func (ec *executionContext) _SpecRun(ctx context.Context, sel ast.SelectionSet,
 	return out
 }
 
-var suiteRunImplementors = []string{"SuiteRun"}
+var testRunConnectionImplementors = []string{"TestRunConnection"}
 
-func (ec *executionContext) _SuiteRun(ctx context.Context, sel ast.SelectionSet, obj *modelv2.SuiteRun) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, suiteRunImplementors)
+func (ec *executionContext) _TestRunConnection(ctx context.Context, sel ast.SelectionSet, obj *modelv2.TestRunConnection) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, testRunConnectionImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	deferred := make(map[string]*graphql.FieldSet)
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("SuiteRun")
-		case "id":
-			out.Values[i] = ec._SuiteRun_id(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("TestRunConnection")
+		case "edges":
+			out.Values[i] = ec._TestRunConnection_edges(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "testRunId":
-			out.Values[i] = ec._SuiteRun_testRunId(ctx, field, obj)
+		case "pageInfo":
+			out.Values[i] = ec._TestRunConnection_pageInfo(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "totalCount":
+			out.Values[i] = ec._TestRunConnection_totalCount(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "suiteName":
-			out.Values[i] = ec._SuiteRun_suiteName(ctx, field, obj)
-		case "startTime":
-			out.Values[i] = ec._SuiteRun_startTime(ctx, field, obj)
-		case "endTime":
-			out.Values[i] = ec._SuiteRun_endTime(ctx, field, obj)
-		case "specRuns":
-			out.Values[i] = ec._SuiteRun_specRuns(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -2125,21 +6622,27 @@ func (ec *executionContext) _SuiteRun(ctx context.Context, sel ast.SelectionSet,
 	return out
 }
 
-var tagImplementors = []string{"Tag"}
+var testRunEdgeImplementors = []string{"TestRunEdge"}
 
-func (ec *executionContext) _Tag(ctx context.Context, sel ast.SelectionSet, obj *modelv2.Tag) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, tagImplementors)
+func (ec *executionContext) _TestRunEdge(ctx context.Context, sel ast.SelectionSet, obj *modelv2.TestRunEdge) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, testRunEdgeImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	deferred := make(map[string]*graphql.FieldSet)
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("Tag")
-		case "id":
-			out.Values[i] = ec._Tag_id(ctx, field, obj)
-		case "name":
-			out.Values[i] = ec._Tag_name(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("TestRunEdge")
+		case "cursor":
+			out.Values[i] = ec._TestRunEdge_cursor(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "testRun":
+			out.Values[i] = ec._TestRunEdge_testRun(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -2160,166 +6663,291 @@ func (ec *executionContext) _Tag(ctx context.Context, sel ast.SelectionSet, obj
 		})
 	}
 
-	return out
+	return out
+}
+
+// endregion **************************** object.gotpl ****************************
+
+// region    ***************************** type.gotpl *****************************
+
+func (ec *executionContext) marshalNFailureGroup2ᚕᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐFailureGroupᚄ(ctx context.Context, sel ast.SelectionSet, v []*modelv2.FailureGroup) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNFailureGroup2ᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐFailureGroup(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNFailureGroup2ᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐFailureGroup(ctx context.Context, sel ast.SelectionSet, v *modelv2.FailureGroup) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._FailureGroup(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNFailureGroupSpec2ᚕᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐFailureGroupSpecᚄ(ctx context.Context, sel ast.SelectionSet, v []*modelv2.FailureGroupSpec) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNFailureGroupSpec2ᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐFailureGroupSpec(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNFailureGroupSpec2ᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐFailureGroupSpec(ctx context.Context, sel ast.SelectionSet, v *modelv2.FailureGroupSpec) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._FailureGroupSpec(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNFlakyEvidence2ᚕᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐFlakyEvidenceᚄ(ctx context.Context, sel ast.SelectionSet, v []*modelv2.FlakyEvidence) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNFlakyEvidence2ᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐFlakyEvidence(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
 }
 
-var testRunImplementors = []string{"TestRun"}
-
-func (ec *executionContext) _TestRun(ctx context.Context, sel ast.SelectionSet, obj *modelv2.TestRun) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, testRunImplementors)
-
-	out := graphql.NewFieldSet(fields)
-	deferred := make(map[string]*graphql.FieldSet)
-	for i, field := range fields {
-		switch field.Name {
-		case "__typename":
-			out.Values[i] = graphql.MarshalString("TestRun")
-		case "id":
-			out.Values[i] = ec._TestRun_id(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				out.Invalids++
-			}
-		case "testProjectName":
-			out.Values[i] = ec._TestRun_testProjectName(ctx, field, obj)
-		case "testSeed":
-			out.Values[i] = ec._TestRun_testSeed(ctx, field, obj)
-		case "startTime":
-			out.Values[i] = ec._TestRun_startTime(ctx, field, obj)
-		case "endTime":
-			out.Values[i] = ec._TestRun_endTime(ctx, field, obj)
-		case "suiteRuns":
-			out.Values[i] = ec._TestRun_suiteRuns(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				out.Invalids++
-			}
-		default:
-			panic("unknown field " + strconv.Quote(field.Name))
+func (ec *executionContext) marshalNFlakyEvidence2ᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐFlakyEvidence(ctx context.Context, sel ast.SelectionSet, v *modelv2.FlakyEvidence) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
 		}
-	}
-	out.Dispatch(ctx)
-	if out.Invalids > 0 {
 		return graphql.Null
 	}
+	return ec._FlakyEvidence(ctx, sel, v)
+}
 
-	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
-
-	for label, dfs := range deferred {
-		ec.processDeferredGroup(graphql.DeferredGroup{
-			Label:    label,
-			Path:     graphql.GetPath(ctx),
-			FieldSet: dfs,
-			Context:  ctx,
-		})
+func (ec *executionContext) marshalNFlakySpec2ᚕᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐFlakySpecᚄ(ctx context.Context, sel ast.SelectionSet, v []*modelv2.FlakySpec) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
 	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNFlakySpec2ᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐFlakySpec(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
 
-	return out
-}
+	}
+	wg.Wait()
 
-var testRunConnectionImplementors = []string{"TestRunConnection"}
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
 
-func (ec *executionContext) _TestRunConnection(ctx context.Context, sel ast.SelectionSet, obj *modelv2.TestRunConnection) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, testRunConnectionImplementors)
+	return ret
+}
 
-	out := graphql.NewFieldSet(fields)
-	deferred := make(map[string]*graphql.FieldSet)
-	for i, field := range fields {
-		switch field.Name {
-		case "__typename":
-			out.Values[i] = graphql.MarshalString("TestRunConnection")
-		case "edges":
-			out.Values[i] = ec._TestRunConnection_edges(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				out.Invalids++
-			}
-		case "pageInfo":
-			out.Values[i] = ec._TestRunConnection_pageInfo(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				out.Invalids++
-			}
-		case "totalCount":
-			out.Values[i] = ec._TestRunConnection_totalCount(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				out.Invalids++
-			}
-		default:
-			panic("unknown field " + strconv.Quote(field.Name))
+func (ec *executionContext) marshalNFlakySpec2ᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐFlakySpec(ctx context.Context, sel ast.SelectionSet, v *modelv2.FlakySpec) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
 		}
-	}
-	out.Dispatch(ctx)
-	if out.Invalids > 0 {
 		return graphql.Null
 	}
+	return ec._FlakySpec(ctx, sel, v)
+}
 
-	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
-
-	for label, dfs := range deferred {
-		ec.processDeferredGroup(graphql.DeferredGroup{
-			Label:    label,
-			Path:     graphql.GetPath(ctx),
-			FieldSet: dfs,
-			Context:  ctx,
-		})
+func (ec *executionContext) marshalNPageInfo2ᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐPageInfo(ctx context.Context, sel ast.SelectionSet, v *modelv2.PageInfo) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
 	}
-
-	return out
+	return ec._PageInfo(ctx, sel, v)
 }
 
-var testRunEdgeImplementors = []string{"TestRunEdge"}
-
-func (ec *executionContext) _TestRunEdge(ctx context.Context, sel ast.SelectionSet, obj *modelv2.TestRunEdge) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, testRunEdgeImplementors)
-
-	out := graphql.NewFieldSet(fields)
-	deferred := make(map[string]*graphql.FieldSet)
-	for i, field := range fields {
-		switch field.Name {
-		case "__typename":
-			out.Values[i] = graphql.MarshalString("TestRunEdge")
-		case "cursor":
-			out.Values[i] = ec._TestRunEdge_cursor(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				out.Invalids++
-			}
-		case "testRun":
-			out.Values[i] = ec._TestRunEdge_testRun(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				out.Invalids++
+func (ec *executionContext) marshalNQuarantineRule2ᚕᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐQuarantineRuleᚄ(ctx context.Context, sel ast.SelectionSet, v []*modelv2.QuarantineRule) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
 			}
-		default:
-			panic("unknown field " + strconv.Quote(field.Name))
+			ret[i] = ec.marshalNQuarantineRule2ᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐQuarantineRule(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
 		}
-	}
-	out.Dispatch(ctx)
-	if out.Invalids > 0 {
-		return graphql.Null
-	}
 
-	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+	}
+	wg.Wait()
 
-	for label, dfs := range deferred {
-		ec.processDeferredGroup(graphql.DeferredGroup{
-			Label:    label,
-			Path:     graphql.GetPath(ctx),
-			FieldSet: dfs,
-			Context:  ctx,
-		})
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
 	}
 
-	return out
+	return ret
 }
 
-// endregion **************************** object.gotpl ****************************
-
-// region    ***************************** type.gotpl *****************************
-
-func (ec *executionContext) marshalNPageInfo2ᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐPageInfo(ctx context.Context, sel ast.SelectionSet, v *modelv2.PageInfo) graphql.Marshaler {
+func (ec *executionContext) marshalNQuarantineRule2ᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐQuarantineRule(ctx context.Context, sel ast.SelectionSet, v *modelv2.QuarantineRule) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
 		}
 		return graphql.Null
 	}
-	return ec._PageInfo(ctx, sel, v)
+	return ec._QuarantineRule(ctx, sel, v)
 }
 
 func (ec *executionContext) marshalNSuiteRun2ᚕᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐSuiteRunᚄ(ctx context.Context, sel ast.SelectionSet, v []*modelv2.SuiteRun) graphql.Marshaler {
@@ -2503,6 +7131,68 @@ func (ec *executionContext) unmarshalNTestRunFilter2githubᚗcomᚋguidewireᚋf
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) unmarshalOAttempts2githubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋmodelsᚐSpecAttempts(ctx context.Context, v interface{}) (models.SpecAttempts, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var res models.SpecAttempts
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOAttempts2githubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋmodelsᚐSpecAttempts(ctx context.Context, sel ast.SelectionSet, v models.SpecAttempts) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
+func (ec *executionContext) unmarshalOContainers2githubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋmodelsᚐContainers(ctx context.Context, v interface{}) (models.Containers, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var res models.Containers
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOContainers2githubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋmodelsᚐContainers(ctx context.Context, sel ast.SelectionSet, v models.Containers) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
+func (ec *executionContext) marshalOFailure2ᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋmodelsᚐFailure(ctx context.Context, sel ast.SelectionSet, v *models.Failure) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._Failure(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalOFailureClassification2ᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐFailureClassification(ctx context.Context, sel ast.SelectionSet, v *modelv2.FailureClassification) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._FailureClassification(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalOLabels2githubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋmodelsᚐLabels(ctx context.Context, v interface{}) (models.Labels, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var res models.Labels
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOLabels2githubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋmodelsᚐLabels(ctx context.Context, sel ast.SelectionSet, v models.Labels) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
 func (ec *executionContext) marshalOSpecRun2ᚕᚖgithubᚗcomᚋguidewireᚋfernᚑreporterᚋpkgᚋgraphᚋmodelv2ᚐSpecRun(ctx context.Context, sel ast.SelectionSet, v []*modelv2.SpecRun) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -2,6 +2,76 @@
 
 package modelv2
 
+import (
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+// Compares a spec run with the previous runs of its spec on the same project
+// branch. kind is new_failure, still_failing or fixed. previousTestRunId is the
+// previous run of the spec, and failingSince* the first run of the failure streak
+// of a still failing spec.
+type FailureClassification struct {
+	Kind                  string  `json:"kind"`
+	FailingSince          *string `json:"failingSince,omitempty"`
+	FailingSinceTestRunID *int    `json:"failingSinceTestRunId,omitempty"`
+	FailingSinceGitSha    *string `json:"failingSinceGitSha,omitempty"`
+	PreviousTestRunID     *int    `json:"previousTestRunId,omitempty"`
+}
+
+// Failed spec runs whose failure messages are alike once the values that vary
+// between runs are normalized away. pattern is the normalized message and
+// message the latest original one.
+type FailureGroup struct {
+	Fingerprint string              `json:"fingerprint"`
+	Pattern     string              `json:"pattern"`
+	Message     string              `json:"message"`
+	Count       int                 `json:"count"`
+	TestRuns    int                 `json:"testRuns"`
+	FirstSeen   string              `json:"firstSeen"`
+	LastSeen    string              `json:"lastSeen"`
+	Specs       []*FailureGroupSpec `json:"specs"`
+}
+
+// A spec affected by a failure group, with the number of its failures in the
+// group and the latest one.
+type FailureGroupSpec struct {
+	TestCaseID      *int   `json:"testCaseId,omitempty"`
+	SuiteName       string `json:"suiteName"`
+	SpecDescription string `json:"specDescription"`
+	Count           int    `json:"count"`
+	LastTestRunID   int    `json:"lastTestRunId"`
+	LastSpecRunID   int    `json:"lastSpecRunId"`
+}
+
+// An execution of a flaky spec that shows its flakiness. reason is one of
+// commit_flip, retried or alternation, and url links to the report of the run.
+type FlakyEvidence struct {
+	TestRunID int     `json:"testRunId"`
+	SpecRunID int     `json:"specRunId"`
+	Status    *string `json:"status,omitempty"`
+	GitSha    *string `json:"gitSha,omitempty"`
+	StartTime *string `json:"startTime,omitempty"`
+	Reason    *string `json:"reason,omitempty"`
+	URL       *string `json:"url,omitempty"`
+}
+
+// A spec of a branch whose outcome changes without a code change, scored from 0
+// (stable) to 1 (most flaky).
+type FlakySpec struct {
+	TestCaseID      *int             `json:"testCaseId,omitempty"`
+	SuiteName       *string          `json:"suiteName,omitempty"`
+	SpecDescription *string          `json:"specDescription,omitempty"`
+	GitBranch       *string          `json:"gitBranch,omitempty"`
+	Score           float64          `json:"score"`
+	Executions      int              `json:"executions"`
+	Failures        int              `json:"failures"`
+	RetriedPasses   int              `json:"retriedPasses"`
+	CommitFlips     int              `json:"commitFlips"`
+	Alternations    int              `json:"alternations"`
+	AlternationRate float64          `json:"alternationRate"`
+	Evidence        []*FlakyEvidence `json:"evidence"`
+}
+
 type PageInfo struct {
 	HasNextPage     bool   `json:"hasNextPage"`
 	HasPreviousPage bool   `json:"hasPreviousPage"`
@@ -9,27 +79,53 @@ type PageInfo struct {
 	EndCursor       string `json:"endCursor"`
 }
 
+// Quarantines a spec of a project until expiresAt. source is manual, or auto when
+// the rule was created for a flakiness score above the configured threshold.
+type QuarantineRule struct {
+	ID              int      `json:"id"`
+	TestProjectName string   `json:"testProjectName"`
+	TestCaseID      *int     `json:"testCaseId,omitempty"`
+	SuiteName       string   `json:"suiteName"`
+	SpecDescription string   `json:"specDescription"`
+	Reason          string   `json:"reason"`
+	Source          string   `json:"source"`
+	Score           *float64 `json:"score,omitempty"`
+	CreatedBy       *string  `json:"createdBy,omitempty"`
+	CreatedAt       string   `json:"createdAt"`
+	ExpiresAt       string   `json:"expiresAt"`
+}
+
 type Query struct {
 }
 
 type SpecRun struct {
-	ID              *int    `json:"id,omitempty"`
-	SuiteID         *int    `json:"suiteId,omitempty"`
-	SpecDescription *string `json:"specDescription,omitempty"`
-	Status          *string `json:"status,omitempty"`
-	Message         *string `json:"message,omitempty"`
-	StartTime       *string `json:"startTime,omitempty"`
-	EndTime         *string `json:"endTime,omitempty"`
-	Tags            []*Tag  `json:"tags" gorm:"many2many:spec_run_tags;"`
+	ID              *int                `json:"id,omitempty"`
+	SuiteID         *int                `json:"suiteId,omitempty"`
+	SpecDescription *string             `json:"specDescription,omitempty"`
+	Status          *string             `json:"status,omitempty"`
+	Message         *string             `json:"message,omitempty"`
+	StartTime       *string             `json:"startTime,omitempty"`
+	EndTime         *string             `json:"endTime,omitempty"`
+	Labels          models.Labels       `json:"labels,omitempty"`
+	Containers      models.Containers   `json:"containers,omitempty"`
+	Failure         *models.Failure     `json:"failure,omitempty"`
+	Attempts        models.SpecAttempts `json:"attempts,omitempty"`
+	Tags            []*Tag              `json:"tags" gorm:"many2many:spec_run_tags;"`
+	TestCaseID      *int                `json:"testCaseId,omitempty"`
+	Owner           *string             `json:"owner,omitempty" gorm:"-"`
+	Quarantined     *bool               `json:"quarantined,omitempty" gorm:"-"`
+	// Only set by testRunById.
+	Classification *FailureClassification `json:"classification,omitempty" gorm:"-"`
 }
 
 type SuiteRun struct {
-	ID        int        `json:"id"`
-	TestRunID int        `json:"testRunId"`
-	SuiteName *string    `json:"suiteName,omitempty"`
-	StartTime *string    `json:"startTime,omitempty"`
-	EndTime   *string    `json:"endTime,omitempty"`
-	SpecRuns  []*SpecRun `json:"spec_runs" gorm:"foreignKey:SuiteID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	ID        int           `json:"id"`
+	TestRunID int           `json:"testRunId"`
+	SuiteName *string       `json:"suiteName,omitempty"`
+	StartTime *string       `json:"startTime,omitempty"`
+	EndTime   *string       `json:"endTime,omitempty"`
+	Labels    models.Labels `json:"labels,omitempty"`
+	SpecRuns  []*SpecRun    `json:"spec_runs" gorm:"foreignKey:SuiteID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
 
 type Tag struct {
@@ -38,12 +134,22 @@ type Tag struct {
 }
 
 type TestRun struct {
-	ID              int         `json:"id"`
-	TestProjectName *string     `json:"testProjectName,omitempty"`
-	TestSeed        *int        `json:"testSeed,omitempty"`
-	StartTime       *string     `json:"startTime,omitempty"`
-	EndTime         *string     `json:"endTime,omitempty"`
-	SuiteRuns       []*SuiteRun `json:"suite_runs" gorm:"foreignKey:TestRunID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	ID                int           `json:"id"`
+	TestProjectName   *string       `json:"testProjectName,omitempty"`
+	TestSeed          *int          `json:"testSeed,omitempty"`
+	StartTime         *string       `json:"startTime,omitempty"`
+	EndTime           *string       `json:"endTime,omitempty"`
+	GitSha            *string       `json:"gitSha,omitempty"`
+	GitBranch         *string       `json:"gitBranch,omitempty"`
+	GitRepoURL        *string       `json:"gitRepoUrl,omitempty"`
+	PullRequestNumber *int          `json:"pullRequestNumber,omitempty"`
+	CiProvider        *string       `json:"ciProvider,omitempty"`
+	BuildNumber       *string       `json:"buildNumber,omitempty"`
+	BuildURL          *string       `json:"buildUrl,omitempty"`
+	TriggeredBy       *string       `json:"triggeredBy,omitempty"`
+	Environment       *string       `json:"environment,omitempty"`
+	Labels            models.Labels `json:"labels,omitempty"`
+	SuiteRuns         []*SuiteRun   `json:"suite_runs" gorm:"foreignKey:TestRunID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
 
 type TestRunConnection struct {
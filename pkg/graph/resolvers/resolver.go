@@ -1,6 +1,19 @@
 package resolvers
 
-import "gorm.io/gorm"
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/guidewire/fern-reporter/pkg/failures"
+	"github.com/guidewire/fern-reporter/pkg/flaky"
+	"github.com/guidewire/fern-reporter/pkg/graph/modelv2"
+	"github.com/guidewire/fern-reporter/pkg/labels"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/ownership"
+	"github.com/guidewire/fern-reporter/pkg/quarantine"
+	"gorm.io/gorm"
+)
 
 //go:generate go run github.com/99designs/gqlgen
 // This file will not be regenerated automatically.
@@ -8,3 +21,334 @@ import "gorm.io/gorm"
 // It serves as dependency injection for your app, add any dependencies you require here.
 
 type Resolver struct{ DB *gorm.DB }
+
+// testRunSourceScope narrows test runs down to a branch and an (abbreviated)
+// commit. Nil or empty values do not filter.
+func testRunSourceScope(branch, commit *string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if branch != nil && *branch != "" {
+			db = db.Where("git_branch = ?", *branch)
+		}
+		if commit != nil && *commit != "" {
+			db = db.Where("git_sha LIKE ?", *commit+"%")
+		}
+		return db
+	}
+}
+
+// testRunLabelSelector parses an optional label selector. Nil or empty
+// selectors match every test run.
+func testRunLabelSelector(selector *string) (labels.Selector, error) {
+	if selector == nil {
+		return labels.Selector{}, nil
+	}
+	return labels.Parse(*selector)
+}
+
+// applyOwners sets the owners of the spec runs of the test runs from the
+// ownership rules of their projects. Failures are only logged, leaving the
+// spec runs without owners.
+func (r *Resolver) applyOwners(testRuns []*modelv2.TestRun) {
+	names := specRunProjectNames(testRuns)
+	if len(names) == 0 {
+		return
+	}
+	matchers, err := ownership.Load(r.DB, names)
+	if err != nil {
+		log.Printf("error loading ownership rules: %v", err)
+		return
+	}
+
+	for _, testRun := range testRuns {
+		matcher, ok := matchers[deref(testRun.TestProjectName)]
+		if !ok {
+			continue
+		}
+		for _, suiteRun := range testRun.SuiteRuns {
+			for _, specRun := range suiteRun.SpecRuns {
+				if owner := matcher.Owner(ownerSpec(suiteRun, specRun)); owner != "" {
+					specRun.Owner = &owner
+				}
+			}
+		}
+	}
+}
+
+// applyQuarantine marks the quarantined spec runs of the test runs from the
+// active quarantine rules of their projects. Failures are only logged,
+// leaving the spec runs unmarked.
+func (r *Resolver) applyQuarantine(testRuns []*modelv2.TestRun) {
+	names := specRunProjectNames(testRuns)
+	if len(names) == 0 {
+		return
+	}
+	matchers, err := quarantine.Load(r.DB, names, time.Now())
+	if err != nil {
+		log.Printf("error loading quarantine rules: %v", err)
+		return
+	}
+
+	for _, testRun := range testRuns {
+		matcher, ok := matchers[deref(testRun.TestProjectName)]
+		if !ok {
+			continue
+		}
+		for _, suiteRun := range testRun.SuiteRuns {
+			for _, specRun := range suiteRun.SpecRuns {
+				if matcher.Rule(toUint64(specRun.TestCaseID), deref(suiteRun.SuiteName), deref(specRun.SpecDescription)) != nil {
+					quarantined := true
+					specRun.Quarantined = &quarantined
+				}
+			}
+		}
+	}
+}
+
+// applyClassification classifies the spec runs of a test run against the
+// previous runs of its project branch. Failures are only logged, leaving the
+// spec runs unclassified.
+func (r *Resolver) applyClassification(testRun *modelv2.TestRun) {
+	if testRun == nil || len(testRun.SuiteRuns) == 0 {
+		return
+	}
+	// Times are scanned into strings as RFC 3339
+	startTime, err := time.Parse(time.RFC3339Nano, deref(testRun.StartTime))
+	if err != nil {
+		log.Printf("error classifying failures: %v", err)
+		return
+	}
+
+	classified := models.TestRun{
+		ID:              uint64(testRun.ID),
+		TestProjectName: deref(testRun.TestProjectName),
+		GitBranch:       deref(testRun.GitBranch),
+		StartTime:       startTime,
+		SuiteRuns:       make([]models.SuiteRun, len(testRun.SuiteRuns)),
+	}
+	for i, suiteRun := range testRun.SuiteRuns {
+		classified.SuiteRuns[i] = models.SuiteRun{SuiteName: deref(suiteRun.SuiteName), SpecRuns: make([]models.SpecRun, len(suiteRun.SpecRuns))}
+		for j, specRun := range suiteRun.SpecRuns {
+			classified.SuiteRuns[i].SpecRuns[j] = models.SpecRun{
+				SpecDescription: deref(specRun.SpecDescription),
+				Status:          deref(specRun.Status),
+				TestCaseID:      toUint64(specRun.TestCaseID),
+			}
+		}
+	}
+	if err := failures.Classify(r.DB, &classified); err != nil {
+		log.Printf("error classifying failures: %v", err)
+		return
+	}
+
+	for i, suiteRun := range testRun.SuiteRuns {
+		for j, specRun := range suiteRun.SpecRuns {
+			specRun.Classification = toFailureClassification(classified.SuiteRuns[i].SpecRuns[j].Classification)
+		}
+	}
+}
+
+// specRunProjectNames returns the project names of the test runs with suite
+// runs.
+func specRunProjectNames(testRuns []*modelv2.TestRun) []string {
+	var names []string
+	for _, testRun := range testRuns {
+		if testRun != nil && testRun.TestProjectName != nil && len(testRun.SuiteRuns) > 0 {
+			names = append(names, *testRun.TestProjectName)
+		}
+	}
+	return names
+}
+
+// ownerSpec returns the values of a spec run that ownership rules match.
+func ownerSpec(suiteRun *modelv2.SuiteRun, specRun *modelv2.SpecRun) ownership.Spec {
+	spec := models.SpecRun{
+		SpecDescription: deref(specRun.SpecDescription),
+		Containers:      specRun.Containers,
+		Failure:         specRun.Failure,
+	}
+	for _, tag := range specRun.Tags {
+		spec.Tags = append(spec.Tags, models.Tag{Name: deref(tag.Name)})
+	}
+	return ownership.SpecOf(deref(suiteRun.SuiteName), spec)
+}
+
+func toUint64(value *int) *uint64 {
+	if value == nil {
+		return nil
+	}
+	converted := uint64(*value)
+	return &converted
+}
+
+func deref(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// flakyQuery builds the flaky spec query from the optional arguments of the
+// flakySpecs field. Times are RFC 3339.
+func flakyQuery(project string, branch, startTime, endTime *string, minExecutions, limit *int) (flaky.Query, error) {
+	query := flaky.Query{Project: project, Branch: deref(branch)}
+	var err error
+	if query.Start, err = parseTimeArgument("startTime", startTime); err != nil {
+		return query, err
+	}
+	if query.End, err = parseTimeArgument("endTime", endTime); err != nil {
+		return query, err
+	}
+	if minExecutions != nil {
+		query.MinExecutions = *minExecutions
+	}
+	if limit != nil {
+		if *limit < 1 || *limit > flaky.MaxLimit {
+			return query, fmt.Errorf("limit must be between 1 and %d", flaky.MaxLimit)
+		}
+		query.Limit = *limit
+	}
+	return query, nil
+}
+
+// failureGroupQuery builds the failure group query from the optional
+// arguments of the failureGroups field. Times are RFC 3339.
+func failureGroupQuery(project string, branch, startTime, endTime *string, limit *int) (failures.Query, error) {
+	query := failures.Query{Project: project, Branch: deref(branch)}
+	var err error
+	if query.Start, err = parseTimeArgument("startTime", startTime); err != nil {
+		return query, err
+	}
+	if query.End, err = parseTimeArgument("endTime", endTime); err != nil {
+		return query, err
+	}
+	if limit != nil {
+		if *limit < 1 || *limit > failures.MaxLimit {
+			return query, fmt.Errorf("limit must be between 1 and %d", failures.MaxLimit)
+		}
+		query.Limit = *limit
+	}
+	return query, nil
+}
+
+// parseTimeArgument parses an optional RFC 3339 time argument. A missing or
+// empty argument is the zero time.
+func parseTimeArgument(name string, value *string) (time.Time, error) {
+	if value == nil || *value == "" {
+		return time.Time{}, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		return parsed, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return parsed, nil
+}
+
+// toFlakySpec converts a flaky spec to its GraphQL type.
+func toFlakySpec(flakySpec models.FlakySpec) *modelv2.FlakySpec {
+	result := &modelv2.FlakySpec{
+		SuiteName:       &flakySpec.SuiteName,
+		SpecDescription: &flakySpec.SpecDescription,
+		GitBranch:       &flakySpec.GitBranch,
+		Score:           flakySpec.Score,
+		Executions:      flakySpec.Executions,
+		Failures:        flakySpec.Failures,
+		RetriedPasses:   flakySpec.RetriedPasses,
+		CommitFlips:     flakySpec.CommitFlips,
+		Alternations:    flakySpec.Alternations,
+		AlternationRate: flakySpec.AlternationRate,
+		Evidence:        make([]*modelv2.FlakyEvidence, len(flakySpec.Evidence)),
+	}
+	if flakySpec.TestCaseID != nil {
+		testCaseID := int(*flakySpec.TestCaseID)
+		result.TestCaseID = &testCaseID
+	}
+	for i, evidence := range flakySpec.Evidence {
+		evidence := evidence
+		startTime := evidence.StartTime.Format(time.RFC3339)
+		result.Evidence[i] = &modelv2.FlakyEvidence{
+			TestRunID: int(evidence.TestRunID),
+			SpecRunID: int(evidence.SpecRunID),
+			Status:    &evidence.Status,
+			GitSha:    &evidence.GitSha,
+			StartTime: &startTime,
+			Reason:    &evidence.Reason,
+			URL:       &evidence.URL,
+		}
+	}
+	return result
+}
+
+// toFailureGroups converts failure groups to their GraphQL type.
+func toFailureGroups(failureGroups []models.FailureGroup) []*modelv2.FailureGroup {
+	result := make([]*modelv2.FailureGroup, len(failureGroups))
+	for i, group := range failureGroups {
+		result[i] = &modelv2.FailureGroup{
+			Fingerprint: group.Fingerprint,
+			Pattern:     group.Pattern,
+			Message:     group.Message,
+			Count:       group.Count,
+			TestRuns:    group.TestRuns,
+			FirstSeen:   group.FirstSeen.Format(time.RFC3339),
+			LastSeen:    group.LastSeen.Format(time.RFC3339),
+			Specs:       make([]*modelv2.FailureGroupSpec, len(group.Specs)),
+		}
+		for j, spec := range group.Specs {
+			result[i].Specs[j] = &modelv2.FailureGroupSpec{
+				SuiteName:       spec.SuiteName,
+				SpecDescription: spec.SpecDescription,
+				Count:           spec.Count,
+				LastTestRunID:   int(spec.LastTestRunID),
+				LastSpecRunID:   int(spec.LastSpecRunID),
+			}
+			if spec.TestCaseID != nil {
+				testCaseID := int(*spec.TestCaseID)
+				result[i].Specs[j].TestCaseID = &testCaseID
+			}
+		}
+	}
+	return result
+}
+
+// toFailureClassification converts a failure classification to its GraphQL
+// type.
+func toFailureClassification(classification *models.FailureClassification) *modelv2.FailureClassification {
+	if classification == nil {
+		return nil
+	}
+	result := &modelv2.FailureClassification{Kind: classification.Kind}
+	if classification.FailingSince != nil {
+		failingSince := classification.FailingSince.Format(time.RFC3339)
+		failingSinceTestRunID := int(classification.FailingSinceTestRunID)
+		result.FailingSince = &failingSince
+		result.FailingSinceTestRunID = &failingSinceTestRunID
+		result.FailingSinceGitSha = &classification.FailingSinceGitSha
+	}
+	if classification.PreviousTestRunID != 0 {
+		previousTestRunID := int(classification.PreviousTestRunID)
+		result.PreviousTestRunID = &previousTestRunID
+	}
+	return result
+}
+
+// toQuarantineRule converts a quarantine rule to its GraphQL type.
+func toQuarantineRule(rule models.QuarantineRule) *modelv2.QuarantineRule {
+	result := &modelv2.QuarantineRule{
+		ID:              int(rule.ID),
+		TestProjectName: rule.TestProjectName,
+		SuiteName:       rule.SuiteName,
+		SpecDescription: rule.SpecDescription,
+		Reason:          rule.Reason,
+		Source:          rule.Source,
+		CreatedBy:       &rule.CreatedBy,
+		CreatedAt:       rule.CreatedAt.Format(time.RFC3339),
+		ExpiresAt:       rule.ExpiresAt.Format(time.RFC3339),
+	}
+	if rule.TestCaseID != nil {
+		testCaseID := int(*rule.TestCaseID)
+		result.TestCaseID = &testCaseID
+	}
+	if rule.Source == quarantine.SourceAuto {
+		result.Score = &rule.Score
+	}
+	return result
+}
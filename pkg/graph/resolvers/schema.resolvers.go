@@ -6,24 +6,35 @@ package resolvers
 
 import (
 	"context"
+	"time"
+
+	"github.com/guidewire/fern-reporter/pkg/failures"
+	"github.com/guidewire/fern-reporter/pkg/flaky"
 	"github.com/guidewire/fern-reporter/pkg/graph/generated"
 	"github.com/guidewire/fern-reporter/pkg/graph/modelv2"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/quarantine"
 	"github.com/guidewire/fern-reporter/pkg/utils"
 )
 
 // TestRuns is the resolver for the testRuns field.
-func (r *queryResolver) TestRuns(ctx context.Context, first *int, after *string) (*modelv2.TestRunConnection, error) {
+func (r *queryResolver) TestRuns(ctx context.Context, first *int, after *string, branch *string, commit *string, labelSelector *string) (*modelv2.TestRunConnection, error) {
 	// Convert the `after` cursor to an offset.
 	offset := utils.DecodeCursor(after)
 
+	selector, err := testRunLabelSelector(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
 	var testRuns []*modelv2.TestRun
-	if err := r.DB.Offset(offset).Limit(*first).Find(&testRuns).Error; err != nil {
+	if err := r.DB.Scopes(models.LiveTestRuns, testRunSourceScope(branch, commit), selector.RunScope()).Offset(offset).Limit(*first).Find(&testRuns).Error; err != nil {
 		return nil, err
 	}
 
 	// Get the total count of TestRun records.
 	var totalCount int64
-	if err := r.DB.Model(&modelv2.TestRun{}).Count(&totalCount).Error; err != nil {
+	if err := r.DB.Model(&modelv2.TestRun{}).Scopes(models.LiveTestRuns, testRunSourceScope(branch, commit), selector.RunScope()).Count(&totalCount).Error; err != nil {
 		return nil, err
 	}
 
@@ -56,26 +67,78 @@ func (r *queryResolver) TestRuns(ctx context.Context, first *int, after *string)
 // TestRun is the resolver for the testRun field.
 func (r *queryResolver) TestRun(ctx context.Context, testRunFilter modelv2.TestRunFilter) ([]*modelv2.TestRun, error) {
 	var testRuns []*modelv2.TestRun
-	r.DB.Preload("SuiteRuns.SpecRuns").Where("id = ?", testRunFilter.ID).Where("test_project_name = ?", testRunFilter.TestProjectName).Find(&testRuns)
+	r.DB.Scopes(models.LiveTestRuns).Preload("SuiteRuns.SpecRuns.Tags").Where("id = ?", testRunFilter.ID).Where("test_project_name = ?", testRunFilter.TestProjectName).Find(&testRuns)
+	r.applyOwners(testRuns)
+	r.applyQuarantine(testRuns)
 	return testRuns, nil
 }
 
 // TestRunByID is the resolver for the testRunById field.
 func (r *queryResolver) TestRunByID(ctx context.Context, id int) (*modelv2.TestRun, error) {
 	var testRun *modelv2.TestRun
-	r.DB.Preload("SuiteRuns.SpecRuns").Where("id = ?", id).First(&testRun)
+	r.DB.Scopes(models.LiveTestRuns).Preload("SuiteRuns.SpecRuns.Tags").Where("id = ?", id).First(&testRun)
+	r.applyOwners([]*modelv2.TestRun{testRun})
+	r.applyQuarantine([]*modelv2.TestRun{testRun})
+	r.applyClassification(testRun)
 
 	return testRun, nil
 }
 
+// FlakySpecs is the resolver for the flakySpecs field.
+func (r *queryResolver) FlakySpecs(ctx context.Context, project string, branch *string, startTime *string, endTime *string, minExecutions *int, limit *int) ([]*modelv2.FlakySpec, error) {
+	query, err := flakyQuery(project, branch, startTime, endTime, minExecutions, limit)
+	if err != nil {
+		return nil, err
+	}
+	flakySpecs, err := flaky.Detect(r.DB, query, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*modelv2.FlakySpec, len(flakySpecs))
+	for i, flakySpec := range flakySpecs {
+		result[i] = toFlakySpec(flakySpec)
+	}
+	return result, nil
+}
+
+// QuarantineRules is the resolver for the quarantineRules field.
+func (r *queryResolver) QuarantineRules(ctx context.Context, project string) ([]*modelv2.QuarantineRule, error) {
+	rules, err := quarantine.Active(r.DB, []string{project}, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*modelv2.QuarantineRule, len(rules))
+	for i, rule := range rules {
+		result[i] = toQuarantineRule(rule)
+	}
+	return result, nil
+}
+
+// FailureGroups is the resolver for the failureGroups field.
+func (r *queryResolver) FailureGroups(ctx context.Context, project string, branch *string, startTime *string, endTime *string, limit *int) ([]*modelv2.FailureGroup, error) {
+	query, err := failureGroupQuery(project, branch, startTime, endTime, limit)
+	if err != nil {
+		return nil, err
+	}
+	failureGroups, err := failures.Detect(r.DB, query, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return toFailureGroups(failureGroups), nil
+}
+
+// TestRunFailureGroups is the resolver for the testRunFailureGroups field.
+func (r *queryResolver) TestRunFailureGroups(ctx context.Context, testRunID int) ([]*modelv2.FailureGroup, error) {
+	var testRun models.TestRun
+	if err := r.DB.Preload("SuiteRuns.SpecRuns").Where("id = ?", testRunID).First(&testRun).Error; err != nil {
+		return nil, err
+	}
+	return toFailureGroups(failures.Group(failures.FromTestRun(testRun))), nil
+}
+
 // Query returns generated.QueryResolver implementation.
 func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 
 type queryResolver struct{ *Resolver }
-
-// !!! WARNING !!!
-// The code below was going to be deleted when updating resolvers. It has been copied here so you have
-// one last chance to move it out of harms way if you want. There are two reasons this happens:
-//   - When renaming or deleting a resolver the old code will be put in here. You can safely delete
-//     it when you're done.
-//   - You have helper methods in this file. Move them out to keep these resolver files clean.
@@ -7,8 +7,11 @@ import (
 	"github.com/99designs/gqlgen/client"
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/guidewire/fern-reporter/pkg/failures"
+	"github.com/guidewire/fern-reporter/pkg/flaky"
 	"github.com/guidewire/fern-reporter/pkg/graph/generated"
 	"github.com/guidewire/fern-reporter/pkg/graph/resolvers"
+	"github.com/guidewire/fern-reporter/pkg/models"
 	"github.com/guidewire/fern-reporter/pkg/utils"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -16,6 +19,7 @@ import (
 	"gorm.io/gorm"
 	"net/http/httptest"
 	"regexp"
+	"time"
 )
 
 var (
@@ -50,7 +54,7 @@ var _ = Describe("Handlers", func() {
 			rows := sqlmock.NewRows([]string{"ID", "TestProjectName", "TestSeed"}).
 				AddRow(1, "project 1", "1")
 
-			mock.ExpectQuery(`SELECT \* FROM "test_runs" LIMIT \$1`).
+			mock.ExpectQuery(`SELECT \* FROM "test_runs" WHERE test_runs\.deleted_at IS NULL LIMIT \$1`).
 				WithArgs(1).
 				WillReturnRows(rows)
 
@@ -116,7 +120,7 @@ var _ = Describe("Handlers", func() {
 			countRows := sqlmock.NewRows([]string{"count"}).AddRow(2)
 
 			// Expectation for the data query
-			mock.ExpectQuery(`SELECT \* FROM "test_runs" LIMIT \$1`).
+			mock.ExpectQuery(`SELECT \* FROM "test_runs" WHERE test_runs\.deleted_at IS NULL LIMIT \$1`).
 				WithArgs(2).
 				WillReturnRows(rows)
 
@@ -200,7 +204,7 @@ var _ = Describe("Handlers", func() {
 			countRows := sqlmock.NewRows([]string{"count"}).AddRow(0)
 
 			// Expectation for the data query
-			mock.ExpectQuery(`SELECT \* FROM "test_runs" LIMIT \$1`).
+			mock.ExpectQuery(`SELECT \* FROM "test_runs" WHERE test_runs\.deleted_at IS NULL LIMIT \$1`).
 				WithArgs(0).
 				WillReturnRows(rows)
 
@@ -259,7 +263,7 @@ var _ = Describe("Handlers", func() {
 
 			countRows := sqlmock.NewRows([]string{"count"}).AddRow(3)
 
-			mock.ExpectQuery(`SELECT \* FROM "test_runs" LIMIT \$1`).
+			mock.ExpectQuery(`SELECT \* FROM "test_runs" WHERE test_runs\.deleted_at IS NULL LIMIT \$1`).
 				WithArgs(5).
 				WillReturnRows(rows)
 
@@ -322,7 +326,7 @@ var _ = Describe("Handlers", func() {
 				AddRow(4, "project 4", 4)
 
 			// Mocking the expected SQL queries and results in the correct order
-			mock.ExpectQuery(`SELECT \* FROM "test_runs" LIMIT \$1 OFFSET \$2`).
+			mock.ExpectQuery(`SELECT \* FROM "test_runs" WHERE test_runs\.deleted_at IS NULL LIMIT \$1 OFFSET \$2`).
 				WithArgs(first, 2). // first=2, after=2 means starting from 3rd record
 				WillReturnRows(testRuns)
 
@@ -330,7 +334,7 @@ var _ = Describe("Handlers", func() {
 				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(totalCount))
 
 			// Execute the resolver function
-			result, err := queryResolver.Query().TestRuns(ctx, &first, &after)
+			result, err := queryResolver.Query().TestRuns(ctx, &first, &after, nil, nil, nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			// Validate the results
@@ -359,7 +363,7 @@ var _ = Describe("Handlers", func() {
 				AddRow(3, "project 3", 3)
 
 			// Mocking the expected SQL queries and results in the correct order
-			mock.ExpectQuery(`SELECT \* FROM "test_runs" LIMIT \$1 OFFSET \$2`).
+			mock.ExpectQuery(`SELECT \* FROM "test_runs" WHERE test_runs\.deleted_at IS NULL LIMIT \$1 OFFSET \$2`).
 				WithArgs(first, 2). // first=1, after=2 means starting from 3rd record
 				WillReturnRows(testRuns)
 
@@ -367,7 +371,7 @@ var _ = Describe("Handlers", func() {
 				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(totalCount))
 
 			// Execute the resolver function
-			result, err := queryResolver.Query().TestRuns(ctx, &first, &after)
+			result, err := queryResolver.Query().TestRuns(ctx, &first, &after, nil, nil, nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			// Validate the results
@@ -382,6 +386,65 @@ var _ = Describe("Handlers", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		It("should filter the records and the total count by branch and commit", func() {
+			queryResolver := &resolvers.Resolver{DB: gormDb}
+			ctx := context.Background()
+			first := 10
+			after := ""
+			branch := "main"
+			commit := "0a1b2c3"
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE test_runs.deleted_at IS NULL AND git_branch = $1 AND git_sha LIKE $2 LIMIT $3`)).
+				WithArgs(branch, "0a1b2c3%", first).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "git_branch", "git_sha"}).AddRow(1, "main", "0a1b2c3d4e5f"))
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "test_runs" WHERE test_runs.deleted_at IS NULL AND git_branch = $1 AND git_sha LIKE $2`)).
+				WithArgs(branch, "0a1b2c3%").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+			result, err := queryResolver.Query().TestRuns(ctx, &first, &after, &branch, &commit, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.TotalCount).To(Equal(1))
+			Expect(*result.Edges[0].TestRun.GitBranch).To(Equal("main"))
+			Expect(*result.Edges[0].TestRun.GitSha).To(Equal("0a1b2c3d4e5f"))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+
+		It("should filter the records and the total count by label selector", func() {
+			queryResolver := &resolvers.Resolver{DB: gormDb}
+			ctx := context.Background()
+			first := 10
+			after := ""
+			selector := "os=linux"
+			exists := `EXISTS (SELECT 1 FROM suite_runs INNER JOIN spec_runs ON spec_runs.suite_id = suite_runs.id WHERE suite_runs.test_run_id = test_runs.id AND (test_runs.labels || suite_runs.labels || spec_runs.labels) ->> $1 = $2)`
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE test_runs.deleted_at IS NULL AND (`+exists+`) LIMIT $3`)).
+				WithArgs("os", "linux", first).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "labels"}).AddRow(1, `{"os":"linux"}`))
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "test_runs" WHERE test_runs.deleted_at IS NULL AND (`+exists+`)`)).
+				WithArgs("os", "linux").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+			result, err := queryResolver.Query().TestRuns(ctx, &first, &after, nil, nil, &selector)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.TotalCount).To(Equal(1))
+			Expect(result.Edges[0].TestRun.Labels).To(HaveKeyWithValue("os", "linux"))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+
+		It("should reject an invalid label selector", func() {
+			queryResolver := &resolvers.Resolver{DB: gormDb}
+			first := 10
+			after := ""
+			selector := "os version=14"
+
+			_, err := queryResolver.Query().TestRuns(context.Background(), &first, &after, nil, nil, &selector)
+			Expect(err).To(MatchError(ContainSubstring("invalid label key")))
+		})
+
 		It("should return an error when fetching TestRun records fails", func() {
 			queryResolver := &resolvers.Resolver{DB: gormDb}
 			testFirst := 3
@@ -392,7 +455,7 @@ var _ = Describe("Handlers", func() {
 				WillReturnError(errors.New("database error when fetching test_runs"))
 
 			// Act: Call the TestRuns method
-			_, err := queryResolver.Query().TestRuns(ctx, &testFirst, &testAfter)
+			_, err := queryResolver.Query().TestRuns(ctx, &testFirst, &testAfter, nil, nil, nil)
 
 			// Assert: Verify that an error occurred and it contains the correct message
 			Expect(err).To(HaveOccurred())
@@ -417,7 +480,7 @@ var _ = Describe("Handlers", func() {
 				WillReturnError(errors.New("database error when fetching total count"))
 
 			// Act: Call the TestRuns method
-			_, err := queryResolver.Query().TestRuns(ctx, &testFirst, &testAfter)
+			_, err := queryResolver.Query().TestRuns(ctx, &testFirst, &testAfter, nil, nil, nil)
 
 			// Assert: Verify that an error occurred and it contains the correct message
 			Expect(err).To(HaveOccurred())
@@ -449,6 +512,10 @@ var _ = Describe("Handlers", func() {
 				WithArgs(1).
 				WillReturnRows(specRows)
 
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_run_tags" WHERE "spec_run_tags"."spec_run_id" = $1`)).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"spec_run_id", "tag_id"}))
+
 			queryResolver := &resolvers.Resolver{DB: gormDb}
 
 			gqlHandler := handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: queryResolver}))
@@ -497,6 +564,213 @@ var _ = Describe("Handlers", func() {
 			Expect(response.TestRun[0].SuiteRuns[0].TestRunID).To(Equal(1))
 			Expect(response.TestRun[0].SuiteRuns[0].SuiteName).To(Equal("suite 1"))
 		})
+
+		It("should return the container hierarchy of spec runs", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND test_project_name = $2`)).
+				WithArgs(1, "project 1").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name"}).AddRow(1, "project 1"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suite_runs" WHERE "suite_runs"."test_run_id" = $1`)).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id"}).AddRow(1, 1))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_runs" WHERE "spec_runs"."suite_id" = $1`)).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "suite_id", "spec_description", "containers"}).
+					AddRow(1, 1, "has no items", `[{"text":"Cart","labels":["cart"]},{"text":"when empty"}]`))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_run_tags" WHERE "spec_run_tags"."spec_run_id" = $1`)).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"spec_run_id", "tag_id"}))
+
+			queryResolver := &resolvers.Resolver{DB: gormDb}
+			cli := client.New(handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: queryResolver})))
+
+			var response struct {
+				TestRun []struct {
+					SuiteRuns []struct {
+						SpecRuns []struct {
+							SpecDescription string
+							Containers      models.Containers
+						}
+					}
+				}
+			}
+			err := cli.Post(`query { testRun(testRunFilter: { id: 1, testProjectName: "project 1" }) { suiteRuns { specRuns { specDescription containers } } } }`, &response)
+			Expect(err).NotTo(HaveOccurred())
+
+			specRun := response.TestRun[0].SuiteRuns[0].SpecRuns[0]
+			Expect(specRun.SpecDescription).To(Equal("has no items"))
+			Expect(specRun.Containers).To(Equal(models.Containers{{Text: "Cart", Labels: []string{"cart"}}, {Text: "when empty"}}))
+		})
+
+		It("should return the failure details of spec runs", func() {
+			failure, err := models.Failure{Message: "expected 1 item", Kind: "assertion", File: "cart_test.go", Line: 42, Stderr: "cart is empty"}.Value()
+			Expect(err).NotTo(HaveOccurred())
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND test_project_name = $2`)).
+				WithArgs(1, "project 1").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name"}).AddRow(1, "project 1"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suite_runs" WHERE "suite_runs"."test_run_id" = $1`)).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id"}).AddRow(1, 1))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_runs" WHERE "spec_runs"."suite_id" = $1`)).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "suite_id", "failure"}).AddRow(1, 1, failure).AddRow(2, 1, nil))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_run_tags" WHERE "spec_run_tags"."spec_run_id" IN ($1,$2)`)).
+				WithArgs(1, 2).
+				WillReturnRows(sqlmock.NewRows([]string{"spec_run_id", "tag_id"}))
+
+			queryResolver := &resolvers.Resolver{DB: gormDb}
+			cli := client.New(handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: queryResolver})))
+
+			var response struct {
+				TestRun []struct {
+					SuiteRuns []struct {
+						SpecRuns []struct {
+							Failure *struct {
+								Message string
+								Kind    string
+								File    string
+								Line    int
+								Stderr  string
+							}
+						}
+					}
+				}
+			}
+			err = cli.Post(`query { testRun(testRunFilter: { id: 1, testProjectName: "project 1" }) { suiteRuns { specRuns { failure { message kind file line stderr } } } } }`, &response)
+			Expect(err).NotTo(HaveOccurred())
+
+			specRuns := response.TestRun[0].SuiteRuns[0].SpecRuns
+			Expect(specRuns[0].Failure.Message).To(Equal("expected 1 item"))
+			Expect(specRuns[0].Failure.Kind).To(Equal("assertion"))
+			Expect(specRuns[0].Failure.File).To(Equal("cart_test.go"))
+			Expect(specRuns[0].Failure.Line).To(Equal(42))
+			Expect(specRuns[0].Failure.Stderr).To(Equal("cart is empty"))
+			Expect(specRuns[1].Failure).To(BeNil())
+		})
+
+		It("should return the owners of spec runs", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND test_project_name = $2`)).
+				WithArgs(1, "project 1").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name"}).AddRow(1, "project 1"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suite_runs" WHERE "suite_runs"."test_run_id" = $1`)).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id", "suite_name"}).AddRow(1, 1, "Checkout"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_runs" WHERE "spec_runs"."suite_id" = $1`)).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "suite_id", "spec_description"}).AddRow(1, 1, "pays").AddRow(2, 1, "loads"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_run_tags" WHERE "spec_run_tags"."spec_run_id" IN ($1,$2)`)).
+				WithArgs(1, 2).
+				WillReturnRows(sqlmock.NewRows([]string{"spec_run_id", "tag_id"}).AddRow(2, 3))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE "tags"."id" = $1`)).
+				WithArgs(3).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "slow"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT ownership_rules.*, projects.name AS project_name FROM "ownership_rules" INNER JOIN projects ON projects.id = ownership_rules.project_id WHERE projects.name IN ($1)`)).
+				WithArgs("project 1").
+				WillReturnRows(sqlmock.NewRows([]string{"position", "suite", "tag", "owner", "project_name"}).
+					AddRow(0, "Check*", "", "payments", "project 1").
+					AddRow(1, "", "slow", "performance", "project 1"))
+
+			queryResolver := &resolvers.Resolver{DB: gormDb}
+			cli := client.New(handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: queryResolver})))
+
+			var response struct {
+				TestRun []struct {
+					SuiteRuns []struct {
+						SpecRuns []struct {
+							Owner string
+						}
+					}
+				}
+			}
+			err := cli.Post(`query { testRun(testRunFilter: { id: 1, testProjectName: "project 1" }) { suiteRuns { specRuns { owner } } } }`, &response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+			specRuns := response.TestRun[0].SuiteRuns[0].SpecRuns
+			Expect(specRuns[0].Owner).To(Equal("payments"))
+			Expect(specRuns[1].Owner).To(Equal("performance"))
+		})
+	})
+
+	Context("test quarantine", func() {
+		ruleColumns := []string{"id", "test_project_name", "test_case_id", "suite_name", "spec_description", "reason", "source", "score", "created_by", "created_at", "expires_at"}
+		createdAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+		expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+		It("should mark the quarantined spec runs", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND test_runs.deleted_at IS NULL`)).
+				WithArgs(1, 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name"}).AddRow(1, "project 1"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suite_runs" WHERE "suite_runs"."test_run_id" = $1`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id", "suite_name"}).AddRow(1, 1, "Cart"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_runs" WHERE "spec_runs"."suite_id" = $1`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "suite_id", "spec_description", "status", "test_case_id"}).
+					AddRow(1, 1, "adds one item", "failed", 7).
+					AddRow(2, 1, "empties", "passed", 8))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_run_tags"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"spec_run_id", "tag_id"}))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT ownership_rules.*`)).
+				WillReturnRows(sqlmock.NewRows([]string{"project_name"}))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "quarantine_rules" WHERE test_project_name IN ($1) AND expires_at > $2 ORDER BY id`)).
+				WithArgs("project 1", sqlmock.AnyArg()).
+				WillReturnRows(sqlmock.NewRows(ruleColumns).
+					AddRow(3, "project 1", 7, "Cart", "adds an item", "JIRA-123", "manual", 0, "jane", createdAt, expiresAt))
+
+			queryResolver := &resolvers.Resolver{DB: gormDb}
+			cli := client.New(handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: queryResolver})))
+
+			var response struct {
+				TestRunByID struct {
+					SuiteRuns []struct {
+						SpecRuns []struct {
+							Status      string
+							TestCaseID  int
+							Quarantined *bool
+						}
+					}
+				}
+			}
+			err := cli.Post(`query { testRunById(id: 1) { suiteRuns { specRuns { status testCaseId quarantined } } } }`, &response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+			specRuns := response.TestRunByID.SuiteRuns[0].SpecRuns
+			Expect(specRuns[0].Status).To(Equal("failed"))
+			Expect(specRuns[0].TestCaseID).To(Equal(7))
+			Expect(*specRuns[0].Quarantined).To(BeTrue())
+			Expect(specRuns[1].Quarantined).To(BeNil())
+		})
+
+		It("should list the active quarantine rules of a project", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "quarantine_rules" WHERE test_project_name IN ($1) AND expires_at > $2 ORDER BY id`)).
+				WithArgs("Checkout", sqlmock.AnyArg()).
+				WillReturnRows(sqlmock.NewRows(ruleColumns).
+					AddRow(3, "Checkout", 7, "Cart", "adds an item", "flakiness score 0.500 on main reached 0.400", "auto", 0.5, "", createdAt, expiresAt))
+
+			queryResolver := &resolvers.Resolver{DB: gormDb}
+			cli := client.New(handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: queryResolver})))
+
+			var response struct {
+				QuarantineRules []struct {
+					ID              int
+					TestCaseID      int
+					SpecDescription string
+					Source          string
+					Score           float64
+					ExpiresAt       string
+				}
+			}
+			err := cli.Post(`query { quarantineRules(project: "Checkout") { id testCaseId specDescription source score expiresAt } }`, &response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+			Expect(response.QuarantineRules).To(HaveLen(1))
+			rule := response.QuarantineRules[0]
+			Expect(rule.TestCaseID).To(Equal(7))
+			Expect(rule.Source).To(Equal("auto"))
+			Expect(rule.Score).To(Equal(0.5))
+			Expect(rule.ExpiresAt).To(Equal(expiresAt.Format(time.RFC3339)))
+		})
 	})
 
 	Context("test TestRunByID resolver", func() {
@@ -507,7 +781,7 @@ var _ = Describe("Handlers", func() {
 				AddRow(2, "project 2", "2")
 
 			// Expect a query to be executed against the "test_runs" table with the specified ID
-			mock.ExpectQuery("SELECT (.+) FROM \"test_runs\" WHERE id = \\$1  ORDER BY \"test_runs\".\"id\" LIMIT \\$2").
+			mock.ExpectQuery("SELECT (.+) FROM \"test_runs\" WHERE id = \\$1 AND test_runs.deleted_at IS NULL ORDER BY \"test_runs\".\"id\" LIMIT \\$2").
 				WithArgs(1, 1).
 				WillReturnRows(rows)
 
@@ -558,6 +832,196 @@ var _ = Describe("Handlers", func() {
 			Expect(response.TestRunByID.TestProjectName).ToNot(Equal("project 2"))
 			Expect(response.TestRunByID.TestSeed).ToNot(Equal(2))
 		})
+
+		It("should classify the failures of the test run", func() {
+			start := time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND test_runs.deleted_at IS NULL`)).
+				WithArgs(3, 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name", "start_time", "git_branch"}).AddRow(3, "Checkout", start, "main"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suite_runs" WHERE "suite_runs"."test_run_id" = $1`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id", "suite_name"}).AddRow(4, 3, "Cart"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_runs" WHERE "spec_runs"."suite_id" = $1`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "suite_id", "spec_description", "status", "test_case_id"}).
+					AddRow(30, 4, "adds an item", "failed", 7).
+					AddRow(31, 4, "empties", "failed", 8).
+					AddRow(32, 4, "checks out", "passed", 9))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_run_tags"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"spec_run_id", "tag_id"}))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT ownership_rules.*`)).
+				WillReturnRows(sqlmock.NewRows([]string{"project_name"}))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "quarantine_rules"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			mock.ExpectQuery(regexp.QuoteMeta(`FROM "spec_runs" INNER JOIN suite_runs ON suite_runs.id = spec_runs.suite_id INNER JOIN test_runs ON test_runs.id = suite_runs.test_run_id WHERE (test_runs.test_project_name = $1 AND test_runs.git_branch = $2)`)).
+				WithArgs("Checkout", "main", start.Add(-30*24*time.Hour), start, 3, "passed", "flaky", "failed", "errored", 7, 8, 9).
+				WillReturnRows(sqlmock.NewRows([]string{"test_case_id", "test_run_id", "spec_run_id", "suite_name", "spec_description", "status", "start_time", "git_branch", "git_sha"}).
+					AddRow(7, 1, 10, "Cart", "adds an item", "passed", start.Add(-2*time.Hour), "main", "aaa").
+					AddRow(8, 1, 11, "Cart", "empties", "failed", start.Add(-2*time.Hour), "main", "aaa").
+					AddRow(8, 2, 21, "Cart", "empties", "failed", start.Add(-time.Hour), "main", "bbb").
+					AddRow(9, 2, 22, "Cart", "checks out", "failed", start.Add(-time.Hour), "main", "bbb"))
+
+			queryResolver := &resolvers.Resolver{DB: gormDb}
+			cli := client.New(handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: queryResolver})))
+
+			var response struct {
+				TestRunByID struct {
+					SuiteRuns []struct {
+						SpecRuns []struct {
+							Classification struct {
+								Kind                  string
+								FailingSince          string
+								FailingSinceTestRunID int
+								FailingSinceGitSha    string
+								PreviousTestRunID     int
+							}
+						}
+					}
+				}
+			}
+			err := cli.Post(`query { testRunById(id: 3) { suiteRuns { specRuns { classification {
+				kind failingSince failingSinceTestRunId failingSinceGitSha previousTestRunId } } } } }`, &response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+			specRuns := response.TestRunByID.SuiteRuns[0].SpecRuns
+			Expect(specRuns[0].Classification.Kind).To(Equal("new_failure"))
+			Expect(specRuns[0].Classification.PreviousTestRunID).To(Equal(1))
+			Expect(specRuns[1].Classification.Kind).To(Equal("still_failing"))
+			Expect(specRuns[1].Classification.FailingSince).To(Equal("2024-04-20T10:00:00Z"))
+			Expect(specRuns[1].Classification.FailingSinceTestRunID).To(Equal(1))
+			Expect(specRuns[1].Classification.FailingSinceGitSha).To(Equal("aaa"))
+			Expect(specRuns[2].Classification.Kind).To(Equal("fixed"))
+			Expect(specRuns[2].Classification.PreviousTestRunID).To(Equal(2))
+		})
+	})
+
+	Context("test flakySpecs resolver", func() {
+		It("should rank the flaky specs of a project with their evidence", func() {
+			start := time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+			mock.ExpectQuery(regexp.QuoteMeta(`FROM "spec_runs" INNER JOIN suite_runs ON suite_runs.id = spec_runs.suite_id INNER JOIN test_runs ON test_runs.id = suite_runs.test_run_id WHERE test_runs.test_project_name = $1`)).
+				WithArgs("Checkout", start, start.Add(24*time.Hour), "passed", "flaky", "failed", "errored", flaky.MaxExecutions, flaky.DefaultMinExecutions).
+				WillReturnRows(sqlmock.NewRows([]string{"test_case_id", "test_run_id", "spec_run_id", "suite_name", "spec_description", "status", "start_time", "git_branch", "git_sha"}).
+					AddRow(7, 1, 10, "Cart", "adds an item", "passed", start, "main", "aaa").
+					AddRow(7, 2, 20, "Cart", "adds an item", "flaky", start.Add(time.Hour), "main", "bbb").
+					AddRow(7, 3, 30, "Cart", "adds an item", "failed", start.Add(2*time.Hour), "main", "bbb"))
+
+			queryResolver := &resolvers.Resolver{DB: gormDb}
+			cli := client.New(handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: queryResolver})))
+
+			var response struct {
+				FlakySpecs []struct {
+					TestCaseID    int
+					Score         float64
+					RetriedPasses int
+					CommitFlips   int
+					Evidence      []struct {
+						TestRunID int
+						Reason    string
+						URL       string
+					}
+				}
+			}
+			err := cli.Post(`query { flakySpecs(project: "Checkout", startTime: "2024-04-20T12:00:00Z", endTime: "2024-04-21T12:00:00Z") {
+				testCaseId score retriedPasses commitFlips evidence { testRunId reason url } } }`, &response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+			Expect(response.FlakySpecs).To(HaveLen(1))
+			Expect(response.FlakySpecs[0].TestCaseID).To(Equal(7))
+			Expect(response.FlakySpecs[0].RetriedPasses).To(Equal(1))
+			Expect(response.FlakySpecs[0].CommitFlips).To(Equal(1))
+			Expect(response.FlakySpecs[0].Evidence[0].URL).To(Equal("/reports/testruns/3"))
+			Expect(response.FlakySpecs[0].Evidence[0].Reason).To(Equal("commit_flip"))
+		})
+
+		It("should reject an invalid start time", func() {
+			queryResolver := &resolvers.Resolver{DB: gormDb}
+			cli := client.New(handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: queryResolver})))
+
+			var response struct{ FlakySpecs []struct{ Score float64 } }
+			err := cli.Post(`query { flakySpecs(project: "Checkout", startTime: "yesterday") { score } }`, &response)
+			Expect(err).To(MatchError(ContainSubstring("invalid startTime")))
+		})
+	})
+
+	Context("test failure group resolvers", func() {
+		It("should group the failures of a project", func() {
+			start := time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+			mock.ExpectQuery(regexp.QuoteMeta(`FROM "spec_runs" INNER JOIN suite_runs ON suite_runs.id = spec_runs.suite_id INNER JOIN test_runs ON test_runs.id = suite_runs.test_run_id WHERE test_runs.test_project_name = $1`)).
+				WithArgs("Checkout", start, start.Add(24*time.Hour), "failed", "errored", "main", failures.MaxSpecFailures).
+				WillReturnRows(sqlmock.NewRows([]string{"test_case_id", "suite_name", "spec_description", "message", "count", "first_seen", "last_seen", "last_test_run_id", "last_spec_run_id", "test_run_ids"}).
+					AddRow(7, "Cart", "adds an item", "connect to 10.0.0.5:5432 refused", 1, start, start, 1, 10, "1").
+					AddRow(8, "Cart", "empties", "connect to 10.0.0.6:5432 refused", 1, start.Add(time.Hour), start.Add(time.Hour), 2, 20, "2"))
+
+			queryResolver := &resolvers.Resolver{DB: gormDb}
+			cli := client.New(handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: queryResolver})))
+
+			var response struct {
+				FailureGroups []struct {
+					Pattern   string
+					Count     int
+					TestRuns  int
+					FirstSeen string
+					LastSeen  string
+					Specs     []struct {
+						TestCaseID    int
+						LastTestRunID int
+					}
+				}
+			}
+			err := cli.Post(`query { failureGroups(project: "Checkout", branch: "main", startTime: "2024-04-20T12:00:00Z", endTime: "2024-04-21T12:00:00Z") {
+				pattern count testRuns firstSeen lastSeen specs { testCaseId lastTestRunId } } }`, &response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+			Expect(response.FailureGroups).To(HaveLen(1))
+			Expect(response.FailureGroups[0].Pattern).To(Equal("connect to <addr> refused"))
+			Expect(response.FailureGroups[0].Count).To(Equal(2))
+			Expect(response.FailureGroups[0].TestRuns).To(Equal(2))
+			Expect(response.FailureGroups[0].FirstSeen).To(Equal("2024-04-20T12:00:00Z"))
+			Expect(response.FailureGroups[0].LastSeen).To(Equal("2024-04-20T13:00:00Z"))
+			Expect(response.FailureGroups[0].Specs).To(HaveLen(2))
+			Expect(response.FailureGroups[0].Specs[0].TestCaseID).To(Equal(7))
+		})
+
+		It("should reject an invalid limit", func() {
+			queryResolver := &resolvers.Resolver{DB: gormDb}
+			cli := client.New(handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: queryResolver})))
+
+			var response struct{ FailureGroups []struct{ Count int } }
+			err := cli.Post(`query { failureGroups(project: "Checkout", limit: 0) { count } }`, &response)
+			Expect(err).To(MatchError(ContainSubstring("limit must be between 1 and 1000")))
+		})
+
+		It("should group the failures of a test run", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "test_runs" WHERE id = $1 AND "test_runs"."deleted_at" IS NULL ORDER BY "test_runs"."id" LIMIT $2`)).
+				WithArgs(2, 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name"}).AddRow(2, "Checkout"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suite_runs" WHERE "suite_runs"."test_run_id" = $1`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "test_run_id", "suite_name"}).AddRow(4, 2, "Cart"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "spec_runs" WHERE "spec_runs"."suite_id" = $1`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "suite_id", "spec_description", "status", "message"}).
+					AddRow(1, 4, "adds an item", "failed", "Expected 1 to equal 2").
+					AddRow(2, 4, "empties", "failed", "Expected 3 to equal 4"))
+
+			queryResolver := &resolvers.Resolver{DB: gormDb}
+			cli := client.New(handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: queryResolver})))
+
+			var response struct {
+				TestRunFailureGroups []struct {
+					Pattern string
+					Count   int
+					Specs   []struct{ SpecDescription string }
+				}
+			}
+			err := cli.Post(`query { testRunFailureGroups(testRunId: 2) { pattern count specs { specDescription } } }`, &response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+			Expect(response.TestRunFailureGroups).To(HaveLen(1))
+			Expect(response.TestRunFailureGroups[0].Pattern).To(Equal("Expected <n> to equal <n>"))
+			Expect(response.TestRunFailureGroups[0].Count).To(Equal(2))
+			Expect(response.TestRunFailureGroups[0].Specs).To(HaveLen(2))
+		})
 	})
 
 })
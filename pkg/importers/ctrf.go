@@ -0,0 +1,381 @@
+package importers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+)
+
+const (
+	ctrfReportFormat = "CTRF"
+	ctrfSpecVersion  = "0.0.0"
+	ctrfToolName     = "fern-reporter"
+	ctrfStatusOther  = "other"
+	ctrfDefaultSuite = "default"
+)
+
+// CTRFReport is a Common Test Report Format document (https://ctrf.io).
+type CTRFReport struct {
+	ReportFormat string      `json:"reportFormat"`
+	SpecVersion  string      `json:"specVersion"`
+	Results      CTRFResults `json:"results"`
+}
+
+type CTRFResults struct {
+	Tool        CTRFTool               `json:"tool"`
+	Summary     CTRFSummary            `json:"summary"`
+	Tests       []CTRFTest             `json:"tests"`
+	Environment map[string]interface{} `json:"environment,omitempty"`
+	Extra       CTRFExtra              `json:"extra"`
+}
+
+type CTRFTool struct {
+	Name string `json:"name"`
+}
+
+type CTRFSummary struct {
+	Tests   int   `json:"tests"`
+	Passed  int   `json:"passed"`
+	Failed  int   `json:"failed"`
+	Pending int   `json:"pending"`
+	Skipped int   `json:"skipped"`
+	Other   int   `json:"other"`
+	Start   int64 `json:"start"`
+	Stop    int64 `json:"stop"`
+}
+
+// CTRFTest is a test of a CTRF report. Statuses CTRF has no equivalent for
+// are kept in RawStatus, and a flaky spec is a passed test with Flaky set.
+type CTRFTest struct {
+	Name      string         `json:"name"`
+	Status    string         `json:"status"`
+	Duration  int64          `json:"duration"`
+	Start     int64          `json:"start,omitempty"`
+	Stop      int64          `json:"stop,omitempty"`
+	Suite     string         `json:"suite,omitempty"`
+	Message   string         `json:"message,omitempty"`
+	Trace     string         `json:"trace,omitempty"`
+	FilePath  string         `json:"filePath,omitempty"`
+	Line      int            `json:"line,omitempty"`
+	RawStatus string         `json:"rawStatus,omitempty"`
+	Tags      []string       `json:"tags,omitempty"`
+	Retries   int            `json:"retries,omitempty"`
+	Flaky     bool           `json:"flaky,omitempty"`
+	Stdout    []string       `json:"stdout,omitempty"`
+	Stderr    []string       `json:"stderr,omitempty"`
+	Extra     *CTRFTestExtra `json:"extra,omitempty"`
+}
+
+// CTRFTestExtra carries the failure kind of a spec, which CTRF has no field
+// for.
+type CTRFTestExtra struct {
+	FailureKind string `json:"failureKind,omitempty"`
+}
+
+// CTRFExtra carries the Fern fields that have no CTRF equivalent so a run
+// survives an export/import round trip.
+type CTRFExtra struct {
+	TestRunID       uint64 `json:"testRunId,omitempty"`
+	TestProjectName string `json:"testProjectName,omitempty"`
+	TestSeed        uint64 `json:"testSeed,omitempty"`
+}
+
+// ParseCTRF converts a CTRF JSON report into a TestRun, grouping tests into
+// suite runs by their "suite" field. When projectName is empty the project
+// stored by a previous export, or the environment appName, is used.
+func ParseCTRF(r io.Reader, projectName string) (*models.TestRun, error) {
+	var report CTRFReport
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		return nil, fmt.Errorf("error parsing ctrf report: %w", err)
+	}
+	if report.ReportFormat != "" && report.ReportFormat != ctrfReportFormat {
+		return nil, fmt.Errorf("unsupported report format %q", report.ReportFormat)
+	}
+
+	results := report.Results
+	if projectName == "" {
+		projectName = results.Extra.TestProjectName
+	}
+	if projectName == "" {
+		projectName, _ = results.Environment["appName"].(string)
+	}
+	if projectName == "" {
+		return nil, errors.New("ctrf report has no project name")
+	}
+
+	clock := time.Now()
+	if results.Summary.Start > 0 {
+		clock = time.UnixMilli(results.Summary.Start)
+	}
+	testRun := &models.TestRun{
+		TestProjectName: projectName,
+		TestSeed:        results.Extra.TestSeed,
+		StartTime:       clock,
+		EndTime:         clock,
+	}
+	applyCTRFEnvironment(testRun, results.Environment)
+	if results.Summary.Stop > 0 {
+		testRun.EndTime = time.UnixMilli(results.Summary.Stop)
+	}
+
+	suiteIndex := map[string]int{}
+	for _, test := range results.Tests {
+		suiteName := test.Suite
+		if suiteName == "" {
+			suiteName = ctrfDefaultSuite
+		}
+
+		i, ok := suiteIndex[suiteName]
+		if !ok {
+			i = len(testRun.SuiteRuns)
+			suiteIndex[suiteName] = i
+			testRun.SuiteRuns = append(testRun.SuiteRuns, models.SuiteRun{SuiteName: suiteName})
+		}
+
+		specRun := convertCTRFTest(test, &clock)
+		suiteRun := &testRun.SuiteRuns[i]
+		if len(suiteRun.SpecRuns) == 0 || specRun.StartTime.Before(suiteRun.StartTime) {
+			suiteRun.StartTime = specRun.StartTime
+		}
+		if specRun.EndTime.After(suiteRun.EndTime) {
+			suiteRun.EndTime = specRun.EndTime
+		}
+		suiteRun.SpecRuns = append(suiteRun.SpecRuns, specRun)
+	}
+
+	for _, suiteRun := range testRun.SuiteRuns {
+		if suiteRun.StartTime.Before(testRun.StartTime) {
+			testRun.StartTime = suiteRun.StartTime
+		}
+		if suiteRun.EndTime.After(testRun.EndTime) {
+			testRun.EndTime = suiteRun.EndTime
+		}
+	}
+
+	return testRun, nil
+}
+
+func convertCTRFTest(test CTRFTest, clock *time.Time) models.SpecRun {
+	// Tests without explicit timestamps are laid out sequentially
+	start := *clock
+	if test.Start > 0 {
+		start = time.UnixMilli(test.Start)
+	}
+	end := start.Add(time.Duration(test.Duration) * time.Millisecond)
+	if test.Stop > 0 {
+		end = time.UnixMilli(test.Stop)
+	}
+	*clock = end
+
+	specRun := models.SpecRun{
+		SpecDescription: test.Name,
+		Status:          ctrfToFernStatus(test),
+		Message:         strings.TrimSpace(test.Message),
+		Failure:         ctrfFailure(test),
+		StartTime:       start,
+		EndTime:         end,
+	}
+	for _, tag := range test.Tags {
+		specRun.Tags = appendTag(specRun.Tags, tag)
+	}
+	return specRun
+}
+
+// ctrfFailure keeps the failure details of a test, with its trace apart from
+// its message.
+func ctrfFailure(test CTRFTest) *models.Failure {
+	failure := models.Failure{
+		StackTrace: strings.TrimSpace(test.Trace),
+		File:       test.FilePath,
+		Line:       test.Line,
+		Stdout:     strings.Join(test.Stdout, "\n"),
+		Stderr:     strings.Join(test.Stderr, "\n"),
+	}
+	if test.Extra != nil {
+		failure.Kind = test.Extra.FailureKind
+	}
+	if failure == (models.Failure{}) && !utils.IsFailedStatus(ctrfToFernStatus(test)) {
+		return nil
+	}
+	failure.Message = strings.TrimSpace(test.Message)
+	return &failure
+}
+
+// ToCTRF converts a stored TestRun into a CTRF report.
+func ToCTRF(testRun models.TestRun) CTRFReport {
+	results := CTRFResults{
+		Tool: CTRFTool{Name: ctrfToolName},
+		Summary: CTRFSummary{
+			Start: testRun.StartTime.UnixMilli(),
+			Stop:  testRun.EndTime.UnixMilli(),
+		},
+		Tests: []CTRFTest{},
+		Extra: CTRFExtra{
+			TestRunID:       testRun.ID,
+			TestProjectName: testRun.TestProjectName,
+			TestSeed:        testRun.TestSeed,
+		},
+	}
+
+	results.Environment = ctrfEnvironment(testRun)
+
+	for _, suiteRun := range testRun.SuiteRuns {
+		for _, specRun := range suiteRun.SpecRuns {
+			test := CTRFTest{
+				Name:     specRun.SpecDescription,
+				Duration: specRun.EndTime.Sub(specRun.StartTime).Milliseconds(),
+				Start:    specRun.StartTime.UnixMilli(),
+				Stop:     specRun.EndTime.UnixMilli(),
+				Suite:    suiteRun.SuiteName,
+				Message:  specRun.Message,
+			}
+			setCTRFStatus(&test, specRun)
+			setCTRFFailure(&test, specRun.Failure)
+			for _, tag := range specRun.Tags {
+				test.Tags = append(test.Tags, tag.Name)
+			}
+			results.Tests = append(results.Tests, test)
+			results.Summary.add(test.Status)
+		}
+	}
+
+	return CTRFReport{
+		ReportFormat: ctrfReportFormat,
+		SpecVersion:  ctrfSpecVersion,
+		Results:      results,
+	}
+}
+
+// ctrfEnvironmentFields pairs the CTRF environment properties with the CI/VCS
+// metadata of a test run.
+func ctrfEnvironmentFields(testRun *models.TestRun) map[string]*string {
+	return map[string]*string{
+		"commit":          &testRun.GitSha,
+		"branchName":      &testRun.GitBranch,
+		"repositoryUrl":   &testRun.GitRepoURL,
+		"buildName":       &testRun.CIProvider,
+		"buildNumber":     &testRun.BuildNumber,
+		"buildUrl":        &testRun.BuildURL,
+		"testEnvironment": &testRun.Environment,
+	}
+}
+
+func applyCTRFEnvironment(testRun *models.TestRun, environment map[string]interface{}) {
+	for key, field := range ctrfEnvironmentFields(testRun) {
+		if value, ok := environment[key].(string); ok {
+			*field = value
+		}
+	}
+	// CTRF has no pull request property, so exports keep it in a custom one
+	if pr, ok := environment["pullRequestNumber"].(float64); ok {
+		testRun.PullRequestNumber = int(pr)
+	}
+	if actor, ok := environment["triggeredBy"].(string); ok {
+		testRun.TriggeredBy = actor
+	}
+}
+
+func ctrfEnvironment(testRun models.TestRun) map[string]interface{} {
+	environment := map[string]interface{}{}
+	for key, field := range ctrfEnvironmentFields(&testRun) {
+		if *field != "" {
+			environment[key] = *field
+		}
+	}
+	if testRun.PullRequestNumber != 0 {
+		environment["pullRequestNumber"] = testRun.PullRequestNumber
+	}
+	if testRun.TriggeredBy != "" {
+		environment["triggeredBy"] = testRun.TriggeredBy
+	}
+	if len(environment) == 0 {
+		return nil
+	}
+	return environment
+}
+
+func (s *CTRFSummary) add(status string) {
+	s.Tests++
+	switch status {
+	case utils.StatusPassed:
+		s.Passed++
+	case utils.StatusFailed:
+		s.Failed++
+	case utils.StatusSkipped:
+		s.Skipped++
+	case utils.StatusPending:
+		s.Pending++
+	default:
+		s.Other++
+	}
+}
+
+// ctrfToFernStatus maps a CTRF status back to the Fern status it was
+// exported from.
+func ctrfToFernStatus(test CTRFTest) string {
+	switch test.Status {
+	case utils.StatusPassed:
+		if test.Flaky {
+			return utils.StatusFlaky
+		}
+		return utils.StatusPassed
+	case utils.StatusFailed:
+		if test.RawStatus == utils.StatusErrored {
+			return utils.StatusErrored
+		}
+		return utils.StatusFailed
+	case utils.StatusSkipped, utils.StatusPending:
+		return test.Status
+	default:
+		return utils.StatusFailed
+	}
+}
+
+// setCTRFStatus exports the status of a spec run. Errored specs fail with
+// their raw status kept, and flaky specs pass with their retries.
+func setCTRFStatus(test *CTRFTest, specRun models.SpecRun) {
+	switch specRun.Status {
+	case utils.StatusPassed, utils.StatusFailed, utils.StatusSkipped, utils.StatusPending:
+		test.Status = specRun.Status
+	case utils.StatusErrored:
+		test.Status = utils.StatusFailed
+		test.RawStatus = specRun.Status
+	case utils.StatusFlaky:
+		test.Status = utils.StatusPassed
+		test.Flaky = true
+		test.Retries = 1
+		if len(specRun.Attempts) > 1 {
+			test.Retries = len(specRun.Attempts) - 1
+		}
+	default:
+		test.Status = ctrfStatusOther
+		test.RawStatus = specRun.Status
+	}
+}
+
+func setCTRFFailure(test *CTRFTest, failure *models.Failure) {
+	if failure == nil {
+		return
+	}
+	if test.Message == "" {
+		test.Message = failure.Message
+	}
+	test.Trace = failure.StackTrace
+	test.FilePath = failure.File
+	test.Line = failure.Line
+	if failure.Stdout != "" {
+		test.Stdout = strings.Split(failure.Stdout, "\n")
+	}
+	if failure.Stderr != "" {
+		test.Stderr = strings.Split(failure.Stderr, "\n")
+	}
+	if failure.Kind != "" {
+		test.Extra = &CTRFTestExtra{FailureKind: failure.Kind}
+	}
+}
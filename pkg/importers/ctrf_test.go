@@ -0,0 +1,185 @@
+package importers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/guidewire/fern-reporter/pkg/importers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CTRF", func() {
+	Describe("ParseCTRF", func() {
+		report := `{
+  "reportFormat": "CTRF",
+  "specVersion": "0.0.0",
+  "results": {
+    "tool": {"name": "jest"},
+    "summary": {"tests": 3, "passed": 1, "failed": 1, "pending": 1, "skipped": 0, "other": 0, "start": 1713614400000, "stop": 1713614405000},
+    "environment": {"appName": "checkout", "branchName": "main", "commit": "0a1b2c3", "buildNumber": "17"},
+    "tests": [
+      {"name": "adds an item", "status": "passed", "duration": 1000, "suite": "cart", "tags": ["smoke"]},
+      {"name": "removes an item", "status": "failed", "duration": 500, "suite": "cart", "message": "expected 0", "trace": "at cart.test.js:10"},
+      {"name": "pays", "status": "pending", "duration": 0, "start": 1713614402000, "stop": 1713614402000}
+    ]
+  }
+}`
+
+		It("should map tests onto suites and specs", func() {
+			testRun, err := importers.ParseCTRF(strings.NewReader(report), "")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(testRun.TestProjectName).To(Equal("checkout"))
+			Expect(testRun.GitBranch).To(Equal("main"))
+			Expect(testRun.GitSha).To(Equal("0a1b2c3"))
+			Expect(testRun.BuildNumber).To(Equal("17"))
+			Expect(testRun.StartTime).To(BeTemporally("==", time.UnixMilli(1713614400000)))
+			Expect(testRun.EndTime).To(BeTemporally("==", time.UnixMilli(1713614405000)))
+			Expect(testRun.SuiteRuns).To(HaveLen(2))
+
+			cart := testRun.SuiteRuns[0]
+			Expect(cart.SuiteName).To(Equal("cart"))
+			Expect(cart.SpecRuns).To(HaveLen(2))
+			Expect(cart.SpecRuns[0].Status).To(Equal("passed"))
+			Expect(cart.SpecRuns[0].Tags).To(Equal([]models.Tag{{Name: "smoke"}}))
+			Expect(cart.SpecRuns[0].EndTime.Sub(cart.SpecRuns[0].StartTime)).To(Equal(time.Second))
+			Expect(cart.SpecRuns[1].Status).To(Equal("failed"))
+			Expect(cart.SpecRuns[1].Message).To(Equal("expected 0"))
+			Expect(cart.SpecRuns[1].Failure).To(Equal(&models.Failure{Message: "expected 0", StackTrace: "at cart.test.js:10"}))
+			Expect(cart.SpecRuns[1].StartTime).To(BeTemporally("==", cart.SpecRuns[0].EndTime))
+
+			Expect(testRun.SuiteRuns[1].SuiteName).To(Equal("default"))
+			Expect(testRun.SuiteRuns[1].SpecRuns[0].Status).To(Equal("pending"))
+			Expect(testRun.SuiteRuns[1].SpecRuns[0].StartTime).To(BeTemporally("==", time.UnixMilli(1713614402000)))
+		})
+
+		It("should reject other report formats", func() {
+			_, err := importers.ParseCTRF(strings.NewReader(`{"reportFormat": "JUnit"}`), "p")
+			Expect(err).To(MatchError(`unsupported report format "JUnit"`))
+		})
+
+		It("should require a project name", func() {
+			_, err := importers.ParseCTRF(strings.NewReader(`{"results": {"tests": []}}`), "")
+			Expect(err).To(MatchError("ctrf report has no project name"))
+		})
+	})
+
+	Describe("ToCTRF", func() {
+		start := time.UnixMilli(1713614400000)
+		testRun := models.TestRun{
+			ID:                7,
+			TestProjectName:   "checkout",
+			TestSeed:          42,
+			StartTime:         start,
+			EndTime:           start.Add(3 * time.Second),
+			GitSha:            "0a1b2c3d4e5f",
+			GitBranch:         "main",
+			PullRequestNumber: 12,
+			BuildURL:          "https://ci.example.com/builds/99",
+			SuiteRuns: []models.SuiteRun{
+				{
+					SuiteName: "cart",
+					StartTime: start,
+					EndTime:   start.Add(3 * time.Second),
+					SpecRuns: []models.SpecRun{
+						{SpecDescription: "adds", Status: "passed", StartTime: start, EndTime: start.Add(time.Second), Tags: []models.Tag{{Name: "smoke"}}},
+						{SpecDescription: "removes", Status: "failed", Message: "boom", StartTime: start.Add(time.Second), EndTime: start.Add(3 * time.Second)},
+						{SpecDescription: "pays", Status: "skipped", StartTime: start.Add(3 * time.Second), EndTime: start.Add(3 * time.Second)},
+					},
+				},
+			},
+		}
+
+		It("should export every spec with a summary", func() {
+			report := importers.ToCTRF(testRun)
+
+			Expect(report.ReportFormat).To(Equal("CTRF"))
+			Expect(report.Results.Summary).To(Equal(importers.CTRFSummary{
+				Tests: 3, Passed: 1, Failed: 1, Skipped: 1,
+				Start: 1713614400000, Stop: 1713614403000,
+			}))
+			Expect(report.Results.Tests).To(HaveLen(3))
+			Expect(report.Results.Tests[1]).To(Equal(importers.CTRFTest{
+				Name: "removes", Status: "failed", Duration: 2000,
+				Start: 1713614401000, Stop: 1713614403000, Suite: "cart", Message: "boom",
+			}))
+			Expect(report.Results.Extra.TestSeed).To(Equal(uint64(42)))
+		})
+
+		It("should round trip through ParseCTRF", func() {
+			var buf bytes.Buffer
+			Expect(json.NewEncoder(&buf).Encode(importers.ToCTRF(testRun))).To(Succeed())
+
+			imported, err := importers.ParseCTRF(&buf, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(imported.TestProjectName).To(Equal(testRun.TestProjectName))
+			Expect(imported.TestSeed).To(Equal(testRun.TestSeed))
+			Expect(imported.GitSha).To(Equal(testRun.GitSha))
+			Expect(imported.GitBranch).To(Equal(testRun.GitBranch))
+			Expect(imported.PullRequestNumber).To(Equal(testRun.PullRequestNumber))
+			Expect(imported.BuildURL).To(Equal(testRun.BuildURL))
+			Expect(imported.StartTime).To(BeTemporally("==", testRun.StartTime))
+			Expect(imported.EndTime).To(BeTemporally("==", testRun.EndTime))
+			Expect(imported.SuiteRuns).To(HaveLen(1))
+			Expect(imported.SuiteRuns[0].SuiteName).To(Equal("cart"))
+			for i, specRun := range imported.SuiteRuns[0].SpecRuns {
+				original := testRun.SuiteRuns[0].SpecRuns[i]
+				Expect(specRun.SpecDescription).To(Equal(original.SpecDescription))
+				Expect(specRun.Status).To(Equal(original.Status))
+				Expect(specRun.Message).To(Equal(original.Message))
+				Expect(specRun.Tags).To(Equal(original.Tags))
+				Expect(specRun.StartTime).To(BeTemporally("==", original.StartTime))
+				Expect(specRun.EndTime).To(BeTemporally("==", original.EndTime))
+			}
+		})
+
+		It("should round trip every status and the failure details", func() {
+			failure := &models.Failure{
+				Message:    "expected 0",
+				Kind:       "assertion",
+				File:       "cart_test.go",
+				Line:       12,
+				StackTrace: "cart_test.go:12\ncart_test.go:30",
+				Stdout:     "adding\nremoving",
+				Stderr:     "warning",
+			}
+			statuses := models.TestRun{TestProjectName: "checkout", StartTime: start, EndTime: start, SuiteRuns: []models.SuiteRun{{
+				SuiteName: "cart",
+				SpecRuns: []models.SpecRun{
+					{SpecDescription: "passes", Status: "passed"},
+					{SpecDescription: "fails", Status: "failed", Message: "expected 0", Failure: failure},
+					{SpecDescription: "errors", Status: "errored", Message: "connection refused", Failure: &models.Failure{Message: "connection refused", StackTrace: "at db.go:3"}},
+					{SpecDescription: "is skipped", Status: "skipped"},
+					{SpecDescription: "is pending", Status: "pending"},
+					{SpecDescription: "passes on a retry", Status: "flaky", Attempts: models.SpecAttempts{{Status: "failed"}, {Status: "failed"}, {Status: "passed"}}},
+				},
+			}}}
+
+			report := importers.ToCTRF(statuses)
+			Expect(report.Results.Summary.Passed).To(Equal(2))
+			Expect(report.Results.Summary.Failed).To(Equal(2))
+			Expect(report.Results.Summary.Pending).To(Equal(1))
+			Expect(report.Results.Summary.Other).To(BeZero())
+			Expect(report.Results.Tests[1].Trace).To(Equal(failure.StackTrace))
+			Expect(report.Results.Tests[5].Flaky).To(BeTrue())
+			Expect(report.Results.Tests[5].Retries).To(Equal(2))
+
+			var buf bytes.Buffer
+			Expect(json.NewEncoder(&buf).Encode(report)).To(Succeed())
+			imported, err := importers.ParseCTRF(&buf, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			for i, specRun := range imported.SuiteRuns[0].SpecRuns {
+				original := statuses.SuiteRuns[0].SpecRuns[i]
+				Expect(specRun.Status).To(Equal(original.Status), original.SpecDescription)
+				Expect(specRun.Message).To(Equal(original.Message), original.SpecDescription)
+				Expect(specRun.Failure).To(Equal(original.Failure), original.SpecDescription)
+			}
+		})
+	})
+})
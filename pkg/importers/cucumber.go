@@ -0,0 +1,186 @@
+package importers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+)
+
+type cucumberFeature struct {
+	URI      string            `json:"uri"`
+	Name     string            `json:"name"`
+	Tags     []cucumberTag     `json:"tags"`
+	Elements []cucumberElement `json:"elements"`
+}
+
+type cucumberElement struct {
+	Keyword        string         `json:"keyword"`
+	Type           string         `json:"type"`
+	Name           string         `json:"name"`
+	StartTimestamp string         `json:"start_timestamp"`
+	Tags           []cucumberTag  `json:"tags"`
+	Before         []cucumberStep `json:"before"`
+	Steps          []cucumberStep `json:"steps"`
+	After          []cucumberStep `json:"after"`
+}
+
+type cucumberStep struct {
+	Keyword string         `json:"keyword"`
+	Name    string         `json:"name"`
+	Result  cucumberResult `json:"result"`
+}
+
+type cucumberResult struct {
+	Status       string `json:"status"`
+	Duration     int64  `json:"duration"`
+	ErrorMessage string `json:"error_message"`
+}
+
+type cucumberTag struct {
+	Name string `json:"name"`
+}
+
+// ParseCucumberJSON converts a cucumber JSON report (as produced by godog and
+// cucumber-jvm) into a TestRun. Features become suite runs and scenarios
+// become spec runs; every example of a scenario outline is its own spec run.
+func ParseCucumberJSON(r io.Reader, projectName string) (*models.TestRun, error) {
+	if projectName == "" {
+		return nil, errors.New("project name is required")
+	}
+
+	var features []cucumberFeature
+	if err := json.NewDecoder(r).Decode(&features); err != nil {
+		return nil, fmt.Errorf("error parsing cucumber report: %w", err)
+	}
+
+	// Cucumber only reports step durations, so scenarios without a start
+	// timestamp are laid out sequentially from the time of the import.
+	clock := time.Now()
+	testRun := &models.TestRun{TestProjectName: projectName, StartTime: clock, EndTime: clock}
+
+	for i, feature := range features {
+		suiteRun := convertCucumberFeature(feature, &clock)
+		testRun.SuiteRuns = append(testRun.SuiteRuns, suiteRun)
+		if i == 0 || suiteRun.StartTime.Before(testRun.StartTime) {
+			testRun.StartTime = suiteRun.StartTime
+		}
+		if suiteRun.EndTime.After(testRun.EndTime) {
+			testRun.EndTime = suiteRun.EndTime
+		}
+	}
+
+	return testRun, nil
+}
+
+func convertCucumberFeature(feature cucumberFeature, clock *time.Time) models.SuiteRun {
+	name := feature.Name
+	if name == "" {
+		name = feature.URI
+	}
+	suiteRun := models.SuiteRun{SuiteName: name, StartTime: *clock, EndTime: *clock}
+
+	var background []cucumberStep
+	outlineExamples := map[string]int{}
+	for _, element := range feature.Elements {
+		// Backgrounds are reported as separate elements ahead of the scenario
+		// they belong to; fold their steps into that scenario.
+		if element.Type == "background" {
+			background = append(background, element.Before...)
+			background = append(background, element.Steps...)
+			background = append(background, element.After...)
+			continue
+		}
+
+		steps := append(append([]cucumberStep{}, background...), element.Before...)
+		steps = append(steps, element.Steps...)
+		steps = append(steps, element.After...)
+		background = nil
+
+		description := element.Name
+		if strings.Contains(strings.ToLower(element.Keyword), "outline") {
+			outlineExamples[element.Name]++
+			description = fmt.Sprintf("%s (example #%d)", element.Name, outlineExamples[element.Name])
+		}
+
+		start := *clock
+		if element.StartTimestamp != "" {
+			if parsed, err := time.Parse(time.RFC3339Nano, element.StartTimestamp); err == nil {
+				start = parsed
+			}
+		}
+		end := start.Add(cucumberDuration(steps))
+		*clock = end
+
+		specRun := models.SpecRun{
+			SpecDescription: description,
+			StartTime:       start,
+			EndTime:         end,
+		}
+		specRun.Status, specRun.Message = cucumberStatus(steps)
+		for _, tag := range append(append([]cucumberTag{}, feature.Tags...), element.Tags...) {
+			specRun.Tags = appendTag(specRun.Tags, strings.TrimPrefix(tag.Name, "@"))
+		}
+
+		if len(suiteRun.SpecRuns) == 0 || start.Before(suiteRun.StartTime) {
+			suiteRun.StartTime = start
+		}
+		if end.After(suiteRun.EndTime) {
+			suiteRun.EndTime = end
+		}
+		suiteRun.SpecRuns = append(suiteRun.SpecRuns, specRun)
+	}
+
+	return suiteRun
+}
+
+func cucumberDuration(steps []cucumberStep) time.Duration {
+	var total time.Duration
+	for _, step := range steps {
+		total += time.Duration(step.Result.Duration)
+	}
+	return total
+}
+
+// cucumberStatus reduces the step results of a scenario to a single status,
+// returning the failing step and its error as the message.
+func cucumberStatus(steps []cucumberStep) (string, string) {
+	executed := false
+	for _, step := range steps {
+		switch step.Result.Status {
+		case "failed", "ambiguous":
+			return utils.StatusFailed, cucumberStepMessage(step)
+		case "passed":
+			executed = true
+		}
+	}
+
+	for _, step := range steps {
+		switch step.Result.Status {
+		case "undefined", "pending":
+			return utils.StatusSkipped, cucumberStepMessage(step)
+		}
+	}
+
+	if !executed && len(steps) > 0 {
+		return utils.StatusSkipped, ""
+	}
+	return utils.StatusPassed, ""
+}
+
+func cucumberStepMessage(step cucumberStep) string {
+	text := strings.TrimSpace(step.Keyword + step.Name)
+	if text == "" {
+		text = "hook"
+	}
+	message := fmt.Sprintf("%s (%s)", text, step.Result.Status)
+	if step.Result.ErrorMessage != "" {
+		message += "\n" + step.Result.ErrorMessage
+	}
+	return message
+}
@@ -0,0 +1,93 @@
+package importers_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/guidewire/fern-reporter/pkg/importers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseCucumberJSON", func() {
+	report := `[{
+  "uri": "features/cart.feature",
+  "name": "Cart",
+  "tags": [{"name": "@cart"}],
+  "elements": [
+    {
+      "keyword": "Background",
+      "type": "background",
+      "name": "",
+      "steps": [{"keyword": "Given ", "name": "a user", "result": {"status": "passed", "duration": 1000000}}]
+    },
+    {
+      "keyword": "Scenario",
+      "type": "scenario",
+      "name": "adding an item",
+      "start_timestamp": "2024-04-20T12:00:00Z",
+      "tags": [{"name": "@smoke"}],
+      "steps": [
+        {"keyword": "When ", "name": "I add an item", "result": {"status": "passed", "duration": 2000000}},
+        {"keyword": "Then ", "name": "the cart has 1 item", "result": {"status": "failed", "duration": 1000000, "error_message": "expected 1 got 0"}}
+      ]
+    },
+    {
+      "keyword": "Scenario Outline",
+      "type": "scenario",
+      "name": "removing <count> items",
+      "steps": [{"keyword": "When ", "name": "I remove 1 items", "result": {"status": "passed", "duration": 1000000}}]
+    },
+    {
+      "keyword": "Scenario Outline",
+      "type": "scenario",
+      "name": "removing <count> items",
+      "steps": [{"keyword": "When ", "name": "I remove 2 items", "result": {"status": "undefined"}}]
+    }
+  ]
+}]`
+
+	It("should map features to suites and scenarios to specs", func() {
+		testRun, err := importers.ParseCucumberJSON(strings.NewReader(report), "shop")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(testRun.TestProjectName).To(Equal("shop"))
+		Expect(testRun.SuiteRuns).To(HaveLen(1))
+
+		suiteRun := testRun.SuiteRuns[0]
+		Expect(suiteRun.SuiteName).To(Equal("Cart"))
+		Expect(suiteRun.SpecRuns).To(HaveLen(3))
+
+		adding := suiteRun.SpecRuns[0]
+		Expect(adding.SpecDescription).To(Equal("adding an item"))
+		Expect(adding.Status).To(Equal("failed"))
+		Expect(adding.Message).To(Equal("Then the cart has 1 item (failed)\nexpected 1 got 0"))
+		Expect(adding.StartTime).To(BeTemporally("==", time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)))
+		// Includes the background step
+		Expect(adding.EndTime.Sub(adding.StartTime)).To(Equal(4 * time.Millisecond))
+		Expect(adding.Tags).To(Equal([]models.Tag{{Name: "cart"}, {Name: "smoke"}}))
+	})
+
+	It("should create a spec run for every scenario outline example", func() {
+		testRun, err := importers.ParseCucumberJSON(strings.NewReader(report), "shop")
+		Expect(err).NotTo(HaveOccurred())
+
+		specRuns := testRun.SuiteRuns[0].SpecRuns
+		Expect(specRuns[1].SpecDescription).To(Equal("removing <count> items (example #1)"))
+		Expect(specRuns[1].Status).To(Equal("passed"))
+		Expect(specRuns[2].SpecDescription).To(Equal("removing <count> items (example #2)"))
+		Expect(specRuns[2].Status).To(Equal("skipped"))
+		Expect(specRuns[2].Message).To(Equal("When I remove 2 items (undefined)"))
+	})
+
+	It("should require a project name", func() {
+		_, err := importers.ParseCucumberJSON(strings.NewReader(report), "")
+		Expect(err).To(MatchError("project name is required"))
+	})
+
+	It("should reject invalid JSON", func() {
+		_, err := importers.ParseCucumberJSON(strings.NewReader("{}"), "shop")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,222 @@
+package importers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// ParseGinkgoReport converts the output of `ginkgo --json-report` into a
+// TestRun. Each suite report becomes a suite run and every It node becomes a
+// spec run described by its own text and its container hierarchy; failed
+// suite level nodes (e.g. BeforeSuite) are kept as well so setup failures are
+// visible. When projectName is empty the description of the first suite is
+// used.
+func ParseGinkgoReport(r io.Reader, projectName string) (*models.TestRun, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading ginkgo report: %w", err)
+	}
+
+	var reports []types.Report
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var report types.Report
+		err = json.Unmarshal(trimmed, &report)
+		reports = []types.Report{report}
+	} else {
+		err = json.Unmarshal(data, &reports)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing ginkgo report: %w", err)
+	}
+	if len(reports) == 0 {
+		return nil, errors.New("ginkgo report contains no suites")
+	}
+
+	if projectName == "" {
+		projectName = reports[0].SuiteDescription
+	}
+	if projectName == "" {
+		return nil, errors.New("ginkgo report has no project name")
+	}
+
+	testRun := &models.TestRun{
+		TestProjectName: projectName,
+		TestSeed:        uint64(reports[0].SuiteConfig.RandomSeed),
+	}
+
+	for i, report := range reports {
+		testRun.SuiteRuns = append(testRun.SuiteRuns, convertGinkgoReport(report))
+		if i == 0 || report.StartTime.Before(testRun.StartTime) {
+			testRun.StartTime = report.StartTime
+		}
+		if report.EndTime.After(testRun.EndTime) {
+			testRun.EndTime = report.EndTime
+		}
+	}
+
+	return testRun, nil
+}
+
+func convertGinkgoReport(report types.Report) models.SuiteRun {
+	suiteRun := models.SuiteRun{
+		SuiteName: report.SuiteDescription,
+		StartTime: report.StartTime,
+		EndTime:   report.EndTime,
+	}
+
+	for _, specReport := range report.SpecReports {
+		if !specReport.LeafNodeType.Is(types.NodeTypeIt) && !specReport.Failed() {
+			continue
+		}
+
+		specRun := models.SpecRun{
+			SpecDescription: ginkgoSpecDescription(specReport),
+			Status:          ginkgoStatus(specReport.State),
+			Message:         ginkgoMessage(specReport),
+			StartTime:       specReport.StartTime,
+			EndTime:         specReport.EndTime,
+			Containers:      ginkgoContainers(specReport),
+			Failure:         ginkgoFailure(specReport),
+		}
+		if specRun.EndTime.IsZero() {
+			specRun.EndTime = specRun.StartTime.Add(specReport.RunTime)
+		}
+		if specRun.Attempts = ginkgoAttempts(specReport, specRun); specRun.Attempts != nil {
+			specRun.Status = utils.SpecOutcome(specRun.Attempts)
+		}
+		for _, label := range append(append([]string{}, report.SuiteLabels...), specReport.Labels()...) {
+			specRun.Tags = appendTag(specRun.Tags, label)
+		}
+		suiteRun.SpecRuns = append(suiteRun.SpecRuns, specRun)
+	}
+
+	return suiteRun
+}
+
+func ginkgoSpecDescription(specReport types.SpecReport) string {
+	if specReport.LeafNodeType.Is(types.NodeTypeIt) {
+		return specReport.LeafNodeText
+	}
+	// Suite level nodes have no text of their own
+	return strings.TrimSpace(specReport.LeafNodeType.String() + " " + specReport.LeafNodeText)
+}
+
+func ginkgoContainers(specReport types.SpecReport) models.Containers {
+	var containers models.Containers
+	for i, text := range specReport.ContainerHierarchyTexts {
+		container := models.Container{Text: text}
+		if i < len(specReport.ContainerHierarchyLabels) && len(specReport.ContainerHierarchyLabels[i]) > 0 {
+			container.Labels = specReport.ContainerHierarchyLabels[i]
+		}
+		containers = append(containers, container)
+	}
+	return containers
+}
+
+// ginkgoAttempts reconstructs the attempts of a spec that ran more than once.
+// Ginkgo only reports how often a spec ran, so the attempts before the last
+// carry the status implied by the decorator, without times or messages.
+func ginkgoAttempts(specReport types.SpecReport, specRun models.SpecRun) models.SpecAttempts {
+	if specReport.NumAttempts <= 1 {
+		return nil
+	}
+
+	// --flake-attempts retries failures, MustPassRepeatedly repeats passes
+	earlier := utils.StatusFailed
+	if specReport.MaxMustPassRepeatedly > 1 {
+		earlier = utils.StatusPassed
+	}
+	attempts := make(models.SpecAttempts, 0, specReport.NumAttempts)
+	for i := 1; i < specReport.NumAttempts; i++ {
+		attempts = append(attempts, models.SpecAttempt{Status: earlier})
+	}
+	return append(attempts, models.SpecAttempt{
+		Status:    specRun.Status,
+		Message:   specRun.Message,
+		StartTime: specRun.StartTime,
+		EndTime:   specRun.EndTime,
+	})
+}
+
+func ginkgoStatus(state types.SpecState) string {
+	switch {
+	case state.Is(types.SpecStatePassed):
+		return utils.StatusPassed
+	case state.Is(types.SpecStateSkipped | types.SpecStatePending):
+		return utils.StatusSkipped
+	default:
+		return utils.StatusFailed
+	}
+}
+
+func ginkgoMessage(specReport types.SpecReport) string {
+	if specReport.Failure.IsZero() {
+		return ""
+	}
+
+	message := specReport.Failure.Message
+	if specReport.Failure.ForwardedPanic != "" {
+		message = strings.TrimSpace(message + "\n" + specReport.Failure.ForwardedPanic)
+	}
+	if location := specReport.Failure.Location; location.FileName != "" {
+		message = strings.TrimSpace(message + "\n" + location.String())
+	}
+	return message
+}
+
+// ginkgoSetupNodes are the node types whose failures mean a spec could not be
+// set up.
+const ginkgoSetupNodes = types.NodeTypeBeforeEach | types.NodeTypeJustBeforeEach | types.NodeTypeBeforeAll |
+	types.NodeTypeBeforeSuite | types.NodeTypeSynchronizedBeforeSuite
+
+func ginkgoFailure(specReport types.SpecReport) *models.Failure {
+	if specReport.Failure.IsZero() {
+		return nil
+	}
+
+	failure := &models.Failure{
+		Message:    specReport.Failure.Message,
+		File:       specReport.Failure.Location.FileName,
+		Line:       specReport.Failure.Location.LineNumber,
+		StackTrace: specReport.Failure.Location.FullStackTrace,
+		Stdout:     specReport.CombinedOutput(),
+	}
+	if specReport.Failure.ForwardedPanic != "" {
+		failure.Message = strings.TrimSpace(failure.Message + "\n" + specReport.Failure.ForwardedPanic)
+	}
+
+	switch {
+	case specReport.State.Is(types.SpecStatePanicked):
+		failure.Kind = utils.FailureKindPanic
+	case specReport.State.Is(types.SpecStateTimedout):
+		failure.Kind = utils.FailureKindTimeout
+	case specReport.State.Is(types.SpecStateInterrupted | types.SpecStateAborted):
+		failure.Kind = utils.FailureKindInterrupted
+	case specReport.Failure.FailureNodeType.Is(ginkgoSetupNodes):
+		failure.Kind = utils.FailureKindSetup
+	default:
+		failure.Kind = utils.FailureKindAssertion
+	}
+	return failure
+}
+
+func appendTag(tags []models.Tag, name string) []models.Tag {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return tags
+	}
+	for _, tag := range tags {
+		if tag.Name == name {
+			return tags
+		}
+	}
+	return append(tags, models.Tag{Name: name})
+}
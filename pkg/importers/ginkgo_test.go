@@ -0,0 +1,137 @@
+package importers_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/guidewire/fern-reporter/pkg/importers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseGinkgoReport", func() {
+	report := `[{
+  "SuitePath": "/src/cart",
+  "SuiteDescription": "Cart Suite",
+  "SuiteLabels": ["unit"],
+  "SuiteSucceeded": false,
+  "StartTime": "2024-04-20T12:00:00Z",
+  "EndTime": "2024-04-20T12:00:05Z",
+  "SuiteConfig": {"RandomSeed": 1713614400},
+  "SpecReports": [
+    {
+      "ContainerHierarchyTexts": ["Cart", "when empty"],
+      "ContainerHierarchyLabels": [["cart"], []],
+      "LeafNodeType": "It",
+      "LeafNodeText": "has no items",
+      "LeafNodeLabels": ["fast"],
+      "State": "passed",
+      "NumAttempts": 3,
+      "MaxFlakeAttempts": 3,
+      "StartTime": "2024-04-20T12:00:01Z",
+      "EndTime": "2024-04-20T12:00:02Z",
+      "RunTime": 1000000000
+    },
+    {
+      "ContainerHierarchyTexts": ["Cart"],
+      "LeafNodeType": "It",
+      "LeafNodeText": "removes items",
+      "State": "failed",
+      "StartTime": "2024-04-20T12:00:02Z",
+      "RunTime": 500000000,
+      "CapturedGinkgoWriterOutput": "loading cart",
+      "Failure": {
+        "Message": "Expected 0 to equal 1",
+        "Location": {"FileName": "/src/cart/cart_test.go", "LineNumber": 42, "FullStackTrace": "cart.TestRemove()\n\t/src/cart/cart_test.go:42"},
+        "FailureNodeType": "It"
+      }
+    },
+    {
+      "ContainerHierarchyTexts": ["Cart"],
+      "LeafNodeType": "It",
+      "LeafNodeText": "checks out",
+      "State": "pending"
+    },
+    {
+      "LeafNodeType": "BeforeSuite",
+      "State": "passed"
+    },
+    {
+      "LeafNodeType": "AfterSuite",
+      "State": "panicked",
+      "Failure": {"Message": "Test Panicked", "ForwardedPanic": "nil pointer"}
+    }
+  ]
+}]`
+
+	It("should map suites, specs, labels and the random seed", func() {
+		testRun, err := importers.ParseGinkgoReport(strings.NewReader(report), "cart-service")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(testRun.TestProjectName).To(Equal("cart-service"))
+		Expect(testRun.TestSeed).To(Equal(uint64(1713614400)))
+		Expect(testRun.StartTime).To(BeTemporally("==", time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)))
+		Expect(testRun.EndTime).To(BeTemporally("==", time.Date(2024, 4, 20, 12, 0, 5, 0, time.UTC)))
+		Expect(testRun.SuiteRuns).To(HaveLen(1))
+
+		suiteRun := testRun.SuiteRuns[0]
+		Expect(suiteRun.SuiteName).To(Equal("Cart Suite"))
+		Expect(suiteRun.SpecRuns).To(HaveLen(4))
+
+		Expect(suiteRun.SpecRuns[0].SpecDescription).To(Equal("has no items"))
+		Expect(suiteRun.SpecRuns[0].Containers).To(Equal(models.Containers{{Text: "Cart", Labels: []string{"cart"}}, {Text: "when empty"}}))
+		Expect(suiteRun.SpecRuns[0].Containers.FullText(suiteRun.SpecRuns[0].SpecDescription)).To(Equal("Cart when empty has no items"))
+		Expect(suiteRun.SpecRuns[0].Status).To(Equal("flaky"))
+		Expect(suiteRun.SpecRuns[0].Attempts).To(HaveLen(3))
+		Expect(suiteRun.SpecRuns[0].Attempts[0].Status).To(Equal("failed"))
+		Expect(suiteRun.SpecRuns[0].Attempts[2].Status).To(Equal("passed"))
+		Expect(suiteRun.SpecRuns[0].Attempts[2].EndTime).To(Equal(suiteRun.SpecRuns[0].EndTime))
+		Expect(suiteRun.SpecRuns[1].Attempts).To(BeNil())
+		Expect(suiteRun.SpecRuns[0].Tags).To(Equal([]models.Tag{{Name: "unit"}, {Name: "cart"}, {Name: "fast"}}))
+
+		Expect(suiteRun.SpecRuns[1].Status).To(Equal("failed"))
+		Expect(suiteRun.SpecRuns[1].Message).To(Equal("Expected 0 to equal 1\n/src/cart/cart_test.go:42"))
+		Expect(suiteRun.SpecRuns[1].EndTime.Sub(suiteRun.SpecRuns[1].StartTime)).To(Equal(500 * time.Millisecond))
+		Expect(suiteRun.SpecRuns[1].Failure).To(Equal(&models.Failure{
+			Message:    "Expected 0 to equal 1",
+			Kind:       "assertion",
+			File:       "/src/cart/cart_test.go",
+			Line:       42,
+			StackTrace: "cart.TestRemove()\n\t/src/cart/cart_test.go:42",
+			Stdout:     "loading cart",
+		}))
+		Expect(suiteRun.SpecRuns[0].Failure).To(BeNil())
+
+		Expect(suiteRun.SpecRuns[2].Status).To(Equal("skipped"))
+
+		Expect(suiteRun.SpecRuns[3].SpecDescription).To(Equal("AfterSuite"))
+		Expect(suiteRun.SpecRuns[3].Containers).To(BeEmpty())
+		Expect(suiteRun.SpecRuns[3].Status).To(Equal("failed"))
+		Expect(suiteRun.SpecRuns[3].Message).To(Equal("Test Panicked\nnil pointer"))
+		Expect(suiteRun.SpecRuns[3].Failure.Kind).To(Equal("panic"))
+	})
+
+	It("should default the project name to the suite description", func() {
+		testRun, err := importers.ParseGinkgoReport(strings.NewReader(report), "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testRun.TestProjectName).To(Equal("Cart Suite"))
+	})
+
+	It("should accept a single report object", func() {
+		single := strings.TrimSuffix(strings.TrimPrefix(report, "["), "]")
+		testRun, err := importers.ParseGinkgoReport(strings.NewReader(single), "cart-service")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testRun.SuiteRuns).To(HaveLen(1))
+	})
+
+	It("should reject an empty report", func() {
+		_, err := importers.ParseGinkgoReport(strings.NewReader("[]"), "cart-service")
+		Expect(err).To(MatchError("ginkgo report contains no suites"))
+	})
+
+	It("should reject invalid JSON", func() {
+		_, err := importers.ParseGinkgoReport(strings.NewReader("{"), "cart-service")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,205 @@
+package importers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+)
+
+// maxGoTestLineSize bounds a single test2json event; test output lines can be
+// long, so this is well above bufio's 64KB default.
+const maxGoTestLineSize = 4 * 1024 * 1024
+
+// goTestEvent mirrors the event emitted by `go test -json` (cmd/test2json).
+type goTestEvent struct {
+	Time    time.Time `json:"Time"`
+	Action  string    `json:"Action"`
+	Package string    `json:"Package"`
+	Test    string    `json:"Test"`
+	Elapsed float64   `json:"Elapsed"`
+	Output  string    `json:"Output"`
+}
+
+type goTestPackage struct {
+	name      string
+	status    string
+	startTime time.Time
+	endTime   time.Time
+	output    strings.Builder
+	tests     []*goTestCase
+	testIndex map[string]*goTestCase
+}
+
+type goTestCase struct {
+	name      string
+	status    string
+	startTime time.Time
+	endTime   time.Time
+	output    strings.Builder
+}
+
+// ParseGoTestJSON folds a newline-delimited `go test -json` event stream into
+// a TestRun with one suite run per package and one spec run per test. A test
+// with subtests fails whenever one of them fails, so it is only recorded when
+// it failed on its own. Lines that are not test2json events are ignored.
+func ParseGoTestJSON(r io.Reader, projectName string) (*models.TestRun, error) {
+	if projectName == "" {
+		return nil, errors.New("project name is required")
+	}
+
+	var packages []*goTestPackage
+	packageIndex := map[string]*goTestPackage{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxGoTestLineSize)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 || line[0] != '{' {
+			continue
+		}
+
+		var event goTestEvent
+		if err := json.Unmarshal(line, &event); err != nil || event.Action == "" {
+			continue
+		}
+
+		pkg, ok := packageIndex[event.Package]
+		if !ok {
+			pkg = &goTestPackage{name: event.Package, startTime: event.Time, testIndex: map[string]*goTestCase{}}
+			packageIndex[event.Package] = pkg
+			packages = append(packages, pkg)
+		}
+		if event.Time.After(pkg.endTime) {
+			pkg.endTime = event.Time
+		}
+
+		if event.Test == "" {
+			pkg.apply(event)
+			continue
+		}
+
+		test, ok := pkg.testIndex[event.Test]
+		if !ok {
+			test = &goTestCase{name: event.Test, startTime: event.Time, endTime: event.Time}
+			pkg.testIndex[event.Test] = test
+			pkg.tests = append(pkg.tests, test)
+		}
+		test.apply(event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading go test output: %w", err)
+	}
+	if len(packages) == 0 {
+		return nil, errors.New("no go test events found")
+	}
+
+	testRun := &models.TestRun{TestProjectName: projectName}
+	for i, pkg := range packages {
+		testRun.SuiteRuns = append(testRun.SuiteRuns, pkg.toSuiteRun())
+		if i == 0 || pkg.startTime.Before(testRun.StartTime) {
+			testRun.StartTime = pkg.startTime
+		}
+		if pkg.endTime.After(testRun.EndTime) {
+			testRun.EndTime = pkg.endTime
+		}
+	}
+
+	return testRun, nil
+}
+
+func (p *goTestPackage) apply(event goTestEvent) {
+	switch event.Action {
+	case "output":
+		p.output.WriteString(event.Output)
+	case "pass", "fail", "skip":
+		p.status = event.Action
+	}
+}
+
+func (t *goTestCase) apply(event goTestEvent) {
+	switch event.Action {
+	case "run":
+		t.startTime = event.Time
+	case "output":
+		t.output.WriteString(event.Output)
+	case "pass", "fail", "skip":
+		t.status = event.Action
+		t.endTime = event.Time
+		if event.Elapsed > 0 {
+			t.startTime = t.endTime.Add(-time.Duration(event.Elapsed * float64(time.Second)))
+		}
+	}
+}
+
+func (p *goTestPackage) toSuiteRun() models.SuiteRun {
+	suiteRun := models.SuiteRun{
+		SuiteName: p.name,
+		StartTime: p.startTime,
+		EndTime:   p.endTime,
+	}
+
+	// Parents of subtests repeat the outcome of their subtests
+	parents := map[string]bool{}
+	failedParents := map[string]bool{}
+	for _, test := range p.tests {
+		for i := strings.LastIndex(test.name, "/"); i > 0; i = strings.LastIndex(test.name[:i], "/") {
+			parents[test.name[:i]] = true
+			if goTestStatus(test.status) == utils.StatusFailed {
+				failedParents[test.name[:i]] = true
+			}
+		}
+	}
+
+	hasFailedTest := false
+	for _, test := range p.tests {
+		if parents[test.name] && (failedParents[test.name] || goTestStatus(test.status) != utils.StatusFailed) {
+			continue
+		}
+		specRun := models.SpecRun{
+			SpecDescription: test.name,
+			Status:          goTestStatus(test.status),
+			StartTime:       test.startTime,
+			EndTime:         test.endTime,
+		}
+		// Output is only kept where it explains the outcome
+		if specRun.Status == utils.StatusFailed || specRun.Status == utils.StatusSkipped {
+			specRun.Message = strings.TrimRight(test.output.String(), "\n")
+		}
+		hasFailedTest = hasFailedTest || specRun.Status == utils.StatusFailed
+		suiteRun.SpecRuns = append(suiteRun.SpecRuns, specRun)
+	}
+
+	// A package can fail without any failing test, e.g. on a build error or a
+	// panic in TestMain. Record it so the failure is not silently dropped.
+	if p.status == "fail" && !hasFailedTest {
+		suiteRun.SpecRuns = append(suiteRun.SpecRuns, models.SpecRun{
+			SpecDescription: p.name,
+			Status:          utils.StatusFailed,
+			Message:         strings.TrimRight(p.output.String(), "\n"),
+			StartTime:       p.startTime,
+			EndTime:         p.endTime,
+		})
+	}
+
+	return suiteRun
+}
+
+func goTestStatus(action string) string {
+	switch action {
+	case "pass":
+		return utils.StatusPassed
+	case "skip":
+		return utils.StatusSkipped
+	default:
+		// A test without a terminal event was interrupted, e.g. by a timeout
+		return utils.StatusFailed
+	}
+}
@@ -0,0 +1,117 @@
+package importers_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/guidewire/fern-reporter/pkg/importers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseGoTestJSON", func() {
+	Context("when given a test2json event stream", func() {
+		stream := `{"Time":"2024-04-20T12:00:00Z","Action":"start","Package":"example.com/cart"}
+{"Time":"2024-04-20T12:00:00Z","Action":"run","Package":"example.com/cart","Test":"TestAdd"}
+{"Time":"2024-04-20T12:00:00Z","Action":"output","Package":"example.com/cart","Test":"TestAdd","Output":"=== RUN   TestAdd\n"}
+{"Time":"2024-04-20T12:00:01Z","Action":"pass","Package":"example.com/cart","Test":"TestAdd","Elapsed":1}
+{"Time":"2024-04-20T12:00:01Z","Action":"run","Package":"example.com/cart","Test":"TestRemove"}
+{"Time":"2024-04-20T12:00:01Z","Action":"run","Package":"example.com/cart","Test":"TestRemove/empty_cart"}
+{"Time":"2024-04-20T12:00:01Z","Action":"output","Package":"example.com/cart","Test":"TestRemove/empty_cart","Output":"    cart_test.go:12: expected error\n"}
+{"Time":"2024-04-20T12:00:02Z","Action":"fail","Package":"example.com/cart","Test":"TestRemove/empty_cart","Elapsed":0.5}
+{"Time":"2024-04-20T12:00:02Z","Action":"fail","Package":"example.com/cart","Test":"TestRemove","Elapsed":1}
+{"Time":"2024-04-20T12:00:02Z","Action":"run","Package":"example.com/cart","Test":"TestSlow"}
+{"Time":"2024-04-20T12:00:02Z","Action":"output","Package":"example.com/cart","Test":"TestSlow","Output":"    cart_test.go:20: skipping in short mode\n"}
+{"Time":"2024-04-20T12:00:02Z","Action":"skip","Package":"example.com/cart","Test":"TestSlow","Elapsed":0}
+{"Time":"2024-04-20T12:00:03Z","Action":"fail","Package":"example.com/cart","Elapsed":3}
+FAIL	example.com/cart	3.000s
+{"Time":"2024-04-20T12:00:00Z","Action":"start","Package":"example.com/broken"}
+{"Time":"2024-04-20T12:00:00Z","Action":"output","Package":"example.com/broken","Output":"FAIL\texample.com/broken [build failed]\n"}
+{"Time":"2024-04-20T12:00:00Z","Action":"fail","Package":"example.com/broken","Elapsed":0}
+`
+
+		It("should create a suite run per package and a spec run per test", func() {
+			testRun, err := importers.ParseGoTestJSON(strings.NewReader(stream), "cart-service")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(testRun.TestProjectName).To(Equal("cart-service"))
+			Expect(testRun.StartTime).To(BeTemporally("==", time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)))
+			Expect(testRun.EndTime).To(BeTemporally("==", time.Date(2024, 4, 20, 12, 0, 3, 0, time.UTC)))
+			Expect(testRun.SuiteRuns).To(HaveLen(2))
+
+			cart := testRun.SuiteRuns[0]
+			Expect(cart.SuiteName).To(Equal("example.com/cart"))
+			Expect(cart.SpecRuns).To(HaveLen(3))
+
+			Expect(cart.SpecRuns[0].SpecDescription).To(Equal("TestAdd"))
+			Expect(cart.SpecRuns[0].Status).To(Equal("passed"))
+			Expect(cart.SpecRuns[0].Message).To(BeEmpty())
+
+			Expect(cart.SpecRuns[1].SpecDescription).To(Equal("TestRemove/empty_cart"))
+			Expect(cart.SpecRuns[1].Status).To(Equal("failed"))
+			Expect(cart.SpecRuns[1].Message).To(Equal("    cart_test.go:12: expected error"))
+			Expect(cart.SpecRuns[1].EndTime.Sub(cart.SpecRuns[1].StartTime)).To(Equal(500 * time.Millisecond))
+
+			Expect(cart.SpecRuns[2].Status).To(Equal("skipped"))
+			Expect(cart.SpecRuns[2].Message).To(ContainSubstring("skipping in short mode"))
+		})
+
+		It("should record a failing package without failing tests as a failed spec", func() {
+			testRun, err := importers.ParseGoTestJSON(strings.NewReader(stream), "cart-service")
+			Expect(err).NotTo(HaveOccurred())
+
+			broken := testRun.SuiteRuns[1]
+			Expect(broken.SpecRuns).To(HaveLen(1))
+			Expect(broken.SpecRuns[0].SpecDescription).To(Equal("example.com/broken"))
+			Expect(broken.SpecRuns[0].Status).To(Equal("failed"))
+			Expect(broken.SpecRuns[0].Message).To(ContainSubstring("build failed"))
+		})
+	})
+
+	It("should only record a nested failure once, on the failing subtest", func() {
+		stream := `{"Time":"2024-04-20T12:00:00Z","Action":"run","Package":"p","Test":"TestCart"}
+{"Time":"2024-04-20T12:00:00Z","Action":"run","Package":"p","Test":"TestCart/remove"}
+{"Time":"2024-04-20T12:00:00Z","Action":"run","Package":"p","Test":"TestCart/remove/empty"}
+{"Time":"2024-04-20T12:00:01Z","Action":"fail","Package":"p","Test":"TestCart/remove/empty","Elapsed":1}
+{"Time":"2024-04-20T12:00:01Z","Action":"fail","Package":"p","Test":"TestCart/remove","Elapsed":1}
+{"Time":"2024-04-20T12:00:01Z","Action":"run","Package":"p","Test":"TestCart/add"}
+{"Time":"2024-04-20T12:00:02Z","Action":"pass","Package":"p","Test":"TestCart/add","Elapsed":1}
+{"Time":"2024-04-20T12:00:02Z","Action":"fail","Package":"p","Test":"TestCart","Elapsed":2}
+{"Time":"2024-04-20T12:00:02Z","Action":"run","Package":"p","Test":"TestCheckout"}
+{"Time":"2024-04-20T12:00:02Z","Action":"run","Package":"p","Test":"TestCheckout/pay"}
+{"Time":"2024-04-20T12:00:03Z","Action":"pass","Package":"p","Test":"TestCheckout/pay","Elapsed":1}
+{"Time":"2024-04-20T12:00:03Z","Action":"output","Package":"p","Test":"TestCheckout","Output":"    checkout_test.go:30: cleanup failed\n"}
+{"Time":"2024-04-20T12:00:03Z","Action":"fail","Package":"p","Test":"TestCheckout","Elapsed":1}
+{"Time":"2024-04-20T12:00:03Z","Action":"fail","Package":"p","Elapsed":3}`
+		testRun, err := importers.ParseGoTestJSON(strings.NewReader(stream), "p")
+		Expect(err).NotTo(HaveOccurred())
+
+		var specs []string
+		for _, specRun := range testRun.SuiteRuns[0].SpecRuns {
+			specs = append(specs, specRun.SpecDescription+" "+specRun.Status)
+		}
+		Expect(specs).To(Equal([]string{
+			"TestCart/remove/empty failed",
+			"TestCart/add passed",
+			"TestCheckout failed",
+			"TestCheckout/pay passed",
+		}))
+	})
+
+	It("should mark tests without a terminal event as failed", func() {
+		stream := `{"Time":"2024-04-20T12:00:00Z","Action":"run","Package":"p","Test":"TestHang"}`
+		testRun, err := importers.ParseGoTestJSON(strings.NewReader(stream), "p")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testRun.SuiteRuns[0].SpecRuns[0].Status).To(Equal("failed"))
+	})
+
+	It("should require a project name", func() {
+		_, err := importers.ParseGoTestJSON(strings.NewReader(""), "")
+		Expect(err).To(MatchError("project name is required"))
+	})
+
+	It("should reject a stream without events", func() {
+		_, err := importers.ParseGoTestJSON(strings.NewReader("ok  \tp\t0.1s\n"), "p")
+		Expect(err).To(MatchError("no go test events found"))
+	})
+})
@@ -0,0 +1,276 @@
+package importers
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+)
+
+// junitTimestampLayouts are the timestamp formats emitted by the common JUnit
+// producers (Ant/Maven surefire, pytest, jest-junit, gotestsum).
+var junitTimestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04:05.000",
+	"2006-01-02 15:04:05",
+}
+
+type JUnitOptions struct {
+	// ProjectName overrides the name of the top level <testsuites> element, or
+	// of the <testsuite> root of a single suite report.
+	ProjectName string
+	// TagProperties lists the <property> names that should be turned into
+	// "name:value" tags. Properties named "tag" or "tags" are always mapped.
+	TagProperties []string
+}
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Timestamp  string           `xml:"timestamp,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string           `xml:"name,attr"`
+	Timestamp  string           `xml:"timestamp,attr"`
+	Time       string           `xml:"time,attr"`
+	Properties []junitProperty  `xml:"properties>property"`
+	TestCases  []junitTestCase  `xml:"testcase"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestCase struct {
+	Name       string          `xml:"name,attr"`
+	ClassName  string          `xml:"classname,attr"`
+	Time       string          `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property"`
+	Failure    *junitResult    `xml:"failure"`
+	Error      *junitResult    `xml:"error"`
+	Skipped    *junitResult    `xml:"skipped"`
+	SystemOut  string          `xml:"system-out"`
+	SystemErr  string          `xml:"system-err"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+	Text  string `xml:",chardata"`
+}
+
+type junitResult struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// ParseJUnit converts a JUnit XML report into a TestRun. Both a <testsuites>
+// root and a single <testsuite> root are accepted; nested suites are flattened.
+func ParseJUnit(r io.Reader, opts JUnitOptions) (*models.TestRun, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading junit report: %w", err)
+	}
+
+	var root junitTestSuites
+	if err := xml.Unmarshal(data, &root); err != nil {
+		var suite junitTestSuite
+		if suiteErr := xml.Unmarshal(data, &suite); suiteErr != nil {
+			return nil, fmt.Errorf("error parsing junit report: %w", err)
+		}
+		// A single suite report is named after its suite
+		root = junitTestSuites{Name: suite.Name, TestSuites: []junitTestSuite{suite}}
+	}
+
+	projectName := opts.ProjectName
+	if projectName == "" {
+		projectName = root.Name
+	}
+	if projectName == "" {
+		return nil, errors.New("junit report has no project name")
+	}
+
+	defaultStart := parseJUnitTimestamp(root.Timestamp, time.Now())
+	testRun := &models.TestRun{
+		TestProjectName: projectName,
+		StartTime:       defaultStart,
+		EndTime:         defaultStart,
+	}
+
+	for _, suite := range flattenJUnitSuites(root.TestSuites) {
+		suiteRun := convertJUnitSuite(suite, defaultStart, opts.TagProperties)
+		testRun.SuiteRuns = append(testRun.SuiteRuns, suiteRun)
+	}
+
+	for i, suiteRun := range testRun.SuiteRuns {
+		if i == 0 || suiteRun.StartTime.Before(testRun.StartTime) {
+			testRun.StartTime = suiteRun.StartTime
+		}
+		if suiteRun.EndTime.After(testRun.EndTime) {
+			testRun.EndTime = suiteRun.EndTime
+		}
+	}
+
+	return testRun, nil
+}
+
+func flattenJUnitSuites(suites []junitTestSuite) []junitTestSuite {
+	var flattened []junitTestSuite
+	for _, suite := range suites {
+		if len(suite.TestCases) > 0 || len(suite.TestSuites) == 0 {
+			flattened = append(flattened, suite)
+		}
+		for _, child := range suite.TestSuites {
+			// Nested suites inherit the properties of their parent
+			child.Properties = append(append([]junitProperty{}, suite.Properties...), child.Properties...)
+			if child.Timestamp == "" {
+				child.Timestamp = suite.Timestamp
+			}
+			flattened = append(flattened, flattenJUnitSuites([]junitTestSuite{child})...)
+		}
+	}
+	return flattened
+}
+
+func convertJUnitSuite(suite junitTestSuite, defaultStart time.Time, tagProperties []string) models.SuiteRun {
+	suiteStart := parseJUnitTimestamp(suite.Timestamp, defaultStart)
+	suiteRun := models.SuiteRun{
+		SuiteName: suite.Name,
+		StartTime: suiteStart,
+		EndTime:   suiteStart.Add(parseJUnitDuration(suite.Time)),
+	}
+
+	// JUnit only records a duration per test case, so the cases are laid out
+	// sequentially from the start of the suite.
+	specStart := suiteStart
+	for _, testCase := range suite.TestCases {
+		specEnd := specStart.Add(parseJUnitDuration(testCase.Time))
+		specRun := models.SpecRun{
+			SpecDescription: junitSpecDescription(suite.Name, testCase),
+			StartTime:       specStart,
+			EndTime:         specEnd,
+			Tags:            junitTags(append(append([]junitProperty{}, suite.Properties...), testCase.Properties...), tagProperties),
+		}
+		specRun.Status, specRun.Message = junitStatus(testCase)
+		specRun.Failure = junitFailure(testCase)
+		suiteRun.SpecRuns = append(suiteRun.SpecRuns, specRun)
+		specStart = specEnd
+	}
+
+	if specStart.After(suiteRun.EndTime) {
+		suiteRun.EndTime = specStart
+	}
+
+	return suiteRun
+}
+
+func junitSpecDescription(suiteName string, testCase junitTestCase) string {
+	if testCase.ClassName == "" || testCase.ClassName == suiteName {
+		return testCase.Name
+	}
+	return testCase.ClassName + " " + testCase.Name
+}
+
+func junitStatus(testCase junitTestCase) (string, string) {
+	switch {
+	case testCase.Failure != nil:
+		return utils.StatusFailed, junitMessage(testCase.Failure)
+	case testCase.Error != nil:
+		return utils.StatusErrored, junitMessage(testCase.Error)
+	case testCase.Skipped != nil:
+		return utils.StatusSkipped, junitMessage(testCase.Skipped)
+	default:
+		return utils.StatusPassed, ""
+	}
+}
+
+// junitFailure keeps the details of a <failure> (a failed assertion) or an
+// <error> (an unexpected exception, whose kind is unknown).
+func junitFailure(testCase junitTestCase) *models.Failure {
+	result, kind := testCase.Failure, utils.FailureKindAssertion
+	if result == nil {
+		result, kind = testCase.Error, ""
+	}
+	if result == nil {
+		return nil
+	}
+
+	message := result.Message
+	if result.Type != "" {
+		message = strings.TrimPrefix(result.Type+": "+message, ": ")
+	}
+	return &models.Failure{
+		Message:    strings.TrimSpace(message),
+		Kind:       kind,
+		StackTrace: strings.TrimSpace(result.Text),
+		Stdout:     testCase.SystemOut,
+		Stderr:     testCase.SystemErr,
+	}
+}
+
+func junitMessage(result *junitResult) string {
+	var parts []string
+	header := result.Message
+	if result.Type != "" && result.Message != "" {
+		header = result.Type + ": " + result.Message
+	} else if result.Type != "" {
+		header = result.Type
+	}
+	if header != "" {
+		parts = append(parts, header)
+	}
+	if text := strings.TrimSpace(result.Text); text != "" && text != result.Message {
+		parts = append(parts, text)
+	}
+	return strings.Join(parts, "\n")
+}
+
+func junitTags(properties []junitProperty, tagProperties []string) []models.Tag {
+	var tags []models.Tag
+	for _, property := range properties {
+		value := property.Value
+		if value == "" {
+			value = strings.TrimSpace(property.Text)
+		}
+		switch {
+		case property.Name == "tag" || property.Name == "tags":
+			for _, name := range strings.Split(value, ",") {
+				tags = appendTag(tags, name)
+			}
+		case slices.Contains(tagProperties, property.Name):
+			tags = appendTag(tags, property.Name+":"+value)
+		}
+	}
+	return tags
+}
+
+func parseJUnitTimestamp(timestamp string, defaultTime time.Time) time.Time {
+	if timestamp == "" {
+		return defaultTime
+	}
+	for _, layout := range junitTimestampLayouts {
+		if parsed, err := time.Parse(layout, timestamp); err == nil {
+			return parsed
+		}
+	}
+	return defaultTime
+}
+
+func parseJUnitDuration(seconds string) time.Duration {
+	if seconds == "" {
+		return 0
+	}
+	// Some producers format large durations with thousands separators
+	parsed, err := time.ParseDuration(strings.ReplaceAll(seconds, ",", "") + "s")
+	if err != nil || parsed < 0 {
+		return 0
+	}
+	return parsed
+}
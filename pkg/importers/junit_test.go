@@ -0,0 +1,127 @@
+package importers_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/guidewire/fern-reporter/pkg/importers"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseJUnit", func() {
+	Context("when given a <testsuites> report", func() {
+		report := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="Checkout" timestamp="2024-04-20T12:00:00Z">
+  <testsuite name="CartTest" timestamp="2024-04-20T12:00:00Z" time="3.5">
+    <properties>
+      <property name="tags" value="smoke, cart"/>
+      <property name="browser" value="chrome"/>
+    </properties>
+    <testcase name="adds an item" classname="CartTest" time="1.5"/>
+    <testcase name="removes an item" classname="com.shop.CartTest" time="1">
+      <failure message="expected 0 items" type="AssertionError">stack trace</failure>
+      <system-out>loading cart</system-out>
+      <system-err>cart is empty</system-err>
+    </testcase>
+    <testcase name="errors out" classname="CartTest" time="0.5">
+      <error message="NullPointerException"/>
+    </testcase>
+    <testcase name="is pending" classname="CartTest">
+      <skipped message="not implemented"/>
+    </testcase>
+  </testsuite>
+</testsuites>`
+
+		It("should map suites, cases, statuses and times", func() {
+			testRun, err := importers.ParseJUnit(strings.NewReader(report), importers.JUnitOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			start := time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+			Expect(testRun.TestProjectName).To(Equal("Checkout"))
+			Expect(testRun.StartTime).To(BeTemporally("==", start))
+			Expect(testRun.EndTime).To(BeTemporally("==", start.Add(3500*time.Millisecond)))
+			Expect(testRun.SuiteRuns).To(HaveLen(1))
+
+			suiteRun := testRun.SuiteRuns[0]
+			Expect(suiteRun.SuiteName).To(Equal("CartTest"))
+			Expect(suiteRun.SpecRuns).To(HaveLen(4))
+
+			Expect(suiteRun.SpecRuns[0].SpecDescription).To(Equal("adds an item"))
+			Expect(suiteRun.SpecRuns[0].Status).To(Equal("passed"))
+			Expect(suiteRun.SpecRuns[0].EndTime.Sub(suiteRun.SpecRuns[0].StartTime)).To(Equal(1500 * time.Millisecond))
+
+			Expect(suiteRun.SpecRuns[1].SpecDescription).To(Equal("com.shop.CartTest removes an item"))
+			Expect(suiteRun.SpecRuns[1].Status).To(Equal("failed"))
+			Expect(suiteRun.SpecRuns[1].Message).To(Equal("AssertionError: expected 0 items\nstack trace"))
+			Expect(suiteRun.SpecRuns[1].StartTime).To(BeTemporally("==", suiteRun.SpecRuns[0].EndTime))
+			Expect(suiteRun.SpecRuns[1].Failure).To(Equal(&models.Failure{
+				Message:    "AssertionError: expected 0 items",
+				Kind:       "assertion",
+				StackTrace: "stack trace",
+				Stdout:     "loading cart",
+				Stderr:     "cart is empty",
+			}))
+
+			Expect(suiteRun.SpecRuns[2].Status).To(Equal("errored"))
+			Expect(suiteRun.SpecRuns[2].Message).To(Equal("NullPointerException"))
+			Expect(suiteRun.SpecRuns[2].Failure).To(Equal(&models.Failure{Message: "NullPointerException"}))
+			Expect(suiteRun.SpecRuns[3].Failure).To(BeNil())
+
+			Expect(suiteRun.SpecRuns[3].Status).To(Equal("skipped"))
+			Expect(suiteRun.SpecRuns[3].Message).To(Equal("not implemented"))
+		})
+
+		It("should map tag properties onto tags", func() {
+			testRun, err := importers.ParseJUnit(strings.NewReader(report), importers.JUnitOptions{TagProperties: []string{"browser"}})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(testRun.SuiteRuns[0].SpecRuns[0].Tags).To(Equal([]models.Tag{
+				{Name: "smoke"},
+				{Name: "cart"},
+				{Name: "browser:chrome"},
+			}))
+		})
+
+		It("should prefer the project name given in the options", func() {
+			testRun, err := importers.ParseJUnit(strings.NewReader(report), importers.JUnitOptions{ProjectName: "Override"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(testRun.TestProjectName).To(Equal("Override"))
+		})
+	})
+
+	Context("when given a single <testsuite> report", func() {
+		It("should default the project name to the suite name", func() {
+			report := `<testsuite name="pytest"><testcase name="test_a" time="0.1"/></testsuite>`
+			testRun, err := importers.ParseJUnit(strings.NewReader(report), importers.JUnitOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(testRun.TestProjectName).To(Equal("pytest"))
+		})
+
+		It("should require a project name when the suite has none", func() {
+			report := `<testsuite><testcase name="test_a" time="0.1"/></testsuite>`
+			_, err := importers.ParseJUnit(strings.NewReader(report), importers.JUnitOptions{})
+			Expect(err).To(MatchError("junit report has no project name"))
+		})
+
+		It("should flatten nested suites", func() {
+			report := `<testsuite name="root">
+  <testsuite name="child-a"><testcase name="a" time="0.1"/></testsuite>
+  <testsuite name="child-b"><testcase name="b" time="0.1"/></testsuite>
+</testsuite>`
+			testRun, err := importers.ParseJUnit(strings.NewReader(report), importers.JUnitOptions{ProjectName: "nested"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(testRun.SuiteRuns).To(HaveLen(2))
+			Expect(testRun.SuiteRuns[0].SuiteName).To(Equal("child-a"))
+			Expect(testRun.SuiteRuns[1].SuiteName).To(Equal("child-b"))
+		})
+	})
+
+	Context("when given invalid XML", func() {
+		It("should return an error", func() {
+			_, err := importers.ParseJUnit(strings.NewReader("not xml"), importers.JUnitOptions{ProjectName: "p"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
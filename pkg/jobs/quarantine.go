@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/guidewire/fern-reporter/pkg/quarantine"
+	"gorm.io/gorm"
+)
+
+// StartAutoQuarantine periodically quarantines the specs whose flakiness
+// score reaches the threshold of the policy. It blocks until ctx is
+// cancelled, so it is meant to be started in its own goroutine. It does not
+// start unless the interval and the quarantine duration are positive.
+func StartAutoQuarantine(ctx context.Context, db *gorm.DB, interval time.Duration, policy quarantine.AutoPolicy) {
+	if interval <= 0 || policy.Duration <= 0 {
+		log.Printf("auto quarantine not started: interval (%v) and duration (%v) must be positive", interval, policy.Duration)
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			rules, err := quarantine.Auto(db, policy, now)
+			if err != nil {
+				log.Printf("error quarantining flaky specs: %v", err)
+			}
+			if len(rules) > 0 {
+				log.Printf("quarantined %d flaky specs", len(rules))
+			}
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package jobs_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/guidewire/fern-reporter/pkg/jobs"
+	"github.com/guidewire/fern-reporter/pkg/quarantine"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StartAutoQuarantine", func() {
+	It("should not start without a positive interval and duration", func() {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			jobs.StartAutoQuarantine(context.Background(), gormDb, 0, quarantine.AutoPolicy{Threshold: 0.5, Duration: time.Hour})
+			jobs.StartAutoQuarantine(context.Background(), gormDb, time.Hour, quarantine.AutoPolicy{Threshold: 0.5})
+		}()
+		Eventually(done).Should(BeClosed())
+	})
+})
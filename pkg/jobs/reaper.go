@@ -0,0 +1,53 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// StartRunReaper periodically aborts in-progress test runs that have seen no
+// activity for longer than timeout. It blocks until ctx is cancelled, so it is
+// meant to be started in its own goroutine. It does not start unless both
+// durations are positive.
+func StartRunReaper(ctx context.Context, db *gorm.DB, interval, timeout time.Duration) {
+	if interval <= 0 || timeout <= 0 {
+		log.Printf("run reaper not started: interval (%v) and in-progress timeout (%v) must be positive", interval, timeout)
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			reaped, err := ReapStaleTestRuns(db, now.Add(-timeout))
+			if err != nil {
+				log.Printf("error reaping stale test runs: %v", err)
+			} else if reaped > 0 {
+				log.Printf("marked %d stale test runs as %s", reaped, utils.RunStatusAborted)
+			}
+		}
+	}
+}
+
+// ReapStaleTestRuns marks every in-progress test run whose last activity is
+// before cutoff as aborted, ending it at its last activity. It returns the
+// number of runs that were aborted.
+func ReapStaleTestRuns(db *gorm.DB, cutoff time.Time) (int64, error) {
+	result := db.Model(&models.TestRun{}).
+		Where("status = ?", utils.RunStatusInProgress).
+		Where("last_activity_time < ?", cutoff).
+		Updates(map[string]interface{}{
+			"status":   utils.RunStatusAborted,
+			"end_time": gorm.Expr("last_activity_time"),
+		})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,77 @@
+package jobs_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/guidewire/fern-reporter/pkg/jobs"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+var (
+	db     *sql.DB
+	gormDb *gorm.DB
+	mock   sqlmock.Sqlmock
+)
+
+var _ = BeforeEach(func() {
+	db, mock, _ = sqlmock.New()
+
+	dialector := postgres.New(postgres.Config{
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		Conn:                 db,
+		PreferSimpleProtocol: true,
+	})
+	gormDb, _ = gorm.Open(dialector, &gorm.Config{})
+})
+
+var _ = AfterEach(func() {
+	db.Close()
+})
+
+var _ = Describe("ReapStaleTestRuns", func() {
+	cutoff := time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+
+	It("should abort in-progress runs without recent activity", func() {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_runs" SET "end_time"=last_activity_time,"status"=$1 WHERE status = $2 AND last_activity_time < $3`)).
+			WithArgs("aborted", "in_progress", cutoff).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		reaped, err := jobs.ReapStaleTestRuns(gormDb, cutoff)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reaped).To(Equal(int64(2)))
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("should return database errors", func() {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "test_runs"`)).
+			WillReturnError(errors.New("database error"))
+		mock.ExpectRollback()
+
+		_, err := jobs.ReapStaleTestRuns(gormDb, cutoff)
+		Expect(err).To(MatchError("database error"))
+	})
+})
+
+var _ = Describe("StartRunReaper", func() {
+	It("should not start without a positive interval and timeout", func() {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			jobs.StartRunReaper(context.Background(), gormDb, 0, time.Hour)
+			jobs.StartRunReaper(context.Background(), gormDb, time.Minute, -time.Hour)
+		}()
+		Eventually(done).Should(BeClosed())
+	})
+})
@@ -0,0 +1,174 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/guidewire/fern-reporter/pkg/blobstore"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// purgeBatchPause is waited between batches so that a large purge does not
+// monopolize the database.
+const purgeBatchPause = 100 * time.Millisecond
+
+// RetentionPolicy is the default retention of test runs. Registered projects
+// override each limit with a non-zero value of their own. A limit of 0 does
+// not expire runs; when both limits apply, a run is only purged once it is
+// outside both of them. Runs in the trash are not counted by either limit and
+// are purged once they have been deleted for longer than TrashGracePeriod.
+type RetentionPolicy struct {
+	// Days keeps the runs started within the last number of days.
+	Days int
+	// RunsPerBranch keeps the most recent runs of each project branch.
+	RunsPerBranch int
+	// TrashGracePeriod keeps deleted runs restorable for this long.
+	TrashGracePeriod time.Duration
+}
+
+// StartRetentionPurge periodically purges the test runs that have expired
+// under the policy, in batches of batchSize runs. It blocks until ctx is
+// cancelled, so it is meant to be started in its own goroutine. It does not
+// start unless the interval and the batch size are positive.
+func StartRetentionPurge(ctx context.Context, db *gorm.DB, blobs blobstore.Store, interval time.Duration, policy RetentionPolicy, batchSize int) {
+	if interval <= 0 || batchSize <= 0 {
+		log.Printf("retention purge not started: interval (%v) and batch size (%d) must be positive", interval, batchSize)
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			purged, err := PurgeExpiredTestRuns(ctx, db, blobs, policy, now, batchSize)
+			if err != nil {
+				log.Printf("error purging expired test runs: %v", err)
+			}
+			if purged > 0 {
+				log.Printf("purged %d expired test runs", purged)
+			}
+		}
+	}
+}
+
+// PurgeExpiredTestRuns deletes the test runs that have expired at now, one
+// short transaction per batch. The runs of each batch are added to the daily
+// aggregates before they are deleted, and the contents of their attachments
+// are removed from blobs once they are gone. It returns the number of runs
+// purged, including those of the batches completed before an error.
+func PurgeExpiredTestRuns(ctx context.Context, db *gorm.DB, blobs blobstore.Store, policy RetentionPolicy, now time.Time, batchSize int) (int64, error) {
+	var purged int64
+	for {
+		var ids []uint64
+		err := expiredTestRuns(db, policy, now).
+			Order("ranked.id").
+			Limit(batchSize).
+			Pluck("ranked.id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return purged, err
+		}
+
+		var storageKeys []string
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := aggregateTestRuns(tx, ids); err != nil {
+				return err
+			}
+			if err := tx.Model(&models.Attachment{}).Where("test_run_id IN ?", ids).Pluck("storage_key", &storageKeys).Error; err != nil {
+				return err
+			}
+			return tx.Unscoped().Where("id IN ?", ids).Delete(&models.TestRun{}).Error
+		})
+		if err != nil {
+			return purged, err
+		}
+		purged += int64(len(ids))
+		blobstore.DeleteAll(ctx, blobs, storageKeys)
+
+		if len(ids) < batchSize {
+			return purged, nil
+		}
+		select {
+		case <-ctx.Done():
+			return purged, ctx.Err()
+		case <-time.After(purgeBatchPause):
+		}
+	}
+}
+
+// SummarizeExpiredTestRuns reports, for each project, the test runs that
+// PurgeExpiredTestRuns would delete at now.
+func SummarizeExpiredTestRuns(db *gorm.DB, policy RetentionPolicy, now time.Time) ([]models.PurgeSummary, error) {
+	summaries := []models.PurgeSummary{}
+	err := expiredTestRuns(db, policy, now).
+		Select("ranked.test_project_name, COUNT(*) AS runs, " +
+			"MIN(ranked.start_time) AS oldest_start_time, MAX(ranked.start_time) AS newest_start_time").
+		Group("ranked.test_project_name").
+		Order("ranked.test_project_name").
+		Scan(&summaries).Error
+	return summaries, err
+}
+
+// expiredTestRuns selects the finished test runs that are outside the
+// retention of their project, and the trashed runs past the grace period, as
+// the table "ranked".
+func expiredTestRuns(db *gorm.DB, policy RetentionPolicy, now time.Time) *gorm.DB {
+	ranked := db.Table("test_runs").
+		Select("test_runs.id, test_runs.test_project_name, test_runs.start_time, test_runs.deleted_at, "+
+			"ROW_NUMBER() OVER (PARTITION BY test_runs.test_project_name, test_runs.git_branch, test_runs.deleted_at IS NULL "+
+			"ORDER BY test_runs.start_time DESC, test_runs.id DESC) AS branch_rank, "+
+			"COALESCE(NULLIF(projects.retention_days, 0), ?) AS days, "+
+			"COALESCE(NULLIF(projects.retention_runs_per_branch, 0), ?) AS runs", policy.Days, policy.RunsPerBranch).
+		Joins("LEFT JOIN projects ON projects.name = test_runs.test_project_name").
+		Where("test_runs.status <> ?", utils.RunStatusInProgress)
+
+	return db.Table("(?) AS ranked", ranked).
+		Where("(ranked.deleted_at IS NULL AND (ranked.days > 0 OR ranked.runs > 0) "+
+			"AND (ranked.days = 0 OR ranked.start_time + make_interval(days => ranked.days) < ?) "+
+			"AND (ranked.runs = 0 OR ranked.branch_rank > ranked.runs)) "+
+			"OR ranked.deleted_at < ?", now, now.Add(-policy.TrashGracePeriod))
+}
+
+// aggregateTestRuns adds the test runs to the daily aggregates of their
+// project branch.
+func aggregateTestRuns(tx *gorm.DB, ids []uint64) error {
+	return tx.Exec(`INSERT INTO test_run_daily_stats AS stats
+    (test_project_name, git_branch, day, runs, spec_runs, passed, failed, skipped, flaky, duration_seconds)
+SELECT test_project_name, git_branch, day, COUNT(*), SUM(spec_runs), SUM(passed), SUM(failed), SUM(skipped), SUM(flaky), SUM(duration_seconds)
+FROM (
+    SELECT test_runs.test_project_name, test_runs.git_branch, CAST(test_runs.start_time AS date) AS day,
+        COALESCE(EXTRACT(EPOCH FROM (test_runs.end_time - test_runs.start_time)), 0) AS duration_seconds,
+        COUNT(spec_runs.id) AS spec_runs,
+        COUNT(spec_runs.id) FILTER (WHERE spec_runs.status = @passed) AS passed,
+        COUNT(spec_runs.id) FILTER (WHERE spec_runs.status IN @failed) AS failed,
+        COUNT(spec_runs.id) FILTER (WHERE spec_runs.status IN @skipped) AS skipped,
+        COUNT(spec_runs.id) FILTER (WHERE spec_runs.status = @flaky) AS flaky
+    FROM test_runs
+    LEFT JOIN suite_runs ON suite_runs.test_run_id = test_runs.id
+    LEFT JOIN spec_runs ON spec_runs.suite_id = suite_runs.id
+    WHERE test_runs.id IN @ids
+    GROUP BY test_runs.id
+) purged
+GROUP BY test_project_name, git_branch, day
+ON CONFLICT (test_project_name, git_branch, day) DO UPDATE SET
+    runs = stats.runs + EXCLUDED.runs,
+    spec_runs = stats.spec_runs + EXCLUDED.spec_runs,
+    passed = stats.passed + EXCLUDED.passed,
+    failed = stats.failed + EXCLUDED.failed,
+    skipped = stats.skipped + EXCLUDED.skipped,
+    flaky = stats.flaky + EXCLUDED.flaky,
+    duration_seconds = stats.duration_seconds + EXCLUDED.duration_seconds`,
+		map[string]interface{}{
+			"ids":     ids,
+			"passed":  utils.StatusPassed,
+			"failed":  []string{utils.StatusFailed, utils.StatusErrored},
+			"skipped": []string{utils.StatusSkipped, utils.StatusPending},
+			"flaky":   utils.StatusFlaky,
+		}).Error
+}
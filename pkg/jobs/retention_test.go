@@ -0,0 +1,107 @@
+package jobs_test
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/guidewire/fern-reporter/pkg/blobstore"
+	"github.com/guidewire/fern-reporter/pkg/jobs"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Retention", func() {
+	now := time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+	policy := jobs.RetentionPolicy{Days: 90, RunsPerBranch: 20, TrashGracePeriod: 72 * time.Hour}
+	trashExpiry := now.Add(-72 * time.Hour)
+	expired := regexp.QuoteMeta(`FROM (SELECT test_runs.id, test_runs.test_project_name, test_runs.start_time, test_runs.deleted_at, ` +
+		`ROW_NUMBER() OVER (PARTITION BY test_runs.test_project_name, test_runs.git_branch, test_runs.deleted_at IS NULL ` +
+		`ORDER BY test_runs.start_time DESC, test_runs.id DESC) AS branch_rank, ` +
+		`COALESCE(NULLIF(projects.retention_days, 0), $1) AS days, COALESCE(NULLIF(projects.retention_runs_per_branch, 0), $2) AS runs ` +
+		`FROM "test_runs" LEFT JOIN projects ON projects.name = test_runs.test_project_name WHERE test_runs.status <> $3) AS ranked ` +
+		`WHERE (ranked.deleted_at IS NULL AND (ranked.days > 0 OR ranked.runs > 0) ` +
+		`AND (ranked.days = 0 OR ranked.start_time + make_interval(days => ranked.days) < $4) ` +
+		`AND (ranked.runs = 0 OR ranked.branch_rank > ranked.runs)) OR ranked.deleted_at < $5`)
+
+	Context("PurgeExpiredTestRuns", func() {
+		It("should aggregate and delete expired runs in batches", func() {
+			store, err := blobstore.NewLocalStore(GinkgoT().TempDir())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(store.Put(context.Background(), "testruns/1/abc", strings.NewReader("log"), 3, "text/plain")).To(Succeed())
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT "ranked"."id" `)+expired+regexp.QuoteMeta(` ORDER BY ranked.id LIMIT $6`)).
+				WithArgs(90, 20, "in_progress", now, trashExpiry, 2).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO test_run_daily_stats AS stats`)).
+				WithArgs("passed", "failed", "errored", "skipped", "pending", "flaky", 1, 2).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT "storage_key" FROM "attachments" WHERE test_run_id IN ($1,$2)`)).
+				WithArgs(1, 2).
+				WillReturnRows(sqlmock.NewRows([]string{"storage_key"}).AddRow("testruns/1/abc"))
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "test_runs" WHERE id IN ($1,$2)`)).
+				WithArgs(1, 2).
+				WillReturnResult(sqlmock.NewResult(0, 2))
+			mock.ExpectCommit()
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT "ranked"."id" `) + expired).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			purged, err := jobs.PurgeExpiredTestRuns(context.Background(), gormDb, store, policy, now, 2)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(purged).To(Equal(int64(2)))
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+			_, err = store.Get(context.Background(), "testruns/1/abc")
+			Expect(err).To(MatchError(blobstore.ErrNotFound))
+		})
+
+		It("should keep the runs when the batch fails", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT "ranked"."id" `) + expired).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO test_run_daily_stats`)).
+				WillReturnError(context.DeadlineExceeded)
+			mock.ExpectRollback()
+
+			purged, err := jobs.PurgeExpiredTestRuns(context.Background(), gormDb, nil, policy, now, 10)
+
+			Expect(err).To(HaveOccurred())
+			Expect(purged).To(BeZero())
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+
+	Context("StartRetentionPurge", func() {
+		It("should not start without a positive interval and batch size", func() {
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				jobs.StartRetentionPurge(context.Background(), gormDb, nil, 0, policy, 10)
+				jobs.StartRetentionPurge(context.Background(), gormDb, nil, time.Hour, policy, 0)
+			}()
+			Eventually(done).Should(BeClosed())
+		})
+	})
+
+	Context("SummarizeExpiredTestRuns", func() {
+		It("should count the expired runs of each project", func() {
+			oldest := now.AddDate(0, -6, 0)
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT ranked.test_project_name, COUNT(*) AS runs, MIN(ranked.start_time) AS oldest_start_time, MAX(ranked.start_time) AS newest_start_time `)+
+				expired+regexp.QuoteMeta(` GROUP BY "ranked"."test_project_name" ORDER BY ranked.test_project_name`)).
+				WithArgs(90, 20, "in_progress", now, trashExpiry).
+				WillReturnRows(sqlmock.NewRows([]string{"test_project_name", "runs", "oldest_start_time", "newest_start_time"}).
+					AddRow("Checkout", 12, oldest, now.AddDate(0, -3, 0)))
+
+			summaries, err := jobs.SummarizeExpiredTestRuns(gormDb, policy, now)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(summaries).To(HaveLen(1))
+			Expect(summaries[0].TestProjectName).To(Equal("Checkout"))
+			Expect(summaries[0].Runs).To(Equal(int64(12)))
+			Expect(summaries[0].OldestStartTime).To(Equal(oldest))
+		})
+	})
+})
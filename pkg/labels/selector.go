@@ -0,0 +1,189 @@
+// Package labels implements label selectors such as
+// "component=billing,os!=windows" over the labels of test runs.
+//
+// A spec run's effective labels are the labels of its test run, overridden by
+// those of its suite run and then by its own. Selectors are matched against
+// effective labels, and a test run matches when at least one of its spec runs
+// does.
+package labels
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"gorm.io/gorm"
+)
+
+// KeyPattern is the format of label keys. It keeps keys free of the
+// characters that separate selector requirements.
+var KeyPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9_.\-/]*[A-Za-z0-9])?$`)
+
+type operator int
+
+const (
+	opEquals operator = iota
+	opNotEquals
+	opExists
+	opNotExists
+)
+
+type requirement struct {
+	key   string
+	op    operator
+	value string
+}
+
+// Selector is a conjunction of label requirements. The zero Selector matches
+// everything.
+type Selector struct {
+	requirements []requirement
+}
+
+// Parse reads a comma separated list of requirements. Each requirement is one
+// of "key=value", "key==value", "key!=value", "key" (the label is set) or
+// "!key" (the label is not set).
+func Parse(selector string) (Selector, error) {
+	var parsed Selector
+	for _, part := range strings.Split(selector, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		var req requirement
+		switch {
+		case strings.Contains(part, "!="):
+			key, value, _ := strings.Cut(part, "!=")
+			req = requirement{key: key, op: opNotEquals, value: value}
+		case strings.Contains(part, "=="):
+			key, value, _ := strings.Cut(part, "==")
+			req = requirement{key: key, op: opEquals, value: value}
+		case strings.Contains(part, "="):
+			key, value, _ := strings.Cut(part, "=")
+			req = requirement{key: key, op: opEquals, value: value}
+		case strings.HasPrefix(part, "!"):
+			req = requirement{key: strings.TrimPrefix(part, "!"), op: opNotExists}
+		default:
+			req = requirement{key: part, op: opExists}
+		}
+
+		req.key = strings.TrimSpace(req.key)
+		req.value = strings.TrimSpace(req.value)
+		if !KeyPattern.MatchString(req.key) {
+			return Selector{}, fmt.Errorf("invalid label key in %q", part)
+		}
+		parsed.requirements = append(parsed.requirements, req)
+	}
+	return parsed, nil
+}
+
+// Empty reports whether the selector has no requirements.
+func (s Selector) Empty() bool {
+	return len(s.requirements) == 0
+}
+
+// Matches reports whether the labels satisfy every requirement.
+func (s Selector) Matches(labels models.Labels) bool {
+	for _, req := range s.requirements {
+		value, ok := labels[req.key]
+		switch req.op {
+		case opEquals:
+			if !ok || value != req.value {
+				return false
+			}
+		case opNotEquals:
+			if ok && value == req.value {
+				return false
+			}
+		case opExists:
+			if !ok {
+				return false
+			}
+		case opNotExists:
+			if ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// RunScope limits a test_runs query to runs with at least one matching spec
+// run, so that the database does the coarse filtering.
+func (s Selector) RunScope() func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if s.Empty() {
+			return db
+		}
+
+		effective := "(test_runs.labels || suite_runs.labels || spec_runs.labels)"
+		var conditions []string
+		var args []interface{}
+		for _, req := range s.requirements {
+			switch req.op {
+			case opEquals:
+				conditions = append(conditions, effective+" ->> ? = ?")
+				args = append(args, req.key, req.value)
+			case opNotEquals:
+				conditions = append(conditions, effective+" ->> ? IS DISTINCT FROM ?")
+				args = append(args, req.key, req.value)
+			case opExists:
+				conditions = append(conditions, effective+" ->> ? IS NOT NULL")
+				args = append(args, req.key)
+			case opNotExists:
+				conditions = append(conditions, effective+" ->> ? IS NULL")
+				args = append(args, req.key)
+			}
+		}
+
+		return db.Where("EXISTS (SELECT 1 FROM suite_runs INNER JOIN spec_runs ON spec_runs.suite_id = suite_runs.id"+
+			" WHERE suite_runs.test_run_id = test_runs.id AND "+strings.Join(conditions, " AND ")+")", args...)
+	}
+}
+
+// FilterTestRun keeps the spec runs of a test run whose effective labels
+// match, dropping suite runs left without spec runs.
+func (s Selector) FilterTestRun(testRun *models.TestRun) {
+	if s.Empty() {
+		return
+	}
+
+	var suiteRuns []models.SuiteRun
+	for _, suiteRun := range testRun.SuiteRuns {
+		var specRuns []models.SpecRun
+		for _, specRun := range suiteRun.SpecRuns {
+			if s.Matches(Effective(*testRun, suiteRun, specRun)) {
+				specRuns = append(specRuns, specRun)
+			}
+		}
+		if len(specRuns) > 0 {
+			suiteRun.SpecRuns = specRuns
+			suiteRuns = append(suiteRuns, suiteRun)
+		}
+	}
+	testRun.SuiteRuns = suiteRuns
+}
+
+// FilterTestRuns applies FilterTestRun to every test run and drops the runs
+// left without suite runs.
+func (s Selector) FilterTestRuns(testRuns []models.TestRun) []models.TestRun {
+	if s.Empty() {
+		return testRuns
+	}
+
+	filtered := []models.TestRun{}
+	for _, testRun := range testRuns {
+		s.FilterTestRun(&testRun)
+		if len(testRun.SuiteRuns) > 0 {
+			filtered = append(filtered, testRun)
+		}
+	}
+	return filtered
+}
+
+// Effective returns the labels that apply to a spec run.
+func Effective(testRun models.TestRun, suiteRun models.SuiteRun, specRun models.SpecRun) models.Labels {
+	return testRun.Labels.Merge(suiteRun.Labels, specRun.Labels)
+}
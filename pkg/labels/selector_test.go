@@ -0,0 +1,71 @@
+package labels_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/pkg/labels"
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+var _ = Describe("Selector", func() {
+	DescribeTable("Matches",
+		func(selector string, labelSet models.Labels, expected bool) {
+			parsed, err := labels.Parse(selector)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Matches(labelSet)).To(Equal(expected))
+		},
+		Entry("empty selector", "", models.Labels{}, true),
+		Entry("equality", "os=linux", models.Labels{"os": "linux"}, true),
+		Entry("double equals", "os==linux", models.Labels{"os": "windows"}, false),
+		Entry("inequality with a different value", "os!=windows", models.Labels{"os": "linux"}, true),
+		Entry("inequality with a missing label", "os!=windows", models.Labels{}, true),
+		Entry("existence", "gpu", models.Labels{"gpu": ""}, true),
+		Entry("non-existence", "!gpu", models.Labels{"gpu": "a100"}, false),
+		Entry("every requirement", "os=linux, component=billing", models.Labels{"os": "linux", "component": "search"}, false),
+	)
+
+	It("should reject invalid keys", func() {
+		_, err := labels.Parse("os version=14")
+		Expect(err).To(MatchError(`invalid label key in "os version=14"`))
+
+		_, err = labels.Parse("=linux")
+		Expect(err).To(HaveOccurred())
+	})
+
+	Describe("FilterTestRuns", func() {
+		It("should keep the spec runs whose effective labels match", func() {
+			testRuns := []models.TestRun{
+				{
+					ID:     1,
+					Labels: models.Labels{"os": "linux"},
+					SuiteRuns: []models.SuiteRun{
+						{
+							SuiteName: "Cart",
+							SpecRuns: []models.SpecRun{
+								{SpecDescription: "adds an item"},
+								{SpecDescription: "runs on windows", Labels: models.Labels{"os": "windows"}},
+							},
+						},
+						{
+							SuiteName: "Search",
+							Labels:    models.Labels{"os": "macos"},
+							SpecRuns:  []models.SpecRun{{SpecDescription: "finds an item"}},
+						},
+					},
+				},
+				{ID: 2, Labels: models.Labels{"os": "windows"}, SuiteRuns: []models.SuiteRun{{SpecRuns: []models.SpecRun{{}}}}},
+			}
+
+			selector, err := labels.Parse("os=linux")
+			Expect(err).NotTo(HaveOccurred())
+			filtered := selector.FilterTestRuns(testRuns)
+
+			Expect(filtered).To(HaveLen(1))
+			Expect(filtered[0].ID).To(Equal(uint64(1)))
+			Expect(filtered[0].SuiteRuns).To(HaveLen(1))
+			Expect(filtered[0].SuiteRuns[0].SpecRuns).To(HaveLen(1))
+			Expect(filtered[0].SuiteRuns[0].SpecRuns[0].SpecDescription).To(Equal("adds an item"))
+		})
+	})
+})
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// SpecAttempt is one execution of a spec that was retried within a test run,
+// for example with Ginkgo's --flake-attempts.
+type SpecAttempt struct {
+	Status    string    `json:"status"`
+	Message   string    `json:"message,omitempty"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+// SpecAttempts are the attempts of a spec run in the order they ran. They are
+// stored as a JSONB array.
+type SpecAttempts []SpecAttempt
+
+func (a SpecAttempts) Value() (driver.Value, error) {
+	if a == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (a *SpecAttempts) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into SpecAttempts", value)
+	}
+	if len(data) == 0 {
+		*a = nil
+		return nil
+	}
+	return json.Unmarshal(data, a)
+}
+
+// MarshalGQL writes the attempts as a GraphQL list of objects.
+func (a SpecAttempts) MarshalGQL(w io.Writer) {
+	data, err := json.Marshal(a)
+	if err != nil || a == nil {
+		data = []byte("[]")
+	}
+	_, _ = w.Write(data)
+}
+
+// UnmarshalGQL reads attempts from a GraphQL list of objects.
+func (a *SpecAttempts) UnmarshalGQL(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, a); err != nil {
+		return fmt.Errorf("attempts must be a list of {status, message, start_time, end_time} objects: %w", err)
+	}
+	return nil
+}
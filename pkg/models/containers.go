@@ -0,0 +1,82 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Container is one of the nested Describe/Context containers of a spec,
+// with the labels declared on it.
+type Container struct {
+	Text   string   `json:"text"`
+	Labels []string `json:"labels,omitempty"`
+}
+
+// Containers is the container hierarchy of a spec, outermost first. It is
+// stored as a JSONB array.
+type Containers []Container
+
+// FullText joins the container texts and the spec description the way
+// Ginkgo does, giving the flat description of a spec.
+func (c Containers) FullText(specDescription string) string {
+	texts := make([]string, 0, len(c)+1)
+	for _, container := range c {
+		texts = append(texts, container.Text)
+	}
+	return strings.Join(append(texts, specDescription), " ")
+}
+
+func (c Containers) Value() (driver.Value, error) {
+	if c == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (c *Containers) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Containers", value)
+	}
+	if len(data) == 0 {
+		*c = nil
+		return nil
+	}
+	return json.Unmarshal(data, c)
+}
+
+// MarshalGQL writes the containers as a GraphQL list of objects.
+func (c Containers) MarshalGQL(w io.Writer) {
+	data, err := json.Marshal(c)
+	if err != nil || c == nil {
+		data = []byte("[]")
+	}
+	_, _ = w.Write(data)
+}
+
+// UnmarshalGQL reads containers from a GraphQL list of objects.
+func (c *Containers) UnmarshalGQL(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return fmt.Errorf("containers must be a list of {text, labels} objects: %w", err)
+	}
+	return nil
+}
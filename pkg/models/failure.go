@@ -0,0 +1,66 @@
+package models
+
+import (
+	"bytes"
+	"compress/gzip"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Failure describes why a spec run failed. Stack traces and captured output
+// can be large, so failures are stored as gzip compressed JSON.
+type Failure struct {
+	Message    string `json:"message"`
+	Kind       string `json:"kind,omitempty"`
+	File       string `json:"file,omitempty"`
+	Line       int    `json:"line,omitempty"`
+	StackTrace string `json:"stack_trace,omitempty"`
+	Stdout     string `json:"stdout,omitempty"`
+	Stderr     string `json:"stderr,omitempty"`
+}
+
+func (f Failure) Value() (driver.Value, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(writer).Encode(f); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Scan reads a failure stored by Value. Uncompressed JSON is accepted too.
+func (f *Failure) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*f = Failure{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Failure", value)
+	}
+	if len(data) == 0 {
+		*f = Failure{}
+		return nil
+	}
+
+	var reader io.Reader = bytes.NewReader(data)
+	if len(data) > 1 && data[0] == 0x1f && data[1] == 0x8b {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return err
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+	*f = Failure{}
+	return json.NewDecoder(reader).Decode(f)
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Labels are key/value pairs attached to test runs, suite runs and spec runs.
+// They are stored as a JSONB object.
+type Labels map[string]string
+
+// Merge returns a copy of l overridden by the labels of more specific levels.
+func (l Labels) Merge(more ...Labels) Labels {
+	merged := Labels{}
+	for key, value := range l {
+		merged[key] = value
+	}
+	for _, labels := range more {
+		for key, value := range labels {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+func (l Labels) Value() (driver.Value, error) {
+	if l == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (l *Labels) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Labels", value)
+	}
+	if len(data) == 0 {
+		*l = nil
+		return nil
+	}
+	return json.Unmarshal(data, l)
+}
+
+// MarshalGQL writes the labels as a GraphQL object.
+func (l Labels) MarshalGQL(w io.Writer) {
+	data, err := json.Marshal(l)
+	if err != nil || l == nil {
+		data = []byte("{}")
+	}
+	_, _ = w.Write(data)
+}
+
+// UnmarshalGQL reads labels from a GraphQL object of string values.
+func (l *Labels) UnmarshalGQL(v interface{}) error {
+	object, ok := v.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("labels must be an object, got %T", v)
+	}
+	labels := Labels{}
+	for key, value := range object {
+		switch value := value.(type) {
+		case string:
+			labels[key] = value
+		case json.Number:
+			labels[key] = value.String()
+		case bool:
+			labels[key] = strconv.FormatBool(value)
+		default:
+			return fmt.Errorf("label %q must be a string", key)
+		}
+	}
+	*l = labels
+	return nil
+}
@@ -1,7 +1,10 @@
 package models
 
 import (
+	"strings"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type TimeLog struct {
@@ -10,12 +13,87 @@ type TimeLog struct {
 }
 
 type TestRun struct {
-	ID              uint64     `json:"id" gorm:"primaryKey"`
-	TestProjectName string     `json:"test_project_name"`
-	TestSeed        uint64     `json:"test_seed"`
-	StartTime       time.Time  `json:"start_time"`
-	EndTime         time.Time  `json:"end_time"`
-	SuiteRuns       []SuiteRun `json:"suite_runs" gorm:"foreignKey:TestRunID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	ID                uint64     `json:"id" gorm:"primaryKey"`
+	TestProjectName   string     `json:"test_project_name"`
+	TestSeed          uint64     `json:"test_seed"`
+	StartTime         time.Time  `json:"start_time"`
+	EndTime           time.Time  `json:"end_time"`
+	Status            string     `json:"status"`
+	LastActivityTime  time.Time  `json:"last_activity_time"`
+	IdempotencyKey    *string    `json:"idempotency_key,omitempty"`
+	GitSha            string     `json:"git_sha"`
+	GitBranch         string     `json:"git_branch"`
+	GitRepoURL        string     `json:"git_repo_url"`
+	PullRequestNumber int        `json:"pull_request_number"`
+	CIProvider        string     `json:"ci_provider" gorm:"column:ci_provider"`
+	BuildNumber       string     `json:"build_number"`
+	BuildURL          string     `json:"build_url"`
+	TriggeredBy       string     `json:"triggered_by"`
+	Environment       string     `json:"environment"`
+	Labels            Labels     `json:"labels,omitempty" gorm:"type:jsonb"`
+	SuiteRuns         []SuiteRun `json:"suite_runs" gorm:"foreignKey:TestRunID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	// DeletedAt moves a deleted run to the trash, hiding it from every query
+	// until it is restored or purged.
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+	DeletedBy string         `json:"deleted_by,omitempty"`
+}
+
+// LiveTestRuns hides test runs in the trash from queries that gorm does not
+// scope to undeleted rows by itself: queries reading test_runs as a plain
+// table or through a join, and queries of models without a DeletedAt field.
+func LiveTestRuns(db *gorm.DB) *gorm.DB {
+	return db.Where("test_runs.deleted_at IS NULL")
+}
+
+// Project is a registered project. Test runs belong to the project whose Name
+// equals their test_project_name; Slug identifies it in URLs.
+type Project struct {
+	ID            uint64 `json:"id" gorm:"primaryKey"`
+	Slug          string `json:"slug"`
+	Name          string `json:"name"`
+	DisplayName   string `json:"display_name"`
+	Description   string `json:"description"`
+	Team          string `json:"team"`
+	DefaultBranch string `json:"default_branch"`
+	RetentionDays int    `json:"retention_days"`
+	// RetentionRunsPerBranch keeps the most recent runs of each branch.
+	// Like RetentionDays, 0 falls back to the configured default.
+	RetentionRunsPerBranch int       `json:"retention_runs_per_branch"`
+	ReportHeader           string    `json:"report_header"`
+	CreatedAt              time.Time `json:"created_at"`
+	UpdatedAt              time.Time `json:"updated_at"`
+}
+
+// OwnershipRule assigns an owner to the specs of a project matching all of
+// its non-empty glob patterns. Rules are evaluated in order and the last
+// matching rule wins, as in a CODEOWNERS file.
+type OwnershipRule struct {
+	ID        uint64 `json:"-" gorm:"primaryKey"`
+	ProjectID uint64 `json:"-"`
+	Position  int    `json:"-"`
+	Suite     string `json:"suite,omitempty"`
+	Spec      string `json:"spec,omitempty"`
+	File      string `json:"file,omitempty"`
+	Tag       string `json:"tag,omitempty"`
+	Owner     string `json:"owner"`
+}
+
+// QuarantineRule quarantines a spec of a project until ExpiresAt. It matches
+// the spec runs of its test case, or with its suite name and spec description.
+// Source is manual, or auto when the rule was created for a flakiness Score
+// above the configured threshold.
+type QuarantineRule struct {
+	ID              uint64    `json:"id" gorm:"primaryKey"`
+	TestProjectName string    `json:"test_project_name"`
+	TestCaseID      *uint64   `json:"test_case_id,omitempty"`
+	SuiteName       string    `json:"suite_name"`
+	SpecDescription string    `json:"spec_description"`
+	Reason          string    `json:"reason"`
+	Source          string    `json:"source"`
+	Score           float64   `json:"score,omitempty"`
+	CreatedBy       string    `json:"created_by"`
+	CreatedAt       time.Time `json:"created_at"`
+	ExpiresAt       time.Time `json:"expires_at"`
 }
 
 type SuiteRun struct {
@@ -24,18 +102,70 @@ type SuiteRun struct {
 	SuiteName string    `json:"suite_name"`
 	StartTime time.Time `json:"start_time"`
 	EndTime   time.Time `json:"end_time"`
+	Labels    Labels    `json:"labels,omitempty" gorm:"type:jsonb"`
 	SpecRuns  []SpecRun `json:"spec_runs" gorm:"foreignKey:SuiteID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
 
 type SpecRun struct {
+	ID              uint64       `json:"id" gorm:"primaryKey"`
+	SuiteID         uint64       `json:"suite_id"`
+	SpecDescription string       `json:"spec_description"`
+	Status          string       `json:"status"`
+	Message         string       `json:"message"`
+	Tags            []Tag        `json:"tags" gorm:"many2many:spec_run_tags;"`
+	StartTime       time.Time    `json:"start_time"`
+	EndTime         time.Time    `json:"end_time"`
+	Labels          Labels       `json:"labels,omitempty" gorm:"type:jsonb"`
+	Containers      Containers   `json:"containers,omitempty" gorm:"type:jsonb"`
+	Failure         *Failure     `json:"failure,omitempty" gorm:"type:bytea"`
+	Attempts        SpecAttempts `json:"attempts,omitempty" gorm:"type:jsonb"`
+	TestCaseID      *uint64      `json:"test_case_id,omitempty"`
+	Owner           string       `json:"owner,omitempty" gorm:"-"`
+	// Quarantined spec runs keep their status but do not fail their run.
+	Quarantined bool `json:"quarantined,omitempty" gorm:"-"`
+	// Classification compares the spec run with the previous runs of its
+	// spec on the same project branch, when the report asks for it.
+	Classification *FailureClassification `json:"classification,omitempty" gorm:"-"`
+}
+
+// FailureClassification tells whether a failed spec run is a new failure or
+// was already failing, and whether a passed spec run fixed a failure.
+// PreviousTestRunID is the previous run of the spec, and for still failing
+// specs FailingSince is the first run of the failure streak.
+type FailureClassification struct {
+	Kind                  string     `json:"kind"`
+	FailingSince          *time.Time `json:"failing_since,omitempty"`
+	FailingSinceTestRunID uint64     `json:"failing_since_test_run_id,omitempty"`
+	FailingSinceGitSha    string     `json:"failing_since_git_sha,omitempty"`
+	PreviousTestRunID     uint64     `json:"previous_test_run_id,omitempty"`
+}
+
+// TestCase is the identity of a spec across runs. Spec runs are linked to the
+// test case of their project with the same fingerprint. A test case merged
+// into another one, for example after a rename, redirects to it.
+type TestCase struct {
 	ID              uint64    `json:"id" gorm:"primaryKey"`
-	SuiteID         uint64    `json:"suite_id"`
+	TestProjectName string    `json:"test_project_name"`
+	SuiteName       string    `json:"suite_name"`
+	SpecDescription string    `json:"spec_description"`
+	Fingerprint     string    `json:"fingerprint"`
+	MergedIntoID    *uint64   `json:"merged_into_id,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// TestCaseRun is one past execution of a test case.
+type TestCaseRun struct {
+	TestRunID       uint64    `json:"test_run_id"`
+	SpecRunID       uint64    `json:"spec_run_id"`
+	SuiteName       string    `json:"suite_name"`
 	SpecDescription string    `json:"spec_description"`
 	Status          string    `json:"status"`
 	Message         string    `json:"message"`
-	Tags            []Tag     `json:"tags" gorm:"many2many:spec_run_tags;"`
 	StartTime       time.Time `json:"start_time"`
 	EndTime         time.Time `json:"end_time"`
+	Duration        float64   `json:"duration"`
+	GitBranch       string    `json:"git_branch"`
+	GitSha          string    `json:"git_sha"`
 }
 
 type TestRunInsight struct {
@@ -46,10 +176,175 @@ type TestRunInsight struct {
 	PassRate        float32   `json:"pass_rate"`
 }
 
+// LabelPassRate is the pass rate of the spec runs carrying one value of a
+// label.
+type LabelPassRate struct {
+	Value    string  `json:"value"`
+	Passed   int     `json:"passed"`
+	Executed int     `json:"executed"`
+	PassRate float32 `json:"pass_rate"`
+}
+
+// PurgeSummary describes the test runs of a project that retention would
+// purge.
+type PurgeSummary struct {
+	TestProjectName string    `json:"test_project_name"`
+	Runs            int64     `json:"runs"`
+	OldestStartTime time.Time `json:"oldest_start_time"`
+	NewestStartTime time.Time `json:"newest_start_time"`
+}
+
+// TestRunDailyStat aggregates the purged test runs of a project branch on a
+// day, so that long-term trends survive the retention of raw runs.
+type TestRunDailyStat struct {
+	TestProjectName string    `json:"test_project_name" gorm:"primaryKey"`
+	GitBranch       string    `json:"git_branch" gorm:"primaryKey"`
+	Day             time.Time `json:"day" gorm:"primaryKey;type:date"`
+	Runs            int64     `json:"runs"`
+	SpecRuns        int64     `json:"spec_runs"`
+	Passed          int64     `json:"passed"`
+	Failed          int64     `json:"failed"`
+	Skipped         int64     `json:"skipped"`
+	Flaky           int64     `json:"flaky"`
+	DurationSeconds float64   `json:"duration_seconds"`
+}
+
+// OwnerFailures counts the failed and executed spec runs of an owner.
+type OwnerFailures struct {
+	Owner    string `json:"owner"`
+	Failed   int    `json:"failed"`
+	Executed int    `json:"executed"`
+}
+
+// FlakySpec is a spec of a project branch whose outcome changes without a
+// reason in the code. Score ranks specs from 0 (stable) to 1 (most flaky).
+type FlakySpec struct {
+	TestCaseID      *uint64         `json:"test_case_id,omitempty"`
+	SuiteName       string          `json:"suite_name"`
+	SpecDescription string          `json:"spec_description"`
+	GitBranch       string          `json:"git_branch"`
+	Score           float64         `json:"score"`
+	Executions      int             `json:"executions"`
+	Failures        int             `json:"failures"`
+	RetriedPasses   int             `json:"retried_passes"`
+	CommitFlips     int             `json:"commit_flips"`
+	Alternations    int             `json:"alternations"`
+	AlternationRate float64         `json:"alternation_rate"`
+	Evidence        []FlakyEvidence `json:"evidence"`
+}
+
+// FlakyEvidence is an execution of a flaky spec that shows its flakiness.
+// Reason is commit_flip, retried or alternation.
+type FlakyEvidence struct {
+	TestRunID uint64    `json:"test_run_id"`
+	SpecRunID uint64    `json:"spec_run_id"`
+	Status    string    `json:"status"`
+	GitSha    string    `json:"git_sha"`
+	StartTime time.Time `json:"start_time"`
+	Reason    string    `json:"reason"`
+	URL       string    `json:"url"`
+}
+
+// QuarantinedSpec is the state of a quarantined spec: its rule and the
+// executions of the spec since the rule was created.
+type QuarantinedSpec struct {
+	QuarantineRule
+	Executions    int    `json:"executions"`
+	Failures      int    `json:"failures"`
+	LastStatus    string `json:"last_status,omitempty"`
+	LastTestRunID uint64 `json:"last_test_run_id,omitempty"`
+}
+
+// FailureGroup is a group of failed spec runs whose failure messages are alike
+// once the values that vary between runs are normalized away, so that they
+// likely share a root cause. Pattern is the normalized message the group was
+// seeded with and Message the latest original message.
+type FailureGroup struct {
+	Fingerprint string             `json:"fingerprint"`
+	Pattern     string             `json:"pattern"`
+	Message     string             `json:"message"`
+	Count       int                `json:"count"`
+	TestRuns    int                `json:"test_runs"`
+	FirstSeen   time.Time          `json:"first_seen"`
+	LastSeen    time.Time          `json:"last_seen"`
+	Specs       []FailureGroupSpec `json:"specs"`
+}
+
+// FailureGroupSpec is a spec affected by a failure group, with the number of
+// its failures in the group and the latest one.
+type FailureGroupSpec struct {
+	TestCaseID      *uint64 `json:"test_case_id,omitempty"`
+	SuiteName       string  `json:"suite_name"`
+	SpecDescription string  `json:"spec_description"`
+	Count           int     `json:"count"`
+	LastTestRunID   uint64  `json:"last_test_run_id"`
+	LastSpecRunID   uint64  `json:"last_spec_run_id"`
+}
+
+// RunComparison diffs the specs of a head test run against a base test run,
+// matched by suite name and spec description, with a rollup per suite.
+type RunComparison struct {
+	Base   ComparedRun       `json:"base"`
+	Head   ComparedRun       `json:"head"`
+	Counts ComparisonCounts  `json:"counts"`
+	Suites []SuiteComparison `json:"suites"`
+}
+
+// ComparedRun identifies a test run of a comparison.
+type ComparedRun struct {
+	TestRunID       uint64    `json:"test_run_id"`
+	TestProjectName string    `json:"test_project_name"`
+	GitBranch       string    `json:"git_branch"`
+	GitSha          string    `json:"git_sha"`
+	StartTime       time.Time `json:"start_time"`
+	Status          string    `json:"status"`
+}
+
+// ComparisonCounts counts the specs of a comparison by change. Slower and
+// Faster count the significant duration changes, whatever the status change.
+type ComparisonCounts struct {
+	Added         int `json:"added"`
+	Removed       int `json:"removed"`
+	NewlyFailing  int `json:"newly_failing"`
+	NewlyPassing  int `json:"newly_passing"`
+	StatusChanged int `json:"status_changed"`
+	Unchanged     int `json:"unchanged"`
+	Slower        int `json:"slower"`
+	Faster        int `json:"faster"`
+}
+
+// SuiteComparison rolls up the spec changes of a suite. Change is added or
+// removed when the suite only ran in one of the runs. Durations are seconds.
+type SuiteComparison struct {
+	SuiteName    string           `json:"suite_name"`
+	Change       string           `json:"change,omitempty"`
+	Counts       ComparisonCounts `json:"counts"`
+	BaseDuration float64          `json:"base_duration"`
+	HeadDuration float64          `json:"head_duration"`
+	Specs        []SpecComparison `json:"specs"`
+}
+
+// SpecComparison is a spec of either run with its status and duration in
+// both. DurationChange is slower or faster when the duration changed
+// significantly. Durations are seconds.
+type SpecComparison struct {
+	SuiteName       string  `json:"suite_name"`
+	SpecDescription string  `json:"spec_description"`
+	Change          string  `json:"change"`
+	BaseStatus      string  `json:"base_status,omitempty"`
+	HeadStatus      string  `json:"head_status,omitempty"`
+	BaseSpecRunID   uint64  `json:"base_spec_run_id,omitempty"`
+	HeadSpecRunID   uint64  `json:"head_spec_run_id,omitempty"`
+	BaseDuration    float64 `json:"base_duration"`
+	HeadDuration    float64 `json:"head_duration"`
+	DurationChange  string  `json:"duration_change,omitempty"`
+}
+
 type TestSummary struct {
 	SuiteRunID           uint
 	TestProjectName      string
 	StartTime            time.Time
+	ContainerPath        string `json:",omitempty"`
 	TotalPassedSpecRuns  int64
 	TotalSkippedSpecRuns int64
 	TotalSpecRuns        int64
@@ -59,3 +354,22 @@ type Tag struct {
 	ID   uint64 `json:"id" gorm:"primaryKey"`
 	Name string `json:"name"`
 }
+
+// Attachment is a file uploaded for a test run, such as a screenshot or a
+// log. It belongs to one spec run of the test run when SpecRunID is set. The
+// content is kept in the blob store under StorageKey.
+type Attachment struct {
+	ID          uint64    `json:"id" gorm:"primaryKey"`
+	TestRunID   uint64    `json:"test_run_id"`
+	SpecRunID   *uint64   `json:"spec_run_id,omitempty"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	StorageKey  string    `json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// IsImage reports whether the attachment can be shown as an image.
+func (a Attachment) IsImage() bool {
+	return strings.HasPrefix(a.ContentType, "image/") && a.ContentType != "image/svg+xml"
+}
@@ -0,0 +1,229 @@
+// Package ownership assigns owners to spec runs from the CODEOWNERS-style
+// rules of their project.
+//
+// A rule holds glob patterns over the suite name, the spec hierarchy (the
+// container texts and the spec description joined by spaces), the file of the
+// failure and the tags of a spec run, where "*" matches any run of characters
+// and "?" a single one. A rule matches a spec run when all of its non-empty
+// patterns do; the tag pattern matches when any tag does. The last matching
+// rule wins. Spec runs only report a file when they fail, so file patterns
+// only match failed spec runs.
+//
+// Owners are evaluated when spec runs are read, so changed rules apply to
+// past runs as well.
+package ownership
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"gorm.io/gorm"
+)
+
+// Spec is what rules are matched against.
+type Spec struct {
+	Suite string
+	Spec  string
+	File  string
+	Tags  []string
+}
+
+// SpecOf returns the values of a spec run of the suite that rules match.
+func SpecOf(suiteName string, specRun models.SpecRun) Spec {
+	spec := Spec{
+		Suite: suiteName,
+		Spec:  specRun.Containers.FullText(specRun.SpecDescription),
+	}
+	if specRun.Failure != nil {
+		spec.File = specRun.Failure.File
+	}
+	for _, tag := range specRun.Tags {
+		spec.Tags = append(spec.Tags, tag.Name)
+	}
+	return spec
+}
+
+type rule struct {
+	suite, spec, file, tag *regexp.Regexp
+	owner                  string
+}
+
+// Matcher finds the owner of specs from an ordered list of rules.
+type Matcher struct {
+	rules []rule
+}
+
+// NewMatcher compiles the rules, which must be in evaluation order.
+func NewMatcher(rules []models.OwnershipRule) *Matcher {
+	m := &Matcher{rules: make([]rule, len(rules))}
+	for i, r := range rules {
+		m.rules[i] = rule{
+			suite: compile(r.Suite),
+			spec:  compile(r.Spec),
+			file:  compile(r.File),
+			tag:   compile(r.Tag),
+			owner: r.Owner,
+		}
+	}
+	return m
+}
+
+// Owner returns the owner of the last rule matching the spec, or "" when no
+// rule matches.
+func (m *Matcher) Owner(spec Spec) string {
+	if m == nil {
+		return ""
+	}
+	for i := len(m.rules) - 1; i >= 0; i-- {
+		if m.rules[i].matches(spec) {
+			return m.rules[i].owner
+		}
+	}
+	return ""
+}
+
+// MatchesFiles reports whether any rule has a file pattern.
+func (m *Matcher) MatchesFiles() bool {
+	if m == nil {
+		return false
+	}
+	for _, r := range m.rules {
+		if r.file != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesTags reports whether any rule has a tag pattern.
+func (m *Matcher) MatchesTags() bool {
+	if m == nil {
+		return false
+	}
+	for _, r := range m.rules {
+		if r.tag != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply sets the owner of every spec run of the test run.
+func (m *Matcher) Apply(testRun *models.TestRun) {
+	for i := range testRun.SuiteRuns {
+		suiteRun := &testRun.SuiteRuns[i]
+		for j := range suiteRun.SpecRuns {
+			specRun := &suiteRun.SpecRuns[j]
+			specRun.Owner = m.Owner(SpecOf(suiteRun.SuiteName, *specRun))
+		}
+	}
+}
+
+func (r rule) matches(spec Spec) bool {
+	if r.suite != nil && !r.suite.MatchString(spec.Suite) {
+		return false
+	}
+	if r.spec != nil && !r.spec.MatchString(spec.Spec) {
+		return false
+	}
+	if r.file != nil && !r.file.MatchString(spec.File) {
+		return false
+	}
+	if r.tag != nil {
+		for _, tag := range spec.Tags {
+			if r.tag.MatchString(tag) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// compile translates a glob pattern to an anchored regular expression. An
+// empty pattern matches anything and compiles to nil.
+func compile(pattern string) *regexp.Regexp {
+	if pattern == "" {
+		return nil
+	}
+	var expr strings.Builder
+	expr.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.MustCompile("(?s)" + expr.String())
+}
+
+// Load returns the matchers of the registered projects among the names, keyed
+// by project name. Projects without rules have no matcher.
+func Load(db *gorm.DB, projectNames []string) (map[string]*Matcher, error) {
+	var rows []struct {
+		models.OwnershipRule
+		ProjectName string
+	}
+	err := db.Table("ownership_rules").
+		Select("ownership_rules.*, projects.name AS project_name").
+		Joins("INNER JOIN projects ON projects.id = ownership_rules.project_id").
+		Where("projects.name IN ?", projectNames).
+		Order("ownership_rules.project_id, ownership_rules.position").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	rules := map[string][]models.OwnershipRule{}
+	for _, row := range rows {
+		rules[row.ProjectName] = append(rules[row.ProjectName], row.OwnershipRule)
+	}
+	matchers := make(map[string]*Matcher, len(rules))
+	for name, projectRules := range rules {
+		matchers[name] = NewMatcher(projectRules)
+	}
+	return matchers, nil
+}
+
+// ApplyAll sets the owners of the spec runs of the test runs from the rules
+// of their projects. Nothing is loaded when the runs have no spec runs.
+func ApplyAll(db *gorm.DB, testRuns []models.TestRun) error {
+	var names []string
+	seen := map[string]bool{}
+	for _, testRun := range testRuns {
+		if seen[testRun.TestProjectName] || !hasSpecRuns(testRun) {
+			continue
+		}
+		seen[testRun.TestProjectName] = true
+		names = append(names, testRun.TestProjectName)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	matchers, err := Load(db, names)
+	if err != nil {
+		return err
+	}
+	for i := range testRuns {
+		if matcher, ok := matchers[testRuns[i].TestProjectName]; ok {
+			matcher.Apply(&testRuns[i])
+		}
+	}
+	return nil
+}
+
+func hasSpecRuns(testRun models.TestRun) bool {
+	for _, suiteRun := range testRun.SuiteRuns {
+		if len(suiteRun.SpecRuns) > 0 {
+			return true
+		}
+	}
+	return false
+}
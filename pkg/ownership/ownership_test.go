@@ -0,0 +1,71 @@
+package ownership_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/ownership"
+)
+
+var _ = Describe("Matcher", func() {
+	matcher := ownership.NewMatcher([]models.OwnershipRule{
+		{Suite: "*", Owner: "qa"},
+		{Suite: "Checkout*", Owner: "payments"},
+		{Spec: "Cart * adds ? item", Owner: "cart"},
+		{Suite: "Checkout*", File: "*/refunds/*", Owner: "refunds"},
+		{Tag: "slow", Owner: "performance"},
+	})
+
+	It("should let the last matching rule win", func() {
+		Expect(matcher.Owner(ownership.Spec{Suite: "Search"})).To(Equal("qa"))
+		Expect(matcher.Owner(ownership.Spec{Suite: "Checkout API"})).To(Equal("payments"))
+		Expect(matcher.Owner(ownership.Spec{Suite: "Checkout API", Spec: "Cart when empty adds 1 item"})).To(Equal("cart"))
+	})
+
+	It("should require all patterns of a rule to match", func() {
+		Expect(matcher.Owner(ownership.Spec{Suite: "Search", File: "/src/refunds/api_test.go"})).To(Equal("qa"))
+		Expect(matcher.Owner(ownership.Spec{Suite: "Checkout", File: "/src/refunds/api_test.go"})).To(Equal("refunds"))
+	})
+
+	It("should match any of the tags", func() {
+		Expect(matcher.Owner(ownership.Spec{Suite: "Checkout", Tags: []string{"smoke", "slow"}})).To(Equal("performance"))
+		Expect(matcher.Owner(ownership.Spec{Suite: "Checkout", Tags: []string{"slower"}})).To(Equal("payments"))
+	})
+
+	It("should treat other characters literally", func() {
+		literal := ownership.NewMatcher([]models.OwnershipRule{{Suite: "a.b (c)", Owner: "team"}})
+		Expect(literal.Owner(ownership.Spec{Suite: "a.b (c)"})).To(Equal("team"))
+		Expect(literal.Owner(ownership.Spec{Suite: "axb (c)"})).To(BeEmpty())
+	})
+
+	It("should tell whether rules match on files and tags", func() {
+		Expect(matcher.MatchesFiles()).To(BeTrue())
+		Expect(matcher.MatchesTags()).To(BeTrue())
+
+		suites := ownership.NewMatcher([]models.OwnershipRule{{Suite: "*", Owner: "qa"}})
+		Expect(suites.MatchesFiles()).To(BeFalse())
+		Expect(suites.MatchesTags()).To(BeFalse())
+
+		var none *ownership.Matcher
+		Expect(none.MatchesFiles()).To(BeFalse())
+	})
+
+	It("should set the owners of the spec runs of a test run", func() {
+		testRun := models.TestRun{SuiteRuns: []models.SuiteRun{{
+			SuiteName: "Checkout",
+			SpecRuns: []models.SpecRun{
+				{SpecDescription: "adds 1 item", Containers: models.Containers{{Text: "Cart"}, {Text: "when empty"}}},
+				{SpecDescription: "refunds", Failure: &models.Failure{File: "/src/refunds/api_test.go"}},
+				{SpecDescription: "loads", Tags: []models.Tag{{Name: "slow"}}},
+			},
+		}}}
+
+		matcher.Apply(&testRun)
+
+		specRuns := testRun.SuiteRuns[0].SpecRuns
+		Expect(specRuns[0].Owner).To(Equal("cart"))
+		Expect(specRuns[1].Owner).To(Equal("refunds"))
+		Expect(specRuns[2].Owner).To(Equal("performance"))
+	})
+})
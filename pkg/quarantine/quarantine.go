@@ -0,0 +1,297 @@
+// Package quarantine mutes the failures of specs that are known to be broken
+// or flaky while their owners fix them.
+//
+// A rule quarantines one spec of a project until it expires. Spec runs match
+// a rule through their test case, or through their suite name and spec
+// description. Matching spec runs keep their status but are marked
+// quarantined, so that their failures do not fail their test run nor count
+// in the failure metrics of a report. Like owners, the marks are evaluated
+// when spec runs are read, from the rules active at that time.
+//
+// Rules are created by hand, or automatically for the specs whose flakiness
+// score reaches a threshold. A spec is only quarantined automatically again
+// once its previous rule ended before the analyzed window, so that lifting a
+// rule is not undone by the flakiness it was created for.
+package quarantine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/guidewire/fern-reporter/pkg/flaky"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// Sources of a rule.
+const (
+	SourceManual = "manual"
+	SourceAuto   = "auto"
+)
+
+// AutoPolicy quarantines the specs whose flakiness score is at least
+// Threshold, for Duration.
+type AutoPolicy struct {
+	Threshold float64
+	Duration  time.Duration
+}
+
+// Matcher tells the quarantined specs of a project from its active rules. A
+// nil matcher quarantines nothing.
+type Matcher struct {
+	rules []models.QuarantineRule
+}
+
+// NewMatcher returns a matcher of the rules.
+func NewMatcher(rules []models.QuarantineRule) *Matcher {
+	return &Matcher{rules: rules}
+}
+
+// Rule returns the rule quarantining a spec, or nil when none does.
+func (m *Matcher) Rule(testCaseID *uint64, suiteName, specDescription string) *models.QuarantineRule {
+	if m == nil {
+		return nil
+	}
+	for i := range m.rules {
+		if matches(m.rules[i], testCaseID, suiteName, specDescription) {
+			return &m.rules[i]
+		}
+	}
+	return nil
+}
+
+func matches(rule models.QuarantineRule, testCaseID *uint64, suiteName, specDescription string) bool {
+	if rule.TestCaseID != nil && testCaseID != nil && *rule.TestCaseID == *testCaseID {
+		return true
+	}
+	return rule.SuiteName == suiteName && rule.SpecDescription == specDescription
+}
+
+// Apply marks the spec runs of the test run matching a rule as quarantined
+// and clears the mark of the others.
+func (m *Matcher) Apply(testRun *models.TestRun) {
+	for i := range testRun.SuiteRuns {
+		suiteRun := &testRun.SuiteRuns[i]
+		for j := range suiteRun.SpecRuns {
+			specRun := &suiteRun.SpecRuns[j]
+			specRun.Quarantined = m.Rule(specRun.TestCaseID, suiteRun.SuiteName, specRun.SpecDescription) != nil
+		}
+	}
+}
+
+// Clear removes the quarantine marks of the spec runs of the test run, such
+// as those sent by a client.
+func Clear(testRun *models.TestRun) {
+	for i := range testRun.SuiteRuns {
+		suiteRun := &testRun.SuiteRuns[i]
+		for j := range suiteRun.SpecRuns {
+			suiteRun.SpecRuns[j].Quarantined = false
+		}
+	}
+}
+
+// Active returns the rules of the projects that are active at now, oldest
+// first.
+func Active(db *gorm.DB, projectNames []string, now time.Time) ([]models.QuarantineRule, error) {
+	rules := []models.QuarantineRule{}
+	err := db.Where("test_project_name IN ? AND expires_at > ?", projectNames, now).
+		Order("id").
+		Find(&rules).Error
+	return rules, err
+}
+
+// Load returns the matchers of the projects with active rules at now, keyed
+// by project name.
+func Load(db *gorm.DB, projectNames []string, now time.Time) (map[string]*Matcher, error) {
+	rules, err := Active(db, projectNames, now)
+	if err != nil {
+		return nil, err
+	}
+	byProject := map[string][]models.QuarantineRule{}
+	for _, rule := range rules {
+		byProject[rule.TestProjectName] = append(byProject[rule.TestProjectName], rule)
+	}
+	matchers := make(map[string]*Matcher, len(byProject))
+	for name, projectRules := range byProject {
+		matchers[name] = NewMatcher(projectRules)
+	}
+	return matchers, nil
+}
+
+// ApplyAll marks the quarantined spec runs of the test runs from the rules of
+// their projects active at now. Nothing is loaded when the runs have no spec
+// runs.
+func ApplyAll(db *gorm.DB, testRuns []models.TestRun, now time.Time) error {
+	var names []string
+	seen := map[string]bool{}
+	for _, testRun := range testRuns {
+		if seen[testRun.TestProjectName] || !hasSpecRuns(testRun) {
+			continue
+		}
+		seen[testRun.TestProjectName] = true
+		names = append(names, testRun.TestProjectName)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	matchers, err := Load(db, names, now)
+	if err != nil {
+		return err
+	}
+	for i := range testRuns {
+		matchers[testRuns[i].TestProjectName].Apply(&testRuns[i])
+	}
+	return nil
+}
+
+func hasSpecRuns(testRun models.TestRun) bool {
+	for _, suiteRun := range testRun.SuiteRuns {
+		if len(suiteRun.SpecRuns) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Auto quarantines the flaky specs of every project with test runs in the
+// flakiness window ending at now. It returns the rules it created.
+func Auto(db *gorm.DB, policy AutoPolicy, now time.Time) ([]models.QuarantineRule, error) {
+	var projectNames []string
+	err := db.Model(&models.TestRun{}).
+		Distinct("test_project_name").
+		Where("start_time >= ?", now.Add(-flaky.DefaultWindow)).
+		Order("test_project_name").
+		Pluck("test_project_name", &projectNames).Error
+	if err != nil {
+		return nil, err
+	}
+
+	created := []models.QuarantineRule{}
+	for _, projectName := range projectNames {
+		rules, err := AutoProject(db, projectName, policy, now)
+		if err != nil {
+			return created, err
+		}
+		created = append(created, rules...)
+	}
+	return created, nil
+}
+
+// AutoProject quarantines the specs of a project whose flakiness score over
+// the default window ending at now reaches the threshold of the policy. Specs
+// with a rule that was active in the window are skipped. It returns the rules
+// it created.
+func AutoProject(db *gorm.DB, projectName string, policy AutoPolicy, now time.Time) ([]models.QuarantineRule, error) {
+	query := flaky.Query{Project: projectName, Limit: flaky.MaxLimit}.WithDefaults(now)
+	flakySpecs, err := flaky.Detect(db, query, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var recent []models.QuarantineRule
+	if err := db.Where("test_project_name = ? AND expires_at > ?", projectName, query.Start).Find(&recent).Error; err != nil {
+		return nil, err
+	}
+	matcher := NewMatcher(recent)
+
+	created := []models.QuarantineRule{}
+	for _, flakySpec := range flakySpecs {
+		// Flaky specs come most flaky first
+		if flakySpec.Score < policy.Threshold {
+			break
+		}
+		if matcher.Rule(flakySpec.TestCaseID, flakySpec.SuiteName, flakySpec.SpecDescription) != nil {
+			continue
+		}
+		rule := models.QuarantineRule{
+			TestProjectName: projectName,
+			TestCaseID:      flakySpec.TestCaseID,
+			SuiteName:       flakySpec.SuiteName,
+			SpecDescription: flakySpec.SpecDescription,
+			Reason:          fmt.Sprintf("flakiness score %.3f on %s reached %.3f", flakySpec.Score, flakySpec.GitBranch, policy.Threshold),
+			Source:          SourceAuto,
+			Score:           flakySpec.Score,
+			CreatedAt:       now,
+			ExpiresAt:       now.Add(policy.Duration),
+		}
+		created = append(created, rule)
+		// A spec flaky on several branches is quarantined once
+		matcher.rules = append(matcher.rules, rule)
+	}
+	if len(created) == 0 {
+		return created, nil
+	}
+	if err := db.Create(&created).Error; err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// ruleMatch joins spec runs to the quarantine rules they match, like matches.
+const ruleMatch = "(quarantine_rules.test_case_id IS NOT NULL AND spec_runs.test_case_id = quarantine_rules.test_case_id) OR " +
+	"(suite_runs.suite_name = quarantine_rules.suite_name AND spec_runs.spec_description = quarantine_rules.spec_description)"
+
+// ruleExecutions are the executions of the spec of a rule since it was
+// created, as counted by Report.
+type ruleExecutions struct {
+	RuleID        uint64
+	Executions    int
+	Failures      int
+	LastStatus    string
+	LastTestRunID uint64
+}
+
+// Report returns the state of the active rules of a project at now: the
+// executions of each quarantined spec since its rule was created, counted in
+// SQL.
+func Report(db *gorm.DB, projectName string, now time.Time) ([]models.QuarantinedSpec, error) {
+	rules, err := Active(db, []string{projectName}, now)
+	if err != nil {
+		return nil, err
+	}
+	quarantinedSpecs := make([]models.QuarantinedSpec, len(rules))
+	if len(rules) == 0 {
+		return quarantinedSpecs, nil
+	}
+
+	ids := make([]uint64, len(rules))
+	for i, rule := range rules {
+		quarantinedSpecs[i].QuarantineRule = rule
+		ids[i] = rule.ID
+	}
+	latest := "ORDER BY test_runs.start_time DESC, spec_runs.id DESC"
+	var rows []ruleExecutions
+	err = db.Table("quarantine_rules").
+		Joins("INNER JOIN test_runs ON test_runs.test_project_name = quarantine_rules.test_project_name AND test_runs.start_time >= quarantine_rules.created_at").
+		Joins("INNER JOIN suite_runs ON suite_runs.test_run_id = test_runs.id").
+		Joins("INNER JOIN spec_runs ON spec_runs.suite_id = suite_runs.id AND ("+ruleMatch+")").
+		Select("quarantine_rules.id AS rule_id, COUNT(*) AS executions, "+
+			"COUNT(*) FILTER (WHERE spec_runs.status IN ('failed','errored')) AS failures, "+
+			"(array_agg(spec_runs.status "+latest+"))[1] AS last_status, "+
+			"(array_agg(test_runs.id "+latest+"))[1] AS last_test_run_id").
+		Where("quarantine_rules.id IN ?", ids).
+		Where("test_runs.start_time <= ?", now).
+		Where("test_runs.deleted_at IS NULL").
+		Where("spec_runs.status IN ?", []string{utils.StatusPassed, utils.StatusFlaky, utils.StatusFailed, utils.StatusErrored}).
+		Group("quarantine_rules.id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byRule := make(map[uint64]ruleExecutions, len(rows))
+	for _, row := range rows {
+		byRule[row.RuleID] = row
+	}
+	for i := range quarantinedSpecs {
+		quarantinedSpec := &quarantinedSpecs[i]
+		row := byRule[quarantinedSpec.ID]
+		quarantinedSpec.Executions = row.Executions
+		quarantinedSpec.Failures = row.Failures
+		quarantinedSpec.LastStatus = row.LastStatus
+		quarantinedSpec.LastTestRunID = row.LastTestRunID
+	}
+	return quarantinedSpecs, nil
+}
@@ -0,0 +1,142 @@
+package quarantine_test
+
+import (
+	"database/sql"
+	"regexp"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/quarantine"
+)
+
+var (
+	db     *sql.DB
+	gormDb *gorm.DB
+	mock   sqlmock.Sqlmock
+)
+
+var _ = BeforeEach(func() {
+	db, mock, _ = sqlmock.New()
+
+	dialector := postgres.New(postgres.Config{
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		Conn:                 db,
+		PreferSimpleProtocol: true,
+	})
+	gormDb, _ = gorm.Open(dialector, &gorm.Config{})
+})
+
+var _ = AfterEach(func() {
+	db.Close()
+})
+
+var _ = Describe("Matcher", func() {
+	testCaseID := uint64(7)
+	matcher := quarantine.NewMatcher([]models.QuarantineRule{
+		{ID: 1, TestCaseID: &testCaseID, SuiteName: "Cart", SpecDescription: "adds an item"},
+		{ID: 2, SuiteName: "Search", SpecDescription: "sorts"},
+	})
+
+	It("should match a spec through its test case, even renamed", func() {
+		otherID := uint64(7)
+		Expect(matcher.Rule(&otherID, "Cart", "adds one item").ID).To(Equal(uint64(1)))
+	})
+
+	It("should match a spec through its names", func() {
+		Expect(matcher.Rule(nil, "Search", "sorts").ID).To(Equal(uint64(2)))
+		Expect(matcher.Rule(nil, "Search", "filters")).To(BeNil())
+	})
+
+	It("should mark the quarantined spec runs of a test run and clear the others", func() {
+		testRun := models.TestRun{SuiteRuns: []models.SuiteRun{{
+			SuiteName: "Search",
+			SpecRuns: []models.SpecRun{
+				{SpecDescription: "sorts", Status: "failed"},
+				{SpecDescription: "filters", Status: "failed", Quarantined: true},
+			},
+		}}}
+
+		matcher.Apply(&testRun)
+
+		Expect(testRun.SuiteRuns[0].SpecRuns[0].Quarantined).To(BeTrue())
+		Expect(testRun.SuiteRuns[0].SpecRuns[1].Quarantined).To(BeFalse())
+	})
+
+	It("should clear the quarantine marks of every spec run", func() {
+		testRun := models.TestRun{SuiteRuns: []models.SuiteRun{{
+			SuiteName: "Search",
+			SpecRuns: []models.SpecRun{
+				{SpecDescription: "sorts", Status: "failed", Quarantined: true},
+				{SpecDescription: "filters", Status: "passed"},
+			},
+		}}}
+
+		quarantine.Clear(&testRun)
+
+		Expect(testRun.SuiteRuns[0].SpecRuns[0].Quarantined).To(BeFalse())
+		Expect(testRun.SuiteRuns[0].SpecRuns[1].Quarantined).To(BeFalse())
+	})
+
+	It("should quarantine nothing when nil", func() {
+		var none *quarantine.Matcher
+		Expect(none.Rule(nil, "Search", "sorts")).To(BeNil())
+	})
+})
+
+var _ = Describe("AutoProject", func() {
+	now := time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+	policy := quarantine.AutoPolicy{Threshold: 0.4, Duration: 7 * 24 * time.Hour}
+	executionColumns := []string{"test_case_id", "test_run_id", "spec_run_id", "suite_name", "spec_description", "status", "start_time", "git_branch", "git_sha"}
+
+	It("should quarantine the specs whose score reaches the threshold once", func() {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT spec_runs.test_case_id, test_runs.id AS test_run_id`)).
+			WillReturnRows(sqlmock.NewRows(executionColumns).
+				// Flaky on a commit: score 0.5
+				AddRow(7, 1, 10, "Cart", "adds an item", "passed", now.Add(-3*time.Hour), "main", "aaa").
+				AddRow(7, 2, 20, "Cart", "adds an item", "failed", now.Add(-2*time.Hour), "main", "aaa").
+				AddRow(7, 3, 30, "Cart", "adds an item", "passed", now.Add(-time.Hour), "main", "bbb").
+				// Retried once: score 0.1
+				AddRow(8, 1, 11, "Cart", "empties", "passed", now.Add(-3*time.Hour), "main", "aaa").
+				AddRow(8, 2, 21, "Cart", "empties", "flaky", now.Add(-2*time.Hour), "main", "aaa").
+				AddRow(8, 3, 31, "Cart", "empties", "passed", now.Add(-time.Hour), "main", "bbb"))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "quarantine_rules" WHERE test_project_name = $1 AND expires_at > $2`)).
+			WithArgs("Checkout", now.Add(-30*24*time.Hour)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "quarantine_rules" ("test_project_name","test_case_id","suite_name","spec_description","reason","source","score","created_by","created_at","expires_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING "id"`)).
+			WithArgs("Checkout", 7, "Cart", "adds an item", "flakiness score 0.500 on main reached 0.400", "auto", 0.5, "", now, now.Add(policy.Duration)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+		mock.ExpectCommit()
+
+		rules, err := quarantine.AutoProject(gormDb, "Checkout", policy, now)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+		Expect(rules).To(HaveLen(1))
+		Expect(rules[0].ID).To(Equal(uint64(4)))
+	})
+
+	It("should skip the specs with a rule in the window", func() {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT spec_runs.test_case_id, test_runs.id AS test_run_id`)).
+			WillReturnRows(sqlmock.NewRows(executionColumns).
+				AddRow(7, 1, 10, "Cart", "adds an item", "passed", now.Add(-3*time.Hour), "main", "aaa").
+				AddRow(7, 2, 20, "Cart", "adds an item", "failed", now.Add(-2*time.Hour), "main", "aaa").
+				AddRow(7, 3, 30, "Cart", "adds an item", "passed", now.Add(-time.Hour), "main", "bbb"))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "quarantine_rules"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "test_project_name", "test_case_id", "suite_name", "spec_description", "expires_at"}).
+				AddRow(2, "Checkout", 7, "Cart", "adds an item", now.Add(-24*time.Hour)))
+
+		rules, err := quarantine.AutoProject(gormDb, "Checkout", policy, now)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+		Expect(rules).To(BeEmpty())
+	})
+})
@@ -0,0 +1,173 @@
+// Package testcases gives specs a stable identity across test runs. Every
+// stored spec run is linked to the test case of its project with the same
+// fingerprint, so history and analytics do not depend on matching spec text.
+package testcases
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Fingerprint strategies decide which specs are considered the same test case.
+const (
+	// FingerprintExact identifies a test case by its suite name and spec
+	// description. It is the default.
+	FingerprintExact = "exact"
+	// FingerprintNormalized ignores case and whitespace differences.
+	FingerprintNormalized = "normalized"
+	// FingerprintDescription ignores the suite name, so specs keep their
+	// identity when they move between suites.
+	FingerprintDescription = "description"
+)
+
+var (
+	// ErrNotFound is returned when a test case to merge does not exist in the
+	// project.
+	ErrNotFound = errors.New("test case not found")
+	// ErrInvalidMerge is returned when a merge would not make sense, such as
+	// merging a test case into itself.
+	ErrInvalidMerge = errors.New("invalid merge")
+)
+
+// upsert creates missing test cases and returns existing ones, refreshing
+// their names so they show how the spec was last reported.
+var upsert = clause.OnConflict{
+	Columns:   []clause.Column{{Name: "test_project_name"}, {Name: "fingerprint"}},
+	DoUpdates: clause.AssignmentColumns([]string{"suite_name", "spec_description"}),
+}
+
+// Fingerprint returns the fingerprint of a spec under a strategy. Unknown
+// strategies fall back to FingerprintExact. The spec description is the full
+// text of the spec, including the texts of its containers.
+func Fingerprint(strategy, suiteName, specDescription string) string {
+	switch strategy {
+	case FingerprintNormalized:
+		suiteName, specDescription = normalize(suiteName), normalize(specDescription)
+	case FingerprintDescription:
+		suiteName = ""
+	}
+	sum := sha256.Sum256([]byte(suiteName + "\x1f" + specDescription))
+	return hex.EncodeToString(sum[:])
+}
+
+func normalize(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
+
+type key struct {
+	project     string
+	fingerprint string
+}
+
+// Resolve links every spec run of the test runs to its test case, creating
+// test cases that do not exist yet with a single statement. It is meant to be
+// called in the transaction that stores the spec runs.
+func Resolve(tx *gorm.DB, strategy string, testRuns ...*models.TestRun) error {
+	var testCases []*models.TestCase
+	byKey := map[key]*models.TestCase{}
+	for _, testRun := range testRuns {
+		for _, suiteRun := range testRun.SuiteRuns {
+			for _, specRun := range suiteRun.SpecRuns {
+				fullText := specRun.Containers.FullText(specRun.SpecDescription)
+				k := key{testRun.TestProjectName, Fingerprint(strategy, suiteRun.SuiteName, fullText)}
+				if _, ok := byKey[k]; ok {
+					continue
+				}
+				testCase := &models.TestCase{
+					TestProjectName: k.project,
+					SuiteName:       suiteRun.SuiteName,
+					SpecDescription: fullText,
+					Fingerprint:     k.fingerprint,
+				}
+				byKey[k] = testCase
+				testCases = append(testCases, testCase)
+			}
+		}
+	}
+	if len(testCases) == 0 {
+		return nil
+	}
+
+	returning := clause.Returning{Columns: []clause.Column{{Name: "id"}, {Name: "merged_into_id"}}}
+	if err := tx.Clauses(upsert, returning).Create(&testCases).Error; err != nil {
+		return err
+	}
+
+	for _, testRun := range testRuns {
+		for i := range testRun.SuiteRuns {
+			suiteRun := &testRun.SuiteRuns[i]
+			for j := range suiteRun.SpecRuns {
+				specRun := &suiteRun.SpecRuns[j]
+				fullText := specRun.Containers.FullText(specRun.SpecDescription)
+				testCase := byKey[key{testRun.TestProjectName, Fingerprint(strategy, suiteRun.SuiteName, fullText)}]
+				id := testCase.ID
+				if testCase.MergedIntoID != nil {
+					id = *testCase.MergedIntoID
+				}
+				specRun.TestCaseID = &id
+			}
+		}
+	}
+	return nil
+}
+
+// Merge folds the source test cases into the target, typically after a spec
+// was renamed. The history and the quarantine rules of the sources move to
+// the target, and spec runs later reported under a source fingerprint are
+// linked to the target. It returns the number of spec runs that moved.
+func Merge(db *gorm.DB, projectName string, targetID uint64, sourceIDs []uint64) (int64, error) {
+	if len(sourceIDs) == 0 {
+		return 0, fmt.Errorf("%w: no source test cases given", ErrInvalidMerge)
+	}
+	for _, sourceID := range sourceIDs {
+		if sourceID == targetID {
+			return 0, fmt.Errorf("%w: a test case cannot be merged into itself", ErrInvalidMerge)
+		}
+	}
+
+	var moved int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var target models.TestCase
+		if err := tx.Where("id = ? AND test_project_name = ?", targetID, projectName).First(&target).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotFound
+			}
+			return err
+		}
+		if target.MergedIntoID != nil {
+			return fmt.Errorf("%w: the target test case was merged into another one", ErrInvalidMerge)
+		}
+
+		var found int64
+		if err := tx.Model(&models.TestCase{}).Where("id IN ? AND test_project_name = ?", sourceIDs, projectName).Count(&found).Error; err != nil {
+			return err
+		}
+		if found != int64(len(sourceIDs)) {
+			return ErrNotFound
+		}
+
+		result := tx.Model(&models.SpecRun{}).Where("test_case_id IN ?", sourceIDs).Update("test_case_id", targetID)
+		if result.Error != nil {
+			return result.Error
+		}
+		moved = result.RowsAffected
+
+		// Quarantine rules keep quarantining the spec under its target
+		if err := tx.Model(&models.QuarantineRule{}).Where("test_case_id IN ?", sourceIDs).Update("test_case_id", targetID).Error; err != nil {
+			return err
+		}
+
+		// Test cases merged into a source earlier follow it to the target
+		return tx.Model(&models.TestCase{}).
+			Where("id IN ? OR merged_into_id IN ?", sourceIDs, sourceIDs).
+			Update("merged_into_id", targetID).Error
+	})
+	return moved, err
+}
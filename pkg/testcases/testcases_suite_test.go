@@ -0,0 +1,13 @@
+package testcases_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestTestCases(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Test Cases Suite")
+}
@@ -0,0 +1,40 @@
+package testcases_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/pkg/testcases"
+)
+
+var _ = Describe("Fingerprint", func() {
+	It("should tell specs of different suites apart by default", func() {
+		Expect(testcases.Fingerprint(testcases.FingerprintExact, "Cart", "adds an item")).
+			NotTo(Equal(testcases.Fingerprint(testcases.FingerprintExact, "Basket", "adds an item")))
+		Expect(testcases.Fingerprint("", "Cart", "adds an item")).
+			To(Equal(testcases.Fingerprint(testcases.FingerprintExact, "Cart", "adds an item")))
+	})
+
+	It("should not let the suite name and description run into each other", func() {
+		Expect(testcases.Fingerprint(testcases.FingerprintExact, "Cart a", "dds")).
+			NotTo(Equal(testcases.Fingerprint(testcases.FingerprintExact, "Cart", "adds")))
+	})
+
+	It("should ignore case and whitespace when normalized", func() {
+		Expect(testcases.Fingerprint(testcases.FingerprintNormalized, "Cart", "Adds  an item ")).
+			To(Equal(testcases.Fingerprint(testcases.FingerprintNormalized, "cart", "adds an item")))
+		Expect(testcases.Fingerprint(testcases.FingerprintExact, "Cart", "Adds  an item ")).
+			NotTo(Equal(testcases.Fingerprint(testcases.FingerprintExact, "cart", "adds an item")))
+	})
+
+	It("should ignore the suite name when keyed by description", func() {
+		Expect(testcases.Fingerprint(testcases.FingerprintDescription, "Cart", "adds an item")).
+			To(Equal(testcases.Fingerprint(testcases.FingerprintDescription, "Basket", "adds an item")))
+	})
+
+	It("should match the fingerprint used to backfill existing spec runs", func() {
+		// encode(sha256(convert_to('Cart' || chr(31) || 'adds an item', 'UTF8')), 'hex')
+		Expect(testcases.Fingerprint(testcases.FingerprintExact, "Cart", "adds an item")).
+			To(Equal("24a85456a06fb2076d4beaaa502f638c2f54e35c892cb3a75363646ad27e5007"))
+	})
+})
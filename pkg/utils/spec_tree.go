@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"strconv"
+
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+// SpecTreeNode is one row of the container tree of a suite run. It holds
+// either a container, with the statuses of the spec runs below it, or a spec
+// run. Path identifies the node within the suite run and starts with the path
+// of its parent, so that a subtree can be collapsed by path prefix.
+type SpecTreeNode struct {
+	Path      string
+	Depth     int
+	Container *models.Container
+	SpecRun   *models.SpecRun
+	Total     int
+	Passed    int
+	Failed    int
+}
+
+type specTreeItem struct {
+	node       SpecTreeNode
+	children   []*specTreeItem
+	containers map[string]*specTreeItem
+}
+
+// SpecTree arranges the spec runs of a suite run under their containers and
+// returns the tree depth first. Containers are merged by text and keep the
+// order in which they were first seen, as do the spec runs within them.
+func SpecTree(suiteRun models.SuiteRun) []SpecTreeNode {
+	root := &specTreeItem{containers: map[string]*specTreeItem{}}
+	for i := range suiteRun.SpecRuns {
+		specRun := &suiteRun.SpecRuns[i]
+		parent := root
+		for j := range specRun.Containers {
+			container := &specRun.Containers[j]
+			child, ok := parent.containers[container.Text]
+			if !ok {
+				child = &specTreeItem{
+					node:       SpecTreeNode{Container: container},
+					containers: map[string]*specTreeItem{},
+				}
+				parent.containers[container.Text] = child
+				parent.children = append(parent.children, child)
+			}
+			parent = child
+			parent.count(specRun.Status)
+		}
+		parent.children = append(parent.children, &specTreeItem{node: SpecTreeNode{SpecRun: specRun}})
+	}
+
+	var nodes []SpecTreeNode
+	var walk func(item *specTreeItem, path string, depth int)
+	walk = func(item *specTreeItem, path string, depth int) {
+		for i, child := range item.children {
+			child.node.Path = path + "." + strconv.Itoa(i)
+			child.node.Depth = depth
+			nodes = append(nodes, child.node)
+			walk(child, child.node.Path, depth+1)
+		}
+	}
+	walk(root, "s"+strconv.FormatUint(suiteRun.ID, 10), 1)
+	return nodes
+}
+
+func (item *specTreeItem) count(status string) {
+	item.node.Total++
+	if IsPassedStatus(status) {
+		item.node.Passed++
+	} else if IsFailedStatus(status) {
+		item.node.Failed++
+	}
+}
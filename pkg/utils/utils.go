@@ -1,10 +1,11 @@
 package utils
 
 import (
-	"github.com/guidewire/fern-reporter/pkg/models"
-	"time"
 	"encoding/base64"
 	"fmt"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"strings"
+	"time"
 )
 
 const (
@@ -12,6 +13,20 @@ const (
 	StatusSkipped    = "skipped"
 	StatusPassed     = "passed"
 	StatusFailed     = "failed"
+	StatusPending    = "pending"
+	StatusFlaky      = "flaky"
+	StatusErrored    = "errored"
+
+	// Test run lifecycle statuses. A finished run is either passed or failed.
+	RunStatusInProgress = "in_progress"
+	RunStatusAborted    = "aborted"
+
+	// Kinds of spec run failures.
+	FailureKindAssertion   = "assertion"
+	FailureKindPanic       = "panic"
+	FailureKindTimeout     = "timeout"
+	FailureKindInterrupted = "interrupted"
+	FailureKindSetup       = "setup"
 )
 
 func CalculateDuration(start, end time.Time) string {
@@ -23,19 +38,25 @@ func FormatDate(t time.Time) string {
 	return t.Format(DateLayoutFormat)
 }
 
-// Common function to calculate test metrics
-func CalculateTestMetrics(testRuns []models.TestRun) (totalTests, executedTests, passedTests, failedTests int) {
+// Common function to calculate test metrics. Flaky specs eventually passed
+// but are counted apart from passed and failed ones. Failures of quarantined
+// specs are executed but not counted as failed.
+func CalculateTestMetrics(testRuns []models.TestRun) (totalTests, executedTests, passedTests, failedTests, flakyTests int) {
 	for _, testRun := range testRuns {
 		for _, suiteRun := range testRun.SuiteRuns {
 			for _, specRun := range suiteRun.SpecRuns {
 				totalTests++ // Count each spec run
-				if specRun.Status != StatusSkipped {
-					executedTests++ // Count only executed spec runs
-					if specRun.Status == StatusPassed {
-						passedTests++ // Count passed spec runs
-					} else if specRun.Status == StatusFailed {
-						failedTests++ // Count failed spec runs
-					}
+				if !IsExecutedStatus(specRun.Status) {
+					continue
+				}
+				executedTests++ // Count only executed spec runs
+				switch {
+				case specRun.Status == StatusFlaky:
+					flakyTests++ // Count spec runs that passed on a retry
+				case IsPassedStatus(specRun.Status):
+					passedTests++ // Count passed spec runs
+				case IsFailedStatus(specRun.Status) && !specRun.Quarantined:
+					failedTests++ // Count failed spec runs
 				}
 			}
 		}
@@ -43,6 +64,52 @@ func CalculateTestMetrics(testRuns []models.TestRun) (totalTests, executedTests,
 	return
 }
 
+// SpecOutcome derives the status of a retried spec from its attempts. A spec
+// that passed after failing is flaky; otherwise the last attempt decides.
+func SpecOutcome(attempts models.SpecAttempts) string {
+	if len(attempts) == 0 {
+		return ""
+	}
+	final := attempts[len(attempts)-1].Status
+	if IsPassedStatus(final) {
+		for _, attempt := range attempts[:len(attempts)-1] {
+			if IsFailedStatus(attempt.Status) || attempt.Status == StatusFlaky {
+				return StatusFlaky
+			}
+		}
+	}
+	return final
+}
+
+// TestRunOutcome derives the final status of a test run from its spec runs.
+// Quarantined spec runs do not fail the run.
+func TestRunOutcome(testRun models.TestRun) string {
+	for _, suiteRun := range testRun.SuiteRuns {
+		for _, specRun := range suiteRun.SpecRuns {
+			if IsFailedStatus(specRun.Status) && !specRun.Quarantined {
+				return StatusFailed
+			}
+		}
+	}
+	return StatusPassed
+}
+
+// IsExecutedStatus reports whether a spec with the given status actually ran
+func IsExecutedStatus(status string) bool {
+	return status != StatusSkipped && status != StatusPending
+}
+
+// IsPassedStatus reports whether a spec with the given status eventually passed
+func IsPassedStatus(status string) bool {
+	return status == StatusPassed || status == StatusFlaky
+}
+
+// IsFailedStatus reports whether a spec with the given status failed, either
+// on an assertion or with an unexpected error
+func IsFailedStatus(status string) bool {
+	return status == StatusFailed || status == StatusErrored
+}
+
 func EncodeCursor(offset int) string {
 	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("cursor%d", offset)))
 }
@@ -58,3 +125,22 @@ func DecodeCursor(cursor *string) int {
 	}
 	return offset
 }
+
+// Slugify derives a URL friendly identifier from a name: lower case letters
+// and digits, with every other run of characters replaced by a single dash.
+func Slugify(name string) string {
+	var slug strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			if dash && slug.Len() > 0 {
+				slug.WriteByte('-')
+			}
+			slug.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return slug.String()
+}
@@ -40,11 +40,12 @@ var _ = Describe("Utils Test", func() {
 
 		Context("when there are no test runs", func() {
 			It("should return zeros for all metrics", func() {
-				total, executed, passed, failed := utils.CalculateTestMetrics(testRuns)
+				total, executed, passed, failed, flaky := utils.CalculateTestMetrics(testRuns)
 				Expect(total).To(Equal(0))
 				Expect(executed).To(Equal(0))
 				Expect(passed).To(Equal(0))
 				Expect(failed).To(Equal(0))
+				Expect(flaky).To(Equal(0))
 			})
 		})
 
@@ -67,11 +68,12 @@ var _ = Describe("Utils Test", func() {
 				}
 			})
 			It("should correctly count total, executed, passed, and failed tests", func() {
-				total, executed, passed, failed := utils.CalculateTestMetrics(testRuns)
+				total, executed, passed, failed, flaky := utils.CalculateTestMetrics(testRuns)
 				Expect(total).To(Equal(3))
 				Expect(executed).To(Equal(2)) // Skipped is not executed
 				Expect(passed).To(Equal(1))
 				Expect(failed).To(Equal(1))
+				Expect(flaky).To(Equal(0))
 			})
 		})
 
@@ -107,11 +109,69 @@ var _ = Describe("Utils Test", func() {
 				}
 			})
 			It("should correctly aggregate counts across multiple test runs", func() {
-				total, executed, passed, failed := utils.CalculateTestMetrics(testRuns)
+				total, executed, passed, failed, flaky := utils.CalculateTestMetrics(testRuns)
 				Expect(total).To(Equal(6))    // Total spec runs
 				Expect(executed).To(Equal(5)) // Skipped is not executed
 				Expect(passed).To(Equal(3))
 				Expect(failed).To(Equal(2))
+				Expect(flaky).To(Equal(0))
+			})
+		})
+
+		Context("when there are flaky specs", func() {
+			BeforeEach(func() {
+				testRuns = []models.TestRun{
+					{
+						ID: 1,
+						SuiteRuns: []models.SuiteRun{
+							{
+								ID: 1,
+								SpecRuns: []models.SpecRun{
+									{Status: "passed"},
+									{Status: "flaky"},
+									{Status: "flaky"},
+									{Status: "errored"},
+								},
+							},
+						},
+					},
+				}
+			})
+			It("should count flaky tests apart from passed and failed tests", func() {
+				total, executed, passed, failed, flaky := utils.CalculateTestMetrics(testRuns)
+				Expect(total).To(Equal(4))
+				Expect(executed).To(Equal(4))
+				Expect(passed).To(Equal(1))
+				Expect(failed).To(Equal(1))
+				Expect(flaky).To(Equal(2))
+			})
+		})
+
+		Context("when there are quarantined specs", func() {
+			BeforeEach(func() {
+				testRuns = []models.TestRun{
+					{
+						ID: 1,
+						SuiteRuns: []models.SuiteRun{
+							{
+								ID: 1,
+								SpecRuns: []models.SpecRun{
+									{Status: "passed", Quarantined: true},
+									{Status: "failed", Quarantined: true},
+									{Status: "failed"},
+								},
+							},
+						},
+					},
+				}
+			})
+			It("should not count their failures", func() {
+				total, executed, passed, failed, flaky := utils.CalculateTestMetrics(testRuns)
+				Expect(total).To(Equal(3))
+				Expect(executed).To(Equal(3))
+				Expect(passed).To(Equal(1))
+				Expect(failed).To(Equal(1))
+				Expect(flaky).To(Equal(0))
 			})
 		})
 
@@ -133,15 +193,51 @@ var _ = Describe("Utils Test", func() {
 				}
 			})
 			It("should count total tests but not executed, passed, or failed tests", func() {
-				total, executed, passed, failed := utils.CalculateTestMetrics(testRuns)
+				total, executed, passed, failed, flaky := utils.CalculateTestMetrics(testRuns)
 				Expect(total).To(Equal(2))
 				Expect(executed).To(Equal(0))
 				Expect(passed).To(Equal(0))
 				Expect(failed).To(Equal(0))
+				Expect(flaky).To(Equal(0))
 			})
 		})
 	})
 
+	Describe("SpecOutcome", func() {
+		attempts := func(statuses ...string) models.SpecAttempts {
+			var attempts models.SpecAttempts
+			for _, status := range statuses {
+				attempts = append(attempts, models.SpecAttempt{Status: status})
+			}
+			return attempts
+		}
+
+		It("should call a spec that passed after failing flaky", func() {
+			Expect(utils.SpecOutcome(attempts("failed", "errored", "passed"))).To(Equal("flaky"))
+		})
+
+		It("should report the last attempt otherwise", func() {
+			Expect(utils.SpecOutcome(attempts("passed", "passed"))).To(Equal("passed"))
+			Expect(utils.SpecOutcome(attempts("passed", "failed"))).To(Equal("failed"))
+			Expect(utils.SpecOutcome(attempts("failed", "failed"))).To(Equal("failed"))
+			Expect(utils.SpecOutcome(nil)).To(BeEmpty())
+		})
+	})
+
+	Describe("TestRunOutcome", func() {
+		testRun := func(specRuns ...models.SpecRun) models.TestRun {
+			return models.TestRun{SuiteRuns: []models.SuiteRun{{SpecRuns: specRuns}}}
+		}
+
+		It("should fail a run with a failed spec", func() {
+			Expect(utils.TestRunOutcome(testRun(models.SpecRun{Status: "passed"}, models.SpecRun{Status: "errored"}))).To(Equal("failed"))
+		})
+
+		It("should not fail a run on quarantined specs", func() {
+			Expect(utils.TestRunOutcome(testRun(models.SpecRun{Status: "passed"}, models.SpecRun{Status: "failed", Quarantined: true}))).To(Equal("passed"))
+		})
+	})
+
 	Describe("EncodeCursor", func() {
 		Context("when called with a positive offset", func() {
 			It("should return the correct base64-encoded string", func() {
@@ -215,4 +311,45 @@ var _ = Describe("Utils Test", func() {
 		})
 	})
 
+	Describe("SpecTree", func() {
+		It("should merge containers by text and keep the order they were first seen in", func() {
+			suiteRun := models.SuiteRun{
+				ID: 4,
+				SpecRuns: []models.SpecRun{
+					{SpecDescription: "has no items", Status: "passed", Containers: models.Containers{{Text: "Cart"}, {Text: "when empty"}}},
+					{SpecDescription: "loads", Status: "passed"},
+					{SpecDescription: "adds an item", Status: "failed", Containers: models.Containers{{Text: "Cart"}}},
+					{SpecDescription: "cannot check out", Status: "skipped", Containers: models.Containers{{Text: "Cart"}, {Text: "when empty"}}},
+				},
+			}
+
+			nodes := utils.SpecTree(suiteRun)
+
+			var rows []string
+			for _, node := range nodes {
+				if node.Container != nil {
+					rows = append(rows, fmt.Sprintf("%s %d %s %d/%d/%d", node.Path, node.Depth, node.Container.Text, node.Passed, node.Failed, node.Total))
+				} else {
+					rows = append(rows, fmt.Sprintf("%s %d %s", node.Path, node.Depth, node.SpecRun.SpecDescription))
+				}
+			}
+			Expect(rows).To(Equal([]string{
+				"s4.0 1 Cart 1/1/3",
+				"s4.0.0 2 when empty 1/0/2",
+				"s4.0.0.0 3 has no items",
+				"s4.0.0.1 3 cannot check out",
+				"s4.0.1 2 adds an item",
+				"s4.1 1 loads",
+			}))
+		})
+	})
+
+	Describe("Slugify", func() {
+		It("should keep letters and digits and join the rest with single dashes", func() {
+			Expect(utils.Slugify("Checkout Service")).To(Equal("checkout-service"))
+			Expect(utils.Slugify("  fern/reporter__v2 ")).To(Equal("fern-reporter-v2"))
+			Expect(utils.Slugify("Zahlungsverkehr (EU)!")).To(Equal("zahlungsverkehr-eu"))
+			Expect(utils.Slugify("ÄÖÜ")).To(BeEmpty())
+		})
+	})
 })
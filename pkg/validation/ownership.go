@@ -0,0 +1,37 @@
+package validation
+
+import (
+	"fmt"
+
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+// maxOwnershipRules bounds the ownership rules of a project, which are
+// evaluated for every spec run read.
+const maxOwnershipRules = 1000
+
+// ValidateOwnershipRules checks the ownership rules of a project. Every rule
+// needs an owner and at least one pattern.
+func ValidateOwnershipRules(rules []models.OwnershipRule) error {
+	v := validator{limits: DefaultLimits}
+	if len(rules) > maxOwnershipRules {
+		v.add("", fmt.Sprintf("must not contain more than %d rules", maxOwnershipRules))
+	}
+	for i, rule := range rules {
+		path := fmt.Sprintf("[%d]", i)
+		v.required(join(path, "owner"), rule.Owner)
+		if rule.Suite == "" && rule.Spec == "" && rule.File == "" && rule.Tag == "" {
+			v.add(path, "must have a suite, spec, file or tag pattern")
+		}
+		for _, field := range []struct{ name, value string }{
+			{"owner", rule.Owner},
+			{"suite", rule.Suite},
+			{"spec", rule.Spec},
+			{"file", rule.File},
+			{"tag", rule.Tag},
+		} {
+			v.length(join(path, field.name), field.value, v.limits.MaxNameLength)
+		}
+	}
+	return v.result()
+}
@@ -0,0 +1,49 @@
+package validation
+
+import (
+	"regexp"
+
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// ValidateProject checks a project before it is registered or updated. A
+// missing slug is derived from the name and a missing display name defaults
+// to the name.
+func ValidateProject(project *models.Project) error {
+	v := validator{limits: DefaultLimits}
+	v.required("name", project.Name)
+	v.length("name", project.Name, v.limits.MaxNameLength)
+
+	if project.Slug == "" {
+		project.Slug = utils.Slugify(project.Name)
+	}
+	if project.Slug == "" {
+		v.add("slug", "is required when the name has no letters or digits")
+	} else if !slugPattern.MatchString(project.Slug) {
+		v.add("slug", "must consist of lower case letters and digits separated by single dashes")
+	}
+	v.length("slug", project.Slug, v.limits.MaxNameLength)
+
+	if project.DisplayName == "" {
+		project.DisplayName = project.Name
+	}
+	for _, field := range []struct{ path, value string }{
+		{"display_name", project.DisplayName},
+		{"team", project.Team},
+		{"default_branch", project.DefaultBranch},
+		{"report_header", project.ReportHeader},
+	} {
+		v.length(field.path, field.value, v.limits.MaxNameLength)
+	}
+	v.length("description", project.Description, v.limits.MaxDescriptionLength)
+	if project.RetentionDays < 0 {
+		v.add("retention_days", "must not be negative")
+	}
+	if project.RetentionRunsPerBranch < 0 {
+		v.add("retention_runs_per_branch", "must not be negative")
+	}
+	return v.result()
+}
@@ -0,0 +1,24 @@
+package validation
+
+import (
+	"time"
+
+	"github.com/guidewire/fern-reporter/pkg/models"
+)
+
+// ValidateQuarantineRule checks a quarantine rule before it is stored at now.
+// Every rule needs the spec it quarantines, a reason and an expiry in the
+// future.
+func ValidateQuarantineRule(rule *models.QuarantineRule, now time.Time) error {
+	v := validator{limits: DefaultLimits}
+	v.required("suite_name", rule.SuiteName)
+	v.length("suite_name", rule.SuiteName, v.limits.MaxNameLength)
+	v.required("spec_description", rule.SpecDescription)
+	v.length("spec_description", rule.SpecDescription, v.limits.MaxDescriptionLength)
+	v.required("reason", rule.Reason)
+	v.length("reason", rule.Reason, v.limits.MaxDescriptionLength)
+	if !rule.ExpiresAt.After(now) {
+		v.add("expires_at", "must be in the future")
+	}
+	return v.result()
+}
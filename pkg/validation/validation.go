@@ -0,0 +1,354 @@
+// Package validation checks ingested test runs, registered projects, their
+// ownership rules and quarantine rules before they are stored. Validation
+// also normalizes the spec statuses reported by the various test frameworks
+// to the statuses known by Fern, derives the outcome of retried spec runs
+// from their attempts, and fills in the message of spec runs that only
+// report a structured failure.
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	labelsel "github.com/guidewire/fern-reporter/pkg/labels"
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/utils"
+)
+
+// Limits bounds the size of an ingested test run.
+type Limits struct {
+	MaxNameLength        int
+	MaxDescriptionLength int
+	MaxMessageLength     int
+	MaxSuiteRuns         int
+	MaxSpecRuns          int
+	MaxTags              int
+	MaxLabels            int
+	MaxContainers        int
+	MaxOutputLength      int
+	MaxAttempts          int
+}
+
+// DefaultLimits are applied to every ingested test run.
+var DefaultLimits = Limits{
+	MaxNameLength:        1024,
+	MaxDescriptionLength: 8192,
+	MaxMessageLength:     1 << 20,
+	MaxSuiteRuns:         10000,
+	MaxSpecRuns:          100000,
+	MaxTags:              100,
+	MaxLabels:            100,
+	MaxContainers:        100,
+	MaxOutputLength:      4 << 20,
+	MaxAttempts:          100,
+}
+
+// failureKinds are the known kinds of spec run failures.
+var failureKinds = []string{
+	utils.FailureKindAssertion,
+	utils.FailureKindPanic,
+	utils.FailureKindTimeout,
+	utils.FailureKindInterrupted,
+	utils.FailureKindSetup,
+}
+
+// statusAliases maps the lower case statuses used by common test frameworks
+// to Fern statuses.
+var statusAliases = map[string]string{
+	"passed":    utils.StatusPassed,
+	"pass":      utils.StatusPassed,
+	"ok":        utils.StatusPassed,
+	"success":   utils.StatusPassed,
+	"succeeded": utils.StatusPassed,
+	"failed":    utils.StatusFailed,
+	"fail":      utils.StatusFailed,
+	"failure":   utils.StatusFailed,
+	"skipped":   utils.StatusSkipped,
+	"skip":      utils.StatusSkipped,
+	"ignored":   utils.StatusSkipped,
+	"disabled":  utils.StatusSkipped,
+	"pending":   utils.StatusPending,
+	"todo":      utils.StatusPending,
+	"flaky":     utils.StatusFlaky,
+	"errored":   utils.StatusErrored,
+	"error":     utils.StatusErrored,
+	"broken":    utils.StatusErrored,
+	"panicked":  utils.StatusErrored,
+}
+
+// FieldError describes one invalid field by its JSON path.
+type FieldError struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// Errors is the list of problems found in a test run.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldError := range e {
+		messages[i] = fieldError.Path + ": " + fieldError.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// NormalizeStatus maps a reported spec status to a Fern status. The second
+// result is false when the status is unknown.
+func NormalizeStatus(status string) (string, bool) {
+	normalized, ok := statusAliases[strings.ToLower(strings.TrimSpace(status))]
+	return normalized, ok
+}
+
+// ValidateTestRun checks a test run against DefaultLimits and normalizes its
+// spec statuses in place. It returns nil when the run is valid.
+func ValidateTestRun(testRun *models.TestRun) error {
+	return DefaultLimits.ValidateTestRun(testRun)
+}
+
+// ValidateTestRun checks a test run against the limits and normalizes its spec
+// statuses in place. It returns nil when the run is valid.
+func (l Limits) ValidateTestRun(testRun *models.TestRun) error {
+	v := validator{limits: l}
+	v.required("test_project_name", testRun.TestProjectName)
+	v.length("test_project_name", testRun.TestProjectName, l.MaxNameLength)
+	v.timeOrder("", testRun.StartTime, testRun.EndTime)
+	for _, field := range []struct{ path, value string }{
+		{"git_sha", testRun.GitSha},
+		{"git_branch", testRun.GitBranch},
+		{"git_repo_url", testRun.GitRepoURL},
+		{"ci_provider", testRun.CIProvider},
+		{"build_number", testRun.BuildNumber},
+		{"build_url", testRun.BuildURL},
+		{"triggered_by", testRun.TriggeredBy},
+		{"environment", testRun.Environment},
+	} {
+		v.length(field.path, field.value, l.MaxNameLength)
+	}
+	if testRun.PullRequestNumber < 0 {
+		v.add("pull_request_number", "must not be negative")
+	}
+	v.labels("labels", testRun.Labels)
+
+	if len(testRun.SuiteRuns) > l.MaxSuiteRuns {
+		v.add("suite_runs", fmt.Sprintf("must not contain more than %d suite runs", l.MaxSuiteRuns))
+	}
+	for i := range testRun.SuiteRuns {
+		v.suiteRun(fmt.Sprintf("suite_runs[%d]", i), &testRun.SuiteRuns[i])
+	}
+	return v.result()
+}
+
+// ValidateSuiteRun checks a suite run appended to an in-progress test run.
+func ValidateSuiteRun(suiteRun *models.SuiteRun) error {
+	v := validator{limits: DefaultLimits}
+	v.suiteRun("", suiteRun)
+	return v.result()
+}
+
+// ValidateSpecRuns checks spec runs appended to an in-progress test run.
+func ValidateSpecRuns(specRuns []models.SpecRun) error {
+	v := validator{limits: DefaultLimits}
+	if len(specRuns) > v.limits.MaxSpecRuns {
+		v.add("", fmt.Sprintf("must not contain more than %d spec runs", v.limits.MaxSpecRuns))
+	}
+	for i := range specRuns {
+		v.specRun(fmt.Sprintf("[%d]", i), &specRuns[i])
+	}
+	return v.result()
+}
+
+type validator struct {
+	limits   Limits
+	specRuns int
+	errors   Errors
+}
+
+func (v *validator) suiteRun(path string, suiteRun *models.SuiteRun) {
+	v.required(join(path, "suite_name"), suiteRun.SuiteName)
+	v.length(join(path, "suite_name"), suiteRun.SuiteName, v.limits.MaxNameLength)
+	v.timeOrder(path, suiteRun.StartTime, suiteRun.EndTime)
+	v.labels(join(path, "labels"), suiteRun.Labels)
+
+	for j := range suiteRun.SpecRuns {
+		v.specRuns++
+		if v.specRuns == v.limits.MaxSpecRuns+1 {
+			v.add(join(path, "spec_runs"), fmt.Sprintf("a test run must not contain more than %d spec runs", v.limits.MaxSpecRuns))
+		}
+		v.specRun(join(path, fmt.Sprintf("spec_runs[%d]", j)), &suiteRun.SpecRuns[j])
+	}
+}
+
+func (v *validator) specRun(path string, specRun *models.SpecRun) {
+	v.required(join(path, "spec_description"), specRun.SpecDescription)
+	v.length(join(path, "spec_description"), specRun.SpecDescription, v.limits.MaxDescriptionLength)
+	v.length(join(path, "message"), specRun.Message, v.limits.MaxMessageLength)
+	v.timeOrder(path, specRun.StartTime, specRun.EndTime)
+
+	if len(specRun.Attempts) > 0 {
+		v.attempts(join(path, "attempts"), specRun)
+	} else {
+		v.status(join(path, "status"), &specRun.Status)
+	}
+
+	if len(specRun.Tags) > v.limits.MaxTags {
+		v.add(join(path, "tags"), fmt.Sprintf("must not contain more than %d tags", v.limits.MaxTags))
+	}
+	for k, tag := range specRun.Tags {
+		tagPath := join(path, fmt.Sprintf("tags[%d].name", k))
+		v.required(tagPath, tag.Name)
+		v.length(tagPath, tag.Name, v.limits.MaxNameLength)
+	}
+	v.labels(join(path, "labels"), specRun.Labels)
+
+	if specRun.Failure != nil {
+		v.failure(join(path, "failure"), specRun.Failure)
+		if specRun.Message == "" {
+			specRun.Message = specRun.Failure.Message
+		}
+	}
+
+	if len(specRun.Containers) > v.limits.MaxContainers {
+		v.add(join(path, "containers"), fmt.Sprintf("must not be nested more than %d containers deep", v.limits.MaxContainers))
+	}
+	for k, container := range specRun.Containers {
+		containerPath := join(path, fmt.Sprintf("containers[%d]", k))
+		v.required(join(containerPath, "text"), container.Text)
+		v.length(join(containerPath, "text"), container.Text, v.limits.MaxDescriptionLength)
+		if len(container.Labels) > v.limits.MaxTags {
+			v.add(join(containerPath, "labels"), fmt.Sprintf("must not contain more than %d labels", v.limits.MaxTags))
+		}
+		for l, label := range container.Labels {
+			v.length(join(containerPath, fmt.Sprintf("labels[%d]", l)), label, v.limits.MaxNameLength)
+		}
+	}
+}
+
+// attempts checks the attempts of a retried spec run and derives its status,
+// times and message from them.
+func (v *validator) attempts(path string, specRun *models.SpecRun) {
+	if len(specRun.Attempts) > v.limits.MaxAttempts {
+		v.add(path, fmt.Sprintf("must not contain more than %d attempts", v.limits.MaxAttempts))
+	}
+	valid := true
+	for k := range specRun.Attempts {
+		attempt := &specRun.Attempts[k]
+		attemptPath := fmt.Sprintf("%s[%d]", path, k)
+		valid = v.status(join(attemptPath, "status"), &attempt.Status) && valid
+		v.length(join(attemptPath, "message"), attempt.Message, v.limits.MaxMessageLength)
+		v.timeOrder(attemptPath, attempt.StartTime, attempt.EndTime)
+	}
+	if !valid {
+		return
+	}
+
+	first, last := specRun.Attempts[0], specRun.Attempts[len(specRun.Attempts)-1]
+	specRun.Status = utils.SpecOutcome(specRun.Attempts)
+	if specRun.StartTime.IsZero() {
+		specRun.StartTime = first.StartTime
+	}
+	if specRun.EndTime.IsZero() {
+		specRun.EndTime = last.EndTime
+	}
+	if specRun.Message == "" {
+		for _, attempt := range specRun.Attempts {
+			if attempt.Message != "" {
+				specRun.Message = attempt.Message
+			}
+		}
+	}
+}
+
+// status normalizes a reported status in place, reporting whether it is
+// known.
+func (v *validator) status(path string, status *string) bool {
+	if strings.TrimSpace(*status) == "" {
+		v.add(path, "is required")
+		return false
+	}
+	normalized, ok := NormalizeStatus(*status)
+	if !ok {
+		v.add(path, fmt.Sprintf("unknown status %q, expected one of passed, failed, skipped, pending, flaky or errored", *status))
+		return false
+	}
+	*status = normalized
+	return true
+}
+
+func (v *validator) failure(path string, failure *models.Failure) {
+	v.length(join(path, "message"), failure.Message, v.limits.MaxMessageLength)
+	if failure.Kind != "" && !slices.Contains(failureKinds, failure.Kind) {
+		v.add(join(path, "kind"), fmt.Sprintf("unknown kind %q, expected one of %s", failure.Kind, strings.Join(failureKinds, ", ")))
+	}
+	v.length(join(path, "file"), failure.File, v.limits.MaxNameLength)
+	if failure.Line < 0 {
+		v.add(join(path, "line"), "must not be negative")
+	}
+	v.length(join(path, "stack_trace"), failure.StackTrace, v.limits.MaxMessageLength)
+	v.length(join(path, "stdout"), failure.Stdout, v.limits.MaxOutputLength)
+	v.length(join(path, "stderr"), failure.Stderr, v.limits.MaxOutputLength)
+}
+
+// labels checks label keys against labels.KeyPattern and keeps commas out of
+// values, so that every label can be addressed by a selector.
+func (v *validator) labels(path string, labels models.Labels) {
+	if len(labels) > v.limits.MaxLabels {
+		v.add(path, fmt.Sprintf("must not contain more than %d labels", v.limits.MaxLabels))
+	}
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		labelPath := fmt.Sprintf("%s[%q]", path, key)
+		if !labelsel.KeyPattern.MatchString(key) {
+			v.add(labelPath, "key must consist of letters, digits, '_', '.', '-' or '/' and start and end with a letter or digit")
+		}
+		v.length(labelPath, key, v.limits.MaxNameLength)
+		v.length(labelPath, labels[key], v.limits.MaxNameLength)
+		if strings.Contains(labels[key], ",") {
+			v.add(labelPath, "value must not contain ','")
+		}
+	}
+}
+
+func (v *validator) required(path, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(path, "is required")
+	}
+}
+
+func (v *validator) length(path, value string, max int) {
+	if utf8.RuneCountInString(value) > max {
+		v.add(path, fmt.Sprintf("must not be longer than %d characters", max))
+	}
+}
+
+func (v *validator) timeOrder(path string, start, end time.Time) {
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		v.add(join(path, "end_time"), "must not be before start_time")
+	}
+}
+
+func (v *validator) add(path, message string) {
+	v.errors = append(v.errors, FieldError{Path: path, Message: message})
+}
+
+func (v *validator) result() error {
+	if len(v.errors) == 0 {
+		return nil
+	}
+	return v.errors
+}
+
+func join(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
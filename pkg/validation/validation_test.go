@@ -0,0 +1,219 @@
+package validation_test
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire/fern-reporter/pkg/models"
+	"github.com/guidewire/fern-reporter/pkg/validation"
+)
+
+var _ = Describe("ValidateTestRun", func() {
+	var (
+		start   time.Time
+		testRun models.TestRun
+	)
+
+	BeforeEach(func() {
+		start = time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+		testRun = models.TestRun{
+			TestProjectName: "Checkout",
+			StartTime:       start,
+			EndTime:         start.Add(time.Minute),
+			SuiteRuns: []models.SuiteRun{{
+				SuiteName: "Cart",
+				SpecRuns: []models.SpecRun{
+					{SpecDescription: "adds an item", Status: "PASS"},
+					{SpecDescription: "removes an item", Status: "Error"},
+					{SpecDescription: "empties the cart", Status: "todo"},
+				},
+			}},
+		}
+	})
+
+	It("should accept a valid run and normalize its statuses", func() {
+		Expect(validation.ValidateTestRun(&testRun)).To(Succeed())
+
+		specRuns := testRun.SuiteRuns[0].SpecRuns
+		Expect(specRuns[0].Status).To(Equal("passed"))
+		Expect(specRuns[1].Status).To(Equal("errored"))
+		Expect(specRuns[2].Status).To(Equal("pending"))
+	})
+
+	It("should list every offending field by its JSON path", func() {
+		testRun.TestProjectName = ""
+		testRun.EndTime = start.Add(-time.Second)
+		testRun.SuiteRuns[0].SpecRuns[1].Status = "exploded"
+		testRun.SuiteRuns[0].SpecRuns[2].Status = ""
+		testRun.SuiteRuns[0].SpecRuns[2].Tags = []models.Tag{{Name: " "}}
+
+		err := validation.ValidateTestRun(&testRun)
+
+		var fieldErrors validation.Errors
+		Expect(err).To(BeAssignableToTypeOf(fieldErrors))
+		fieldErrors = err.(validation.Errors)
+
+		var paths []string
+		for _, fieldError := range fieldErrors {
+			paths = append(paths, fieldError.Path)
+		}
+		Expect(paths).To(Equal([]string{
+			"test_project_name",
+			"end_time",
+			"suite_runs[0].spec_runs[1].status",
+			"suite_runs[0].spec_runs[2].status",
+			"suite_runs[0].spec_runs[2].tags[0].name",
+		}))
+		Expect(fieldErrors[2].Message).To(ContainSubstring(`unknown status "exploded"`))
+	})
+
+	It("should enforce the size limits", func() {
+		limits := validation.DefaultLimits
+		limits.MaxSpecRuns = 2
+		limits.MaxDescriptionLength = 10
+
+		err := limits.ValidateTestRun(&testRun)
+
+		Expect(err).To(MatchError(ContainSubstring("suite_runs[0].spec_runs: a test run must not contain more than 2 spec runs")))
+		Expect(err).To(MatchError(ContainSubstring("suite_runs[0].spec_runs[1].spec_description: must not be longer than 10 characters")))
+	})
+
+	It("should reject labels that cannot be selected", func() {
+		testRun.Labels = models.Labels{"team": "payments"}
+		testRun.SuiteRuns[0].Labels = models.Labels{"os version": "14"}
+		testRun.SuiteRuns[0].SpecRuns[0].Labels = models.Labels{"browser": "chrome,firefox"}
+
+		err := validation.ValidateTestRun(&testRun)
+
+		Expect(err).To(MatchError(ContainSubstring(`suite_runs[0].labels["os version"]: key must consist of`)))
+		Expect(err).To(MatchError(ContainSubstring(`suite_runs[0].spec_runs[0].labels["browser"]: value must not contain ','`)))
+		Expect(err).NotTo(MatchError(ContainSubstring(`labels["team"]`)))
+	})
+
+	It("should check the container hierarchy of spec runs", func() {
+		testRun.SuiteRuns[0].SpecRuns[0].Containers = models.Containers{{Text: "Cart", Labels: []string{"cart"}}, {Text: " "}}
+		limits := validation.DefaultLimits
+		limits.MaxContainers = 1
+
+		err := limits.ValidateTestRun(&testRun)
+
+		Expect(err).To(MatchError(ContainSubstring("suite_runs[0].spec_runs[0].containers: must not be nested more than 1 containers deep")))
+		Expect(err).To(MatchError(ContainSubstring("suite_runs[0].spec_runs[0].containers[1].text: is required")))
+		Expect(err).NotTo(MatchError(ContainSubstring("containers[0]")))
+	})
+
+	It("should check failure details and default the message to the failure message", func() {
+		testRun.SuiteRuns[0].SpecRuns[0].Failure = &models.Failure{Message: "expected 1 item", Kind: "assertion", File: "cart_test.go", Line: 42}
+		testRun.SuiteRuns[0].SpecRuns[1].Failure = &models.Failure{Kind: "meltdown", Line: -1, Stdout: strings.Repeat("a", 11)}
+		limits := validation.DefaultLimits
+		limits.MaxOutputLength = 10
+
+		err := limits.ValidateTestRun(&testRun)
+
+		Expect(err).To(MatchError(ContainSubstring(`suite_runs[0].spec_runs[1].failure.kind: unknown kind "meltdown"`)))
+		Expect(err).To(MatchError(ContainSubstring("suite_runs[0].spec_runs[1].failure.line: must not be negative")))
+		Expect(err).To(MatchError(ContainSubstring("suite_runs[0].spec_runs[1].failure.stdout: must not be longer than 10 characters")))
+		Expect(err).NotTo(MatchError(ContainSubstring("spec_runs[0].failure")))
+		Expect(testRun.SuiteRuns[0].SpecRuns[0].Message).To(Equal("expected 1 item"))
+	})
+
+	It("should derive the outcome of a retried spec run from its attempts", func() {
+		testRun.SuiteRuns[0].SpecRuns[0] = models.SpecRun{
+			SpecDescription: "adds an item",
+			Attempts: models.SpecAttempts{
+				{Status: "FAIL", Message: "timed out", StartTime: start, EndTime: start.Add(time.Second)},
+				{Status: "pass", StartTime: start.Add(time.Second), EndTime: start.Add(2 * time.Second)},
+			},
+		}
+
+		Expect(validation.ValidateTestRun(&testRun)).To(Succeed())
+
+		specRun := testRun.SuiteRuns[0].SpecRuns[0]
+		Expect(specRun.Status).To(Equal("flaky"))
+		Expect(specRun.Attempts[0].Status).To(Equal("failed"))
+		Expect(specRun.Attempts[1].Status).To(Equal("passed"))
+		Expect(specRun.StartTime).To(Equal(start))
+		Expect(specRun.EndTime).To(Equal(start.Add(2 * time.Second)))
+		Expect(specRun.Message).To(Equal("timed out"))
+	})
+
+	It("should report invalid attempts by their path", func() {
+		testRun.SuiteRuns[0].SpecRuns[0].Attempts = models.SpecAttempts{{Status: "exploded"}, {Status: "passed"}}
+
+		err := validation.ValidateTestRun(&testRun)
+
+		Expect(err).To(MatchError(ContainSubstring(`suite_runs[0].spec_runs[0].attempts[0].status: unknown status "exploded"`)))
+	})
+
+	It("should check spec runs appended to an in-progress run", func() {
+		specRuns := []models.SpecRun{{SpecDescription: strings.Repeat("a", 3), Status: "ok"}, {Status: "passed"}}
+
+		err := validation.ValidateSpecRuns(specRuns)
+
+		Expect(err).To(MatchError("[1].spec_description: is required"))
+		Expect(specRuns[0].Status).To(Equal("passed"))
+	})
+})
+
+var _ = Describe("ValidateProject", func() {
+	It("should derive the slug and display name from the name", func() {
+		project := models.Project{Name: "Checkout Service"}
+
+		Expect(validation.ValidateProject(&project)).To(Succeed())
+		Expect(project.Slug).To(Equal("checkout-service"))
+		Expect(project.DisplayName).To(Equal("Checkout Service"))
+	})
+
+	It("should reject invalid slugs and negative retention", func() {
+		project := models.Project{Name: "Checkout", Slug: "Check Out", RetentionDays: -1, RetentionRunsPerBranch: -1}
+
+		err := validation.ValidateProject(&project)
+
+		Expect(err).To(MatchError(ContainSubstring("slug: must consist of lower case letters and digits")))
+		Expect(err).To(MatchError(ContainSubstring("retention_days: must not be negative")))
+		Expect(err).To(MatchError(ContainSubstring("retention_runs_per_branch: must not be negative")))
+	})
+})
+
+var _ = Describe("ValidateOwnershipRules", func() {
+	It("should accept rules with an owner and a pattern", func() {
+		Expect(validation.ValidateOwnershipRules([]models.OwnershipRule{
+			{Suite: "Checkout*", Owner: "payments"},
+			{Tag: "slow", Owner: "performance"},
+		})).To(Succeed())
+	})
+
+	It("should reject rules without an owner or patterns", func() {
+		err := validation.ValidateOwnershipRules([]models.OwnershipRule{{Suite: "Checkout*"}, {Owner: "payments"}})
+
+		Expect(err).To(MatchError(ContainSubstring("[0].owner: is required")))
+		Expect(err).To(MatchError(ContainSubstring("[1]: must have a suite, spec, file or tag pattern")))
+	})
+})
+
+var _ = Describe("ValidateQuarantineRule", func() {
+	now := time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+
+	It("should accept a rule with a spec, a reason and a future expiry", func() {
+		Expect(validation.ValidateQuarantineRule(&models.QuarantineRule{
+			SuiteName:       "Cart",
+			SpecDescription: "adds an item",
+			Reason:          "JIRA-123",
+			ExpiresAt:       now.Add(time.Hour),
+		}, now)).To(Succeed())
+	})
+
+	It("should reject a rule without a reason or with a past expiry", func() {
+		err := validation.ValidateQuarantineRule(&models.QuarantineRule{
+			SuiteName:       "Cart",
+			SpecDescription: "adds an item",
+			ExpiresAt:       now,
+		}, now)
+
+		Expect(err).To(MatchError(ContainSubstring("reason: is required")))
+		Expect(err).To(MatchError(ContainSubstring("expires_at: must be in the future")))
+	})
+})